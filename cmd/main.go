@@ -1,23 +1,41 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
+	_ "time/tzdata" // Base de fusos horários embutida (fuso dos usuários mesmo sem tzdata no sistema)
 
 	"crm-backend/internal/config"
 	"crm-backend/internal/database"
+	"crm-backend/internal/events"
 	"crm-backend/internal/handlers"
 	"crm-backend/internal/middleware"
+	"crm-backend/internal/models"
 	"crm-backend/internal/repositories"
 	"crm-backend/internal/services"
 	"crm-backend/pkg/logger"
+	"crm-backend/pkg/mailer"
+	"crm-backend/pkg/metrics"
+	"crm-backend/pkg/phone"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
+// activityHistorySize define quantos eventos recentes por usuário ficam disponíveis para reenvio no fluxo SSE
+const activityHistorySize = 50
+
+// attachmentUploadRoute é a rota de upload de anexos, que tem limite de corpo próprio (MAX_UPLOAD_SIZE_MB)
+const attachmentUploadRoute = "/api/interactions/:id/attachments"
+
 func main() {
 	// Carregar variáveis de ambiente
 	if err := godotenv.Load(); err != nil {
@@ -25,19 +43,48 @@ func main() {
 	}
 
 	// Inicializar logger
-	logger.Init()
+	loggingConfig := config.GetLoggingConfig()
+	if err := logger.Init(logger.Options{
+		Output:     loggingConfig.Output,
+		File:       loggingConfig.File,
+		MaxSize:    loggingConfig.MaxSize,
+		MaxBackups: loggingConfig.MaxBackups,
+		MaxAge:     loggingConfig.MaxAge,
+		Compress:   loggingConfig.Compress,
+	}); err != nil {
+		log.Fatalf("Falha ao inicializar logger: %v", err)
+	}
+	defer logger.Close()
+	logger.SetFormat(loggingConfig.Format)
 	logger.Info("Iniciando aplicação CRM Backend")
 
 	// Carregar configurações
 	cfg := config.Load()
 	logger.Infof("Configurações carregadas - Environment: %s", cfg.Environment)
 
+	if err := cfg.ValidateTrustedProxies(); err != nil {
+		logger.Fatal("Configuração inválida:", err)
+	}
+
+	if !phone.IsKnownRegion(cfg.DefaultPhoneRegion) {
+		logger.Warning("DEFAULT_PHONE_REGION desconhecida - telefones sem código do país serão armazenados apenas com os dígitos")
+	}
+
+	// Tamanhos de página compartilhados por todas as listagens
+	models.ConfigurePageSize(cfg.DefaultPageSize, cfg.MaxPageSize)
+	models.ConfigureMaxOffset(cfg.MaxOffset)
+
 	// Conectar ao banco de dados
-	db, err := database.Connect(cfg.DatabaseURL)
+	db, err := database.Connect(cfg.DatabaseURL, cfg.DBPool)
 	if err != nil {
 		logger.Fatal("Falha ao conectar com o banco de dados:", err)
 	}
 	logger.Info("Conexão com banco de dados estabelecida")
+	logger.WithFields("INFO", "Database Pool Configured", map[string]interface{}{
+		"max_open_conns":    cfg.DBPool.MaxOpenConns,
+		"max_idle_conns":    cfg.DBPool.MaxIdleConns,
+		"conn_max_lifetime": cfg.DBPool.ConnMaxLifetime.String(),
+	})
 
 	// Executar migrações
 	if err := database.Migrate(db); err != nil {
@@ -45,20 +92,69 @@ func main() {
 	}
 	logger.Info("Migrações executadas com sucesso")
 
+	// Registrar métricas de requisições e consultas ao banco (apenas com METRICS_ADDR)
+	var metricsRegistry *metrics.Registry
+	if cfg.MetricsAddr != "" {
+		metricsRegistry = metrics.NewRegistry()
+		if err := database.RegisterMetrics(db, metricsRegistry); err != nil {
+			logger.Fatal("Falha ao registrar métricas do banco de dados:", err)
+		}
+	}
+
 	// Inicializar repositórios
 	userRepo := repositories.NewUserRepository(db)
 	contactRepo := repositories.NewContactRepository(db)
 	interactionRepo := repositories.NewInteractionRepository(db)
 	taskRepo := repositories.NewTaskRepository(db)
 	projectRepo := repositories.NewProjectRepository(db)
+	attachmentRepo := repositories.NewAttachmentRepository(db)
+	passwordResetRepo := repositories.NewPasswordResetRepository(db)
+	sharedResourceRepo := repositories.NewSharedResourceRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	auditLogRepo := repositories.NewAuditLogRepository(db)
+	tagRepo := repositories.NewTagRepository(db)
+	transactor := repositories.NewTransactor(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
+	retentionRepo := repositories.NewRetentionRepository(db)
+	importantDateRepo := repositories.NewImportantDateRepository(db)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+	taskCommentRepo := repositories.NewTaskCommentRepository(db)
+
+	// Inicializar barramento de eventos de atividades
+	eventBus := events.NewBus(activityHistorySize)
+
+	// Inicializar envio de emails
+	var emailSender mailer.Mailer = mailer.NewLogMailer()
+	if cfg.SMTPHost != "" {
+		emailSender = mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+	}
+
+	// Registros de outros usuários: 403 (padrão) ou 404, sem revelar quais IDs existem
+	accessPolicy := services.AccessPolicy{HideOwnership: cfg.HideOwnership}
 
 	// Inicializar serviços
+	auditService := services.NewAuditService(auditLogRepo)
 	authService := services.NewAuthService(userRepo, cfg.JWTSecret)
-	userService := services.NewUserService(userRepo, contactRepo, taskRepo, projectRepo, interactionRepo)
-	contactService := services.NewContactService(contactRepo, interactionRepo, taskRepo, projectRepo)
-	interactionService := services.NewInteractionService(interactionRepo, contactRepo)
-	taskService := services.NewTaskService(taskRepo, contactRepo, projectRepo)
-	projectService := services.NewProjectService(projectRepo, contactRepo, taskRepo)
+	userService := services.NewUserService(userRepo, contactRepo, taskRepo, projectRepo, interactionRepo, eventBus, cfg.RecentInteractionsDays, cfg.RecentActivitiesDays)
+	contactService := services.NewContactService(contactRepo, interactionRepo, taskRepo, projectRepo, tagRepo, userRepo, transactor, eventBus, cfg.DefaultPhoneRegion, cfg.MaxContactsPerUser, auditService, accessPolicy)
+	interactionService := services.NewInteractionService(interactionRepo, contactRepo, taskRepo, userRepo, eventBus, cfg.InteractionFutureTolerance, cfg.RecentInteractionsDays, auditService, accessPolicy)
+	taskService := services.NewTaskService(taskRepo, contactRepo, projectRepo, sharedResourceRepo, userRepo, transactor, eventBus, cfg.StrictTaskAssociations, auditService, accessPolicy)
+	projectService := services.NewProjectService(projectRepo, contactRepo, taskRepo, sharedResourceRepo, transactor, eventBus, cfg.MaxProjectsPerUser, auditService, accessPolicy)
+	searchService := services.NewSearchService(contactRepo, taskRepo, projectRepo, interactionRepo)
+	notificationService := services.NewNotificationService(notificationRepo, taskRepo, accessPolicy)
+	sharingService := services.NewSharingService(sharedResourceRepo, projectRepo, userRepo, accessPolicy)
+	passwordResetService := services.NewPasswordResetService(userRepo, passwordResetRepo, emailSender, cfg.PasswordResetURL)
+	attachmentService := services.NewAttachmentService(attachmentRepo, interactionRepo, cfg.UploadDir, cfg.MaxUploadSize, accessPolicy)
+	webhookService := services.NewWebhookService(webhookRepo, accessPolicy)
+	importantDateService := services.NewImportantDateService(importantDateRepo, contactRepo, userRepo, accessPolicy)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo, accessPolicy)
+	taskCommentService := services.NewTaskCommentService(taskCommentRepo, taskRepo, accessPolicy)
 
 	// Inicializar handlers
 	authHandler := handlers.NewAuthHandler(authService)
@@ -67,6 +163,43 @@ func main() {
 	interactionHandler := handlers.NewInteractionHandler(interactionService)
 	taskHandler := handlers.NewTaskHandler(taskService)
 	projectHandler := handlers.NewProjectHandler(projectService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	passwordResetHandler := handlers.NewPasswordResetHandler(passwordResetService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	sharingHandler := handlers.NewSharingHandler(sharingService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	importantDateHandler := handlers.NewImportantDateHandler(importantDateService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	taskCommentHandler := handlers.NewTaskCommentHandler(taskCommentService)
+	healthHandler := handlers.NewHealthHandler(db, cfg.Version)
+
+	// Contexto dos processos em segundo plano, cancelado no encerramento do servidor
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	// Iniciar varredura periódica de tarefas próximas do vencimento
+	notificationService.StartDueSoonScanner(backgroundCtx, cfg.NotificationScanInterval)
+	logger.Infof("Varredura de notificações iniciada - intervalo: %s", cfg.NotificationScanInterval)
+
+	// Entregar os eventos do barramento aos webhooks cadastrados
+	webhookDispatcher := services.NewWebhookDispatcher(webhookRepo, cfg.WebhookTimeout, cfg.WebhookMaxRetries)
+	eventBus.AddListener(webhookDispatcher.Enqueue)
+	webhookDispatcher.Start(backgroundCtx)
+
+	// Remover definitivamente, uma vez por dia, os registros excluídos há mais que o período de retenção
+	// (a limpeza sob demanda da rota administrativa exige apenas o período de retenção)
+	var retentionService services.RetentionService
+	if cfg.SoftDeleteRetentionDays > 0 {
+		retentionService = services.NewRetentionService(retentionRepo, cfg.SoftDeleteRetentionDays)
+	}
+	if cfg.SoftDeletePurgeEnabled && retentionService != nil {
+		retentionService.StartPurgeJob(backgroundCtx)
+		logger.Infof("Limpeza de registros excluídos iniciada - retenção: %d dias", cfg.SoftDeleteRetentionDays)
+	} else {
+		logger.Info("Limpeza de registros excluídos desativada")
+	}
 
 	// Configurar Gin
 	if cfg.Environment == "production" {
@@ -75,23 +208,53 @@ func main() {
 
 	router := gin.Default()
 
-	config := cors.Config{
-		AllowOrigins:     []string{"http://localhost:5173", "http://localhost:3000", "http://localhost:4200"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Content-Length", "Authorization", "Accept"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+	// IP do cliente: cabeçalhos de encaminhamento só são aceitos dos proxies em TRUSTED_PROXIES
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		logger.Fatal("Falha ao configurar TRUSTED_PROXIES:", err)
 	}
+	if len(cfg.TrustedProxies) == 0 && cfg.Environment == "production" {
+		logger.Warning("TRUSTED_PROXIES não definido em produção - atrás de um balanceador de carga, o IP registrado será o do proxy")
+	}
+
+	// Configurar CORS a partir de CORS_ALLOWED_ORIGINS
+	if len(cfg.CORSAllowedOrigins) == 0 {
+		logger.Warning("CORS_ALLOWED_ORIGINS não definido em produção - requisições de outras origens serão bloqueadas")
+	} else {
+		corsConfig := cors.Config{
+			AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowHeaders:  []string{"Origin", "Content-Type", "Content-Length", "Authorization", "Accept", "If-None-Match", middleware.IdempotencyKeyHeader, middleware.RequestIDHeader},
+			ExposeHeaders: []string{"Content-Length", "ETag", middleware.IdempotentReplayedHeader, middleware.RequestIDHeader},
+			MaxAge:        12 * time.Hour,
+		}
+		if cfg.AllowsAllOrigins() {
+			// A especificação não permite credenciais com origem curinga
+			corsConfig.AllowAllOrigins = true
+			corsConfig.AllowCredentials = false
+		} else {
+			corsConfig.AllowOrigins = cfg.CORSAllowedOrigins
+			corsConfig.AllowCredentials = true
+		}
 
-	router.Use(cors.New(config))
+		router.Use(cors.New(corsConfig))
+		logger.Infof("CORS configurado - origens: %s", strings.Join(cfg.CORSAllowedOrigins, ", "))
+	}
 
-	// Middleware global
-	router.Use(middleware.CustomLogger()) // Usar o logger personalizado
+	// Middleware global (RequestID antes do logger para que o ID esteja disponível em todos os logs)
+	router.Use(middleware.RequestID())
+	if metricsRegistry != nil {
+		router.Use(middleware.Metrics(metricsRegistry))
+	}
+	router.Use(middleware.CustomLogger(loggingConfig)) // Usar o logger personalizado
 	router.Use(middleware.ErrorHandler())
+	// Limite do corpo das requisições; o upload de anexos aplica o próprio limite (MAX_UPLOAD_SIZE_MB)
+	router.Use(middleware.BodyLimit(cfg.MaxBodyBytes, attachmentUploadRoute))
 
 	logger.Info("Middlewares configurados")
 
+	// Rotas de verificação de saúde (fora de /api e sem autenticação)
+	router.GET("/health", healthHandler.Live)
+	router.GET("/health/ready", healthHandler.Ready)
+
 	// Agrupar todas as rotas sob /api
 	api := router.Group("/api")
 	{
@@ -100,13 +263,17 @@ func main() {
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/forgot-password", passwordResetHandler.ForgotPassword)
+			auth.POST("/reset-password", passwordResetHandler.ResetPassword)
 			auth.GET("/validate", middleware.AuthMiddleware(cfg.JWTSecret), authHandler.ValidateToken)
 			auth.POST("/logout", middleware.AuthMiddleware(cfg.JWTSecret), authHandler.Logout)
 		}
 
 		// Rotas protegidas (agora como subgrupo de /api)
 		protected := api.Group("/")
-		protected.Use(middleware.AuthMiddleware(cfg.JWTSecret))
+		// Autenticação pelo token JWT (Authorization: Bearer) ou por chave de API (X-API-Key)
+		protected.Use(middleware.APIKeyAuth(apiKeyService, middleware.AuthMiddleware(cfg.JWTSecret)))
+		protected.Use(middleware.Idempotency(middleware.NewIdempotencyStore(cfg.IdempotencyTTL))) // Apenas POST com Idempotency-Key
 		{
 			// Rotas de usuários
 			users := protected.Group("/users")
@@ -116,8 +283,15 @@ func main() {
 				users.PUT("/change-password", userHandler.ChangePassword)
 				users.DELETE("/delete-account", userHandler.DeleteAccount)
 				users.GET("/stats", userHandler.GetStats)
+				users.GET("/stats/conversion", userHandler.GetConversionStats)
 				users.GET("/activities", userHandler.GetRecentActivities)
+				users.GET("/activities/stream", userHandler.StreamActivities)
 				users.GET("/dashboard", userHandler.GetDashboardData)
+				users.GET("/digest", userHandler.GetDailyDigest)
+				users.GET("/export", userHandler.ExportAllData)
+				users.POST("/api-keys", apiKeyHandler.Create)
+				users.GET("/api-keys", apiKeyHandler.List)
+				users.DELETE("/api-keys/:id", apiKeyHandler.Delete)
 			}
 
 			// Rotas de contatos
@@ -125,12 +299,30 @@ func main() {
 			{
 				contacts.POST("/create", contactHandler.Create)
 				contacts.GET("/list", contactHandler.List)
+				contacts.GET("/export", contactHandler.Export)
+				contacts.GET("/trash", contactHandler.Trash)
+				contacts.GET("/stale", contactHandler.Stale)
+				contacts.GET("/follow-up", contactHandler.FollowUp)
+				contacts.GET("/duplicates", contactHandler.Duplicates)
+				contacts.GET("/dates/upcoming", importantDateHandler.Upcoming)
+				contacts.PUT("/batch/type", contactHandler.BatchUpdateType)
 				contacts.GET("/:id", contactHandler.GetByID)
 				contacts.PUT("/:id", contactHandler.Update)
 				contacts.DELETE("/:id", contactHandler.Delete)
+				contacts.PUT("/:id/convert-to-client", contactHandler.ConvertToClient)
+				contacts.POST("/:id/restore", contactHandler.Restore)
+				contacts.DELETE("/:id/purge", contactHandler.Purge)
+				contacts.POST("/:id/touch", contactHandler.Touch)
+				contacts.GET("/:id/report", contactHandler.Report)
+				contacts.POST("/:id/tags", contactHandler.AddTags)
+				contacts.DELETE("/:id/tags/:tag", contactHandler.RemoveTag)
+				contacts.POST("/:id/dates", importantDateHandler.Create)
+				contacts.GET("/:id/dates", importantDateHandler.ListByContact)
 
 				contacts.POST("/:id/interactions", interactionHandler.Create)
 				contacts.GET("/:id/interactions", interactionHandler.ListByContact)
+				contacts.GET("/:id/all-tasks", taskHandler.GetAllByContact)
+				contacts.GET("/:id/tasks/overdue", taskHandler.GetOverdueByContact)
 			}
 
 			// Rotas de tarefas
@@ -138,11 +330,20 @@ func main() {
 			{
 				tasks.POST("/create", taskHandler.Create)
 				tasks.GET("/list", taskHandler.List)
+				tasks.GET("/stats", taskHandler.GetStats)
+				tasks.GET("/calendar", taskHandler.GetCalendar)
+				tasks.GET("/my-day", taskHandler.MyDay)
+				tasks.DELETE("/completed", taskHandler.DeleteCompleted)
 				tasks.GET("/:id", taskHandler.GetByID)
 				tasks.PUT("/:id", taskHandler.Update)
 				tasks.DELETE("/:id", taskHandler.Delete)
+				tasks.POST("/:id/restore", taskHandler.Restore)
 				tasks.PUT("/:id/complete", taskHandler.MarkTaskAsCompleted)
 				tasks.PUT("/:id/uncomplete", taskHandler.MarkTaskAsPending)
+				tasks.PUT("/:id/start", taskHandler.MarkTaskAsInProgress)
+				tasks.PUT("/:id/snooze", taskHandler.Snooze)
+				tasks.POST("/:id/comments", taskCommentHandler.Create)
+				tasks.GET("/:id/comments", taskCommentHandler.List)
 			}
 
 			// Rotas de projetos
@@ -150,36 +351,155 @@ func main() {
 			{
 				projects.POST("/create", projectHandler.Create)
 				projects.GET("/list", projectHandler.List)
+				projects.GET("/shared", sharingHandler.ListSharedProjects)
+				projects.GET("/board", projectHandler.GetBoard)
 				projects.GET("/list/:id", projectHandler.GetByID)
 				projects.PUT("/:id", projectHandler.Update)
 				projects.DELETE("/:id", projectHandler.Delete)
+				projects.POST("/:id/restore", projectHandler.Restore)
+				projects.POST("/:id/duplicate", projectHandler.Duplicate)
+				projects.POST("/:id/shares", sharingHandler.ShareProject)
+				projects.GET("/:id/shares", sharingHandler.ListProjectShares)
+				projects.DELETE("/:id/shares/:shareId", sharingHandler.RevokeProjectShare)
+				projects.GET("/:id/tasks/overdue", taskHandler.GetOverdueByProject)
 			}
 
 			// Rotas de interações (globais)
 			interactions := protected.Group("/interactions")
 			{
+				interactions.POST("", interactionHandler.CreateByContactEmail)
+				interactions.GET("", interactionHandler.List)
 				interactions.GET("/list", interactionHandler.List)
+				interactions.GET("/recent", interactionHandler.GetRecent)
+				interactions.GET("/recent/count", interactionHandler.GetRecentInteractionsCount)
 				interactions.GET("/:id", interactionHandler.GetByID)
+				interactions.GET("/:id/context", interactionHandler.GetWithContext)
 				interactions.PUT("/:id", interactionHandler.Update)
 				interactions.DELETE("/:id", interactionHandler.Delete)
+				interactions.POST("/:id/attachments", attachmentHandler.Upload)
+				interactions.GET("/:id/attachments", attachmentHandler.List)
+			}
+
+			// Rotas de notificações
+			notifications := protected.Group("/notifications")
+			{
+				notifications.GET("", notificationHandler.List)
+				notifications.PUT("/:id/read", notificationHandler.MarkAsRead)
+			}
+
+			// Rotas de webhooks
+			webhooks := protected.Group("/webhooks")
+			{
+				webhooks.POST("/create", webhookHandler.Create)
+				webhooks.GET("/list", webhookHandler.List)
+				webhooks.GET("/:id", webhookHandler.GetByID)
+				webhooks.PUT("/:id", webhookHandler.Update)
+				webhooks.DELETE("/:id", webhookHandler.Delete)
+			}
+
+			// Rota de busca global
+			protected.GET("/search", searchHandler.Search)
+
+			// Rota do log de auditoria
+			protected.GET("/audit", auditHandler.List)
+
+			// Rotas administrativas (apenas ADMIN). Sem a claim role no token, o papel é consultado no banco
+			admin := protected.Group("/admin")
+			admin.Use(middleware.RequireRole(userService, models.RoleAdmin))
+			{
+				if retentionService != nil {
+					admin.POST("/retention/purge", handlers.NewRetentionHandler(retentionService).Purge)
+				}
 			}
 		}
 
-		// Iniciar servidor
-		port := os.Getenv("PORT")
-		if port == "" {
-			port = "8080"
+		// Iniciar servidor (TCP em HOST:PORT ou socket unix em UNIX_SOCKET)
+		network, address := "tcp", net.JoinHostPort(cfg.Host, cfg.Port)
+		if cfg.UnixSocket != "" {
+			network, address = "unix", cfg.UnixSocket
+
+			// Remover socket deixado por uma execução anterior encerrada sem limpeza
+			if err := os.Remove(address); err != nil && !os.IsNotExist(err) {
+				logger.Fatal("Falha ao remover socket unix existente:", err)
+			}
+		}
+
+		listener, err := net.Listen(network, address)
+		if err != nil {
+			logger.Fatal("Falha ao iniciar servidor:", err)
 		}
 
-		logger.Infof("Servidor iniciando na porta %s", port)
+		logger.Infof("Servidor iniciando em %s", address)
 		logger.WithFields("INFO", "Server Starting", map[string]interface{}{
-			"port":        port,
+			"network":     network,
+			"address":     address,
 			"environment": cfg.Environment,
-			"address":     "0.0.0.0:" + port,
 		})
 
-		if err := router.Run("0.0.0.0:" + port); err != nil {
-			logger.Fatal("Falha ao iniciar servidor:", err)
+		server := &http.Server{Handler: router}
+		// Shutdown não cancela o contexto das requisições: encerrar os fluxos de atividades
+		// abertos para que não segurem o desligamento até o fim do prazo
+		server.RegisterOnShutdown(eventBus.Close)
+
+		go func() {
+			if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Falha ao iniciar servidor:", err)
+			}
+		}()
+
+		// Métricas do Prometheus em um listener separado, fora do alcance do tráfego público
+		var metricsServer *http.Server
+		if metricsRegistry != nil {
+			metricsMux := http.NewServeMux()
+			metricsMux.Handle("/metrics", metricsRegistry.Handler())
+			metricsServer = &http.Server{Addr: cfg.MetricsAddr, Handler: metricsMux}
+
+			go func() {
+				if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					logger.Fatal("Falha ao iniciar servidor de métricas:", err)
+				}
+			}()
+			logger.Infof("Métricas disponíveis em http://%s/metrics", cfg.MetricsAddr)
+		}
+
+		// Aguardar sinal de encerramento (SIGINT/SIGTERM)
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+		sig := <-quit
+
+		logger.WithFields("INFO", "Server Shutting Down", map[string]interface{}{
+			"signal":  sig.String(),
+			"timeout": cfg.ShutdownTimeout.String(),
+		})
+
+		// Parar de aceitar conexões e aguardar as requisições em andamento dentro do prazo
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Erro ao encerrar servidor:", err)
+		} else {
+			logger.Info("Servidor encerrado - requisições em andamento concluídas")
+		}
+		if metricsServer != nil {
+			if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+				logger.Error("Erro ao encerrar servidor de métricas:", err)
+			}
+		}
+
+		if cfg.UnixSocket != "" {
+			if err := os.Remove(cfg.UnixSocket); err != nil && !os.IsNotExist(err) {
+				logger.Error("Erro ao remover socket unix:", err)
+			}
 		}
+
+		stopBackground()
+
+		if err := database.Close(db); err != nil {
+			logger.Error("Erro ao fechar conexão com o banco de dados:", err)
+		} else {
+			logger.Info("Conexão com banco de dados encerrada")
+		}
+
+		logger.Info("Aplicação encerrada")
 	}
 }
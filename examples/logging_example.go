@@ -8,7 +8,7 @@
 
 func main() {
 	// Inicializar logger
-	logger.Init()
+	logger.Init(logger.Options{})
 	logger.InitStructuredLogger()
 
 	// Exemplo de logs básicos
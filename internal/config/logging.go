@@ -11,6 +11,7 @@ type LoggingConfig struct {
 	Level      string
 	Format     string
 	Output     string
+	File       string
 	MaxSize    int // Em MB
 	MaxBackups int
 	MaxAge     int // Em dias
@@ -23,6 +24,7 @@ func GetLoggingConfig() *LoggingConfig {
 		Level:      getEnvOrDefault("LOG_LEVEL", "INFO"),
 		Format:     getEnvOrDefault("LOG_FORMAT", "text"),   // text ou json
 		Output:     getEnvOrDefault("LOG_OUTPUT", "stdout"), // stdout, file, ou both
+		File:       getEnvOrDefault("LOG_FILE", "logs/app.log"),
 		MaxSize:    getIntEnvOrDefault("LOG_MAX_SIZE", 10),
 		MaxBackups: getIntEnvOrDefault("LOG_MAX_BACKUPS", 5),
 		MaxAge:     getIntEnvOrDefault("LOG_MAX_AGE", 30),
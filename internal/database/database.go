@@ -1,6 +1,7 @@
 package database
 
 import (
+	"crm-backend/internal/config"
 	"crm-backend/internal/models"
 
 	"gorm.io/driver/postgres"
@@ -8,26 +9,58 @@
 	"gorm.io/gorm/logger"
 )
 
-// Connect estabelece conexão com o banco de dados
-func Connect(databaseURL string) (*gorm.DB, error) {
+// Connect estabelece conexão com o banco de dados, aplica os limites do pool de conexões e
+// normaliza todos os horários para UTC
+func Connect(databaseURL string, pool config.DBPoolConfig) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:  logger.Default.LogMode(logger.Info),
+		NowFunc: utcNow,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if err := RegisterUTCTimestamps(db); err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
 
 	return db, nil
 }
 
+// Close encerra o pool de conexões com o banco de dados
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
 // Migrate executa as migrações do banco de dados
 func Migrate(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&models.User{},
+		&models.Tag{},
 		&models.Contact{},
 		&models.Interaction{},
 		&models.Task{},
 		&models.Project{},
+		&models.Attachment{},
+		&models.PasswordReset{},
+		&models.SharedResource{},
+		&models.Notification{},
+		&models.AuditLog{},
+		&models.Webhook{},
+		&models.ImportantDate{},
+		&models.APIKey{},
+		&models.TaskComment{},
 	)
 }
 
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"crm-backend/pkg/metrics"
+
+	"gorm.io/gorm"
+)
+
+// RegisterMetrics registra callbacks do GORM que contam as consultas executadas por operação
+func RegisterMetrics(db *gorm.DB, registry *metrics.Registry) error {
+	count := func(operation string) func(*gorm.DB) {
+		return func(*gorm.DB) {
+			registry.IncDBQuery(operation)
+		}
+	}
+
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("gorm:create").Register("metrics:create", count("create")); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register("metrics:query", count("query")); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("metrics:update", count("update")); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("metrics:delete", count("delete")); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register("metrics:row", count("row")); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("metrics:raw", count("raw"))
+}
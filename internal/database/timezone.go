@@ -0,0 +1,80 @@
+package database
+
+import (
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// utcNow é a fonte de horário do GORM (created_at, updated_at, deleted_at), sempre em UTC
+func utcNow() time.Time {
+	return time.Now().UTC()
+}
+
+// RegisterUTCTimestamps registra callbacks do GORM que convertem para UTC os campos de data/hora
+// dos modelos lidos do banco e dos modelos gravados (que são devolvidos pela API após a criação ou
+// atualização). Assim todos os horários são serializados em RFC3339 com o sufixo "Z",
+// independentemente do fuso do servidor, da sessão do banco ou do horário enviado pelo cliente.
+func RegisterUTCTimestamps(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Query().After("gorm:query").Register("utc:query", normalizeTimestamps); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("utc:create", normalizeTimestamps); err != nil {
+		return err
+	}
+	return callbacks.Update().After("gorm:update").Register("utc:update", normalizeTimestamps)
+}
+
+// normalizeTimestamps converte para UTC os campos de data/hora do resultado da operação
+func normalizeTimestamps(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || !stmt.ReflectValue.IsValid() {
+		return
+	}
+
+	value := reflect.Indirect(stmt.ReflectValue)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			timestampsToUTC(stmt, reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		timestampsToUTC(stmt, value)
+	}
+}
+
+// timestampsToUTC converte os campos time.Time, *time.Time e gorm.DeletedAt de um modelo para UTC.
+// Destinos de outro tipo (ex.: Scan em um DTO) são ignorados.
+func timestampsToUTC(stmt *gorm.Statement, value reflect.Value) {
+	if value.Kind() != reflect.Struct || value.Type() != stmt.Schema.ModelType || !value.CanAddr() {
+		return
+	}
+
+	for _, field := range stmt.Schema.Fields {
+		if !isTimeField(field) {
+			continue
+		}
+
+		switch t := field.ReflectValueOf(stmt.Context, value).Addr().Interface().(type) {
+		case *time.Time:
+			*t = t.UTC()
+		case **time.Time:
+			if *t != nil {
+				utc := (*t).UTC()
+				*t = &utc
+			}
+		case *gorm.DeletedAt:
+			if t.Valid {
+				t.Time = t.Time.UTC()
+			}
+		}
+	}
+}
+
+// isTimeField verifica se o campo do modelo armazena data/hora
+func isTimeField(field *schema.Field) bool {
+	return field.DataType == schema.Time || field.FieldType == reflect.TypeOf(gorm.DeletedAt{})
+}
@@ -0,0 +1,154 @@
+package events
+
+import (
+	"crm-backend/internal/models"
+	"sync"
+)
+
+// subscriberBufferSize define quantos eventos podem ficar pendentes por assinante antes de serem descartados
+const subscriberBufferSize = 32
+
+// Event representa uma atividade publicada no barramento de eventos
+type Event struct {
+	ID       uint64              `json:"id"`
+	UserID   uint                `json:"user_id"`
+	Activity models.UserActivity `json:"activity"`
+}
+
+// Publisher define a interface para publicação de atividades
+type Publisher interface {
+	Publish(userID uint, activity models.UserActivity)
+}
+
+// Bus é um barramento de eventos em memória, com assinaturas por usuário
+// e um histórico curto para reenvio a clientes que se reconectam
+type Bus struct {
+	mu          sync.RWMutex
+	nextID      uint64
+	historySize int
+	history     map[uint][]Event
+	subscribers map[uint]map[chan Event]struct{}
+	listeners   []func(Event)
+	closed      bool
+}
+
+// NewBus cria um novo barramento mantendo os últimos historySize eventos de cada usuário
+func NewBus(historySize int) *Bus {
+	return &Bus{
+		historySize: historySize,
+		history:     make(map[uint][]Event),
+		subscribers: make(map[uint]map[chan Event]struct{}),
+	}
+}
+
+// AddListener registra uma função chamada para todos os eventos publicados, de qualquer usuário.
+// A função é chamada de forma síncrona na publicação e não deve bloquear.
+func (b *Bus) AddListener(listener func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.listeners = append(b.listeners, listener)
+}
+
+// Publish publica uma atividade para os assinantes do usuário e para os ouvintes globais
+func (b *Bus) Publish(userID uint, activity models.UserActivity) {
+	event, listeners := b.publish(userID, activity)
+
+	// Ouvintes fora do lock, para que possam consultar o barramento
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
+
+// publish registra o evento e o entrega aos assinantes do usuário, retornando os ouvintes globais
+func (b *Bus) publish(userID uint, activity models.UserActivity) (Event, []func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	event := Event{
+		ID:       b.nextID,
+		UserID:   userID,
+		Activity: activity,
+	}
+
+	// Guardar no histórico do usuário
+	if b.historySize > 0 {
+		history := append(b.history[userID], event)
+		if len(history) > b.historySize {
+			history = history[len(history)-b.historySize:]
+		}
+		b.history[userID] = history
+	}
+
+	// Entregar sem bloquear: assinantes lentos perdem eventos em vez de travar os serviços
+	for ch := range b.subscribers[userID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+
+	return event, b.listeners
+}
+
+// Subscribe registra um assinante para os eventos do usuário.
+// Retorna os eventos do histórico posteriores a lastEventID, o canal de novos eventos
+// e a função para cancelar a assinatura.
+func (b *Bus) Subscribe(userID uint, lastEventID uint64) ([]Event, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	replay := []Event{}
+	for _, event := range b.history[userID] {
+		if event.ID > lastEventID {
+			replay = append(replay, event)
+		}
+	}
+
+	ch := make(chan Event, subscriberBufferSize)
+	if b.closed {
+		// Barramento encerrado: o assinante recebe apenas o histórico e o canal já fechado
+		close(ch)
+		return replay, ch, func() {}
+	}
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[chan Event]struct{})
+	}
+	b.subscribers[userID][ch] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			// O canal já foi fechado por Close
+			if _, ok := b.subscribers[userID][ch]; !ok {
+				return
+			}
+			delete(b.subscribers[userID], ch)
+			if len(b.subscribers[userID]) == 0 {
+				delete(b.subscribers, userID)
+			}
+			close(ch)
+		})
+	}
+
+	return replay, ch, unsubscribe
+}
+
+// Close fecha os canais de todos os assinantes, encerrando os fluxos abertos (ex.: SSE) no
+// desligamento do servidor. Publicações posteriores continuam chegando aos ouvintes globais.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for userID, channels := range b.subscribers {
+		for ch := range channels {
+			close(ch)
+		}
+		delete(b.subscribers, userID)
+	}
+}
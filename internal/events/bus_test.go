@@ -0,0 +1,75 @@
+package events
+
+import (
+	"crm-backend/internal/models"
+	"testing"
+	"time"
+)
+
+// receive lê um evento do canal ou falha se nada chegar a tempo
+func receive(t *testing.T, ch <-chan Event) (Event, bool) {
+	t.Helper()
+	select {
+	case event, ok := <-ch:
+		return event, ok
+	case <-time.After(time.Second):
+		t.Fatal("nenhum evento recebido")
+		return Event{}, false
+	}
+}
+
+func TestBusCloseEndsSubscriptions(t *testing.T) {
+	bus := NewBus(10)
+	bus.Publish(1, models.UserActivity{Action: models.ActionCreated})
+
+	_, stream, unsubscribe := bus.Subscribe(1, 0)
+	_, other, unsubscribeOther := bus.Subscribe(2, 0)
+	defer unsubscribeOther()
+
+	bus.Close()
+
+	if _, ok := receive(t, stream); ok {
+		t.Fatal("o canal do assinante deveria estar fechado")
+	}
+	if _, ok := receive(t, other); ok {
+		t.Fatal("o canal de outro usuário deveria estar fechado")
+	}
+
+	// Cancelar depois do Close não fecha o canal de novo
+	unsubscribe()
+
+	// Publicar depois do Close não entrega a canais fechados e ainda alcança os ouvintes
+	var delivered int
+	bus.AddListener(func(Event) { delivered++ })
+	bus.Publish(1, models.UserActivity{Action: models.ActionUpdated})
+	if delivered != 1 {
+		t.Fatalf("ouvintes chamados %d vezes, want 1", delivered)
+	}
+
+	// Novas assinaturas recebem o histórico e um canal já fechado
+	replay, late, unsubscribeLate := bus.Subscribe(1, 0)
+	defer unsubscribeLate()
+	if len(replay) != 2 {
+		t.Fatalf("replay = %d eventos, want 2", len(replay))
+	}
+	if _, ok := receive(t, late); ok {
+		t.Fatal("assinaturas após o Close deveriam receber um canal fechado")
+	}
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	bus := NewBus(0)
+	_, stream, unsubscribe := bus.Subscribe(1, 0)
+
+	bus.Publish(1, models.UserActivity{Action: models.ActionCreated})
+	if event, ok := receive(t, stream); !ok || event.Activity.Action != models.ActionCreated {
+		t.Fatalf("evento = %+v, want CREATED", event)
+	}
+
+	unsubscribe()
+	unsubscribe()
+	if _, ok := receive(t, stream); ok {
+		t.Fatal("o canal deveria estar fechado após cancelar a assinatura")
+	}
+	bus.Close()
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler gerencia as rotas de chaves de API
+type APIKeyHandler struct {
+	apiKeyService services.APIKeyService
+}
+
+// NewAPIKeyHandler cria uma nova instância do handler de chaves de API
+func NewAPIKeyHandler(apiKeyService services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// Create gera uma nova chave de API
+// @Summary Criar chave de API
+// @Description Gera uma chave de API para integrações servidor-a-servidor, enviada no cabeçalho X-API-Key no lugar do token JWT. A chave é retornada apenas nesta resposta; somente o hash é armazenado.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body models.APIKeyCreateRequest true "Nome da chave"
+// @Success 201 {object} models.APIKeyCreateResponse
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/users/api-keys [post]
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req models.APIKeyCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados inválidos: " + err.Error()))
+		return
+	}
+
+	apiKey, err := h.apiKeyService.Create(userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, apiKey)
+}
+
+// List lista as chaves de API do usuário
+// @Summary Listar chaves de API
+// @Description Lista as chaves de API do usuário, sem os valores das chaves
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.APIKey
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/users/api-keys [get]
+func (h *APIKeyHandler) List(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	apiKeys, err := h.apiKeyService.GetByUserID(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, apiKeys)
+}
+
+// Delete revoga uma chave de API
+// @Summary Revogar chave de API
+// @Description Revoga uma chave de API do usuário; requisições com ela passam a receber 401
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID da chave de API"
+// @Success 204 "Chave revogada"
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Chave de API não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/users/api-keys/{id} [delete]
+func (h *APIKeyHandler) Delete(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	keyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da chave de API inválido"))
+		return
+	}
+
+	if err := h.apiKeyService.Delete(userID, uint(keyID)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
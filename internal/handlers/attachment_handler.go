@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	stderrors "errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead reserva espaço para os cabeçalhos do formulário multipart além do próprio arquivo
+const multipartOverhead = 1 << 20
+
+// AttachmentHandler gerencia as rotas de anexos de interações
+type AttachmentHandler struct {
+	attachmentService services.AttachmentService
+}
+
+// NewAttachmentHandler cria uma nova instância do handler de anexos
+func NewAttachmentHandler(attachmentService services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+	}
+}
+
+// Upload anexa um arquivo a uma interação
+// @Summary Anexar arquivo à interação
+// @Description Envia um arquivo (multipart, campo "file") e o anexa à interação. Tipos aceitos: PDF, PNG, JPEG, GIF, WebP e texto
+// @Tags interactions
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "ID da interação"
+// @Param file formData file true "Arquivo a ser anexado"
+// @Success 201 {object} models.Attachment
+// @Failure 400 {object} map[string]interface{} "Arquivo inválido ou tipo não permitido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Interação não encontrada"
+// @Failure 413 {object} map[string]interface{} "Arquivo muito grande"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/interactions/{id}/attachments [post]
+func (h *AttachmentHandler) Upload(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID da interação da URL
+	interactionIDStr := c.Param("id")
+	interactionID, err := strconv.ParseUint(interactionIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da interação inválido"))
+		return
+	}
+
+	// Limitar o tamanho do corpo da requisição antes de processar o formulário
+	maxSize := h.attachmentService.MaxUploadSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			c.Error(errors.NewAppError(http.StatusRequestEntityTooLarge, "Arquivo muito grande", ""))
+			return
+		}
+		c.Error(errors.NewBadRequestError("Arquivo não enviado no campo 'file'"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(errors.NewBadRequestError("Não foi possível ler o arquivo enviado"))
+		return
+	}
+	defer file.Close()
+
+	// Chamar service para armazenar o anexo
+	attachment, err := h.attachmentService.Upload(userID, uint(interactionID), fileHeader.Filename, fileHeader.Size, file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// List lista os anexos de uma interação
+// @Summary Listar anexos da interação
+// @Description Lista os metadados dos arquivos anexados à interação
+// @Tags interactions
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID da interação"
+// @Success 200 {array} models.Attachment
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Interação não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/interactions/{id}/attachments [get]
+func (h *AttachmentHandler) List(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID da interação da URL
+	interactionIDStr := c.Param("id")
+	interactionID, err := strconv.ParseUint(interactionIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da interação inválido"))
+		return
+	}
+
+	// Chamar service para listar anexos
+	attachments, err := h.attachmentService.GetByInteractionID(userID, uint(interactionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler gerencia as rotas do log de auditoria
+type AuditHandler struct {
+	auditService services.AuditService
+}
+
+// NewAuditHandler cria uma nova instância do handler de auditoria
+func NewAuditHandler(auditService services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// List lista os registros de auditoria das entidades do usuário
+// @Summary Listar log de auditoria
+// @Description Lista as alterações (criação, atualização, exclusão, restauração e remoção definitiva) em contatos, tarefas, projetos e interações do usuário, das mais recentes às mais antigas
+// @Tags audit
+// @Security BearerAuth
+// @Produce json
+// @Param entity_type query string false "Tipo da entidade (contact, task, project, interaction)"
+// @Param entity_id query int false "ID da entidade"
+// @Param limit query int false "Limite de resultados (padrão: 50)"
+// @Param offset query int false "Offset para paginação (padrão: 0)"
+// @Success 200 {array} models.AuditLog
+// @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/audit [get]
+func (h *AuditHandler) List(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var filter models.AuditLogFilter
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(errors.NewBadRequestError("Parâmetros de consulta inválidos: " + err.Error()))
+		return
+	}
+
+	auditLogs, err := h.auditService.GetByUserID(userID, &filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, auditLogs)
+}
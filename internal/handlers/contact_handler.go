@@ -5,6 +5,7 @@
 	"crm-backend/internal/services"
 	"crm-backend/pkg/errors"
 	"crm-backend/pkg/logger"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -35,6 +36,7 @@ func NewContactHandler(contactService services.ContactService) *ContactHandler {
 // @Success 201 {object} models.Contact
 // @Failure 400 {object} map[string]interface{} "Dados inválidos"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Limite de contatos do plano atingido"
 // @Failure 409 {object} map[string]interface{} "Email já existe"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/contacts [post]
@@ -47,10 +49,10 @@ func (h *ContactHandler) Create(c *gin.Context) {
 
 	// Validar entrada JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.LogError(errors.NewBadRequestError("Dados de entrada inválidos: "+err.Error()), "Contact Creation", map[string]interface{}{
+		logger.LogError(errors.NewBadRequestError("Dados de entrada inválidos: "+err.Error()), "Contact Creation", withRequestID(c, map[string]interface{}{
 			"user_id": userID,
 			"error":   err.Error(),
-		})
+		}))
 		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
 		return
 	}
@@ -58,22 +60,22 @@ func (h *ContactHandler) Create(c *gin.Context) {
 	// Chamar service para criar contato
 	contact, err := h.contactService.Create(userID, &req)
 	if err != nil {
-		logger.LogError(err, "Contact Creation Service", map[string]interface{}{
+		logger.LogError(err, "Contact Creation Service", withRequestID(c, map[string]interface{}{
 			"user_id": userID,
 			"request": req,
-		})
+		}))
 		c.Error(err)
 		return
 	}
 
 	duration := time.Since(start)
 	logger.LogServiceCall("ContactHandler", "Create", duration, true)
-	logger.WithFields("INFO", "Contact Created", map[string]interface{}{
+	logger.WithFields("INFO", "Contact Created", withRequestID(c, map[string]interface{}{
 		"user_id":    userID,
 		"contact_id": contact.ID,
 		"email":      contact.Email,
 		"duration":   duration,
-	})
+	}))
 
 	c.JSON(http.StatusCreated, contact)
 }
@@ -86,9 +88,17 @@ func (h *ContactHandler) Create(c *gin.Context) {
 // @Produce json
 // @Param type query string false "Tipo de contato (CLIENT ou LEAD)"
 // @Param search query string false "Busca por nome, email ou empresa"
+// @Param company query string false "Filtra pela empresa (busca parcial)"
+// @Param has_projects query bool false "true: apenas clientes com projetos; false: contatos sem projetos"
+// @Param tags query string false "Etiquetas separadas por vírgula (retorna contatos com qualquer uma delas)"
+// @Param sort query string false "Campo de ordenação (name, email, company, created_at, updated_at)"
+// @Param order query string false "Direção da ordenação (asc ou desc, padrão: asc)"
 // @Param limit query int false "Limite de resultados (padrão: 50)"
 // @Param offset query int false "Offset para paginação (padrão: 0)"
-// @Success 200 {array} models.Contact
+// @Param changed_since query string false "Sincronização: apenas os alterados ou excluídos a partir deste momento (RFC3339), incluindo os excluídos com deleted e deleted_at"
+// @Param legacy query bool false "Retornar array simples sem metadados de paginação"
+// @Success 200 {object} models.PaginatedResponse[models.Contact]
+// @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/contacts [get]
@@ -102,14 +112,61 @@ func (h *ContactHandler) List(c *gin.Context) {
 		return
 	}
 
+	// Sincronização incremental: inclui os excluídos, indicados em cada item
+	if filter.ChangedSince != nil {
+		page, err := h.contactService.GetChangedByUserID(userID, &filter)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		respondPaginated(c, page)
+		return
+	}
+
 	// Chamar service para listar contatos
-	contacts, err := h.contactService.GetByUserID(userID, &filter)
+	page, err := h.contactService.GetPaginatedByUserID(userID, &filter)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, contacts)
+	respondPaginated(c, page)
+}
+
+// Trash lista os contatos excluídos do usuário
+// @Summary Listar contatos excluídos
+// @Description Lista os contatos excluídos (soft delete) do usuário, com a data de exclusão, para revisão antes da restauração ou remoção definitiva
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Param type query string false "Tipo de contato (CLIENT ou LEAD)"
+// @Param search query string false "Busca por nome, email ou empresa"
+// @Param limit query int false "Limite de resultados (padrão: 50)"
+// @Param offset query int false "Offset para paginação (padrão: 0)"
+// @Param legacy query bool false "Retornar array simples sem metadados de paginação"
+// @Success 200 {object} models.PaginatedResponse[models.DeletedContact]
+// @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/trash [get]
+func (h *ContactHandler) Trash(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var filter models.ContactListFilter
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(errors.NewBadRequestError("Parâmetros de consulta inválidos: " + err.Error()))
+		return
+	}
+
+	// Chamar service para listar contatos excluídos
+	page, err := h.contactService.GetDeletedByUserID(userID, &filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	respondPaginated(c, page)
 }
 
 // GetByID obtém um contato específico
@@ -119,7 +176,10 @@ func (h *ContactHandler) List(c *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "ID do contato"
+// @Param If-None-Match header string false "ETag recebido anteriormente"
 // @Success 200 {object} models.Contact
+// @Header 200 {string} ETag "Versão do recurso para requisições condicionais (If-None-Match)"
+// @Failure 304 "Não modificado (If-None-Match corresponde ao ETag atual)"
 // @Failure 400 {object} map[string]interface{} "ID inválido"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
@@ -144,7 +204,7 @@ func (h *ContactHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, contact)
+	respondWithETag(c, contact.ID, contact.UpdatedAt, contact)
 }
 
 // GetDetails obtém detalhes completos de um contato
@@ -196,7 +256,7 @@ func (h *ContactHandler) GetDetails(c *gin.Context) {
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
 // @Failure 404 {object} map[string]interface{} "Contato não encontrado"
-// @Failure 409 {object} map[string]interface{} "Email já existe"
+// @Failure 409 {object} map[string]interface{} "Email já existe ou versão desatualizada"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/contacts/{id} [put]
 func (h *ContactHandler) Update(c *gin.Context) {
@@ -229,16 +289,17 @@ func (h *ContactHandler) Update(c *gin.Context) {
 
 // Delete exclui um contato
 // @Summary Excluir contato
-// @Description Exclui um contato e todos os dados relacionados
+// @Description Exclui um contato e todos os dados relacionados. Com reassign_to, as tarefas do contato são transferidas para outro contato do usuário antes da exclusão.
 // @Tags contacts
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "ID do contato"
+// @Param reassign_to query int false "ID do contato que receberá as tarefas"
 // @Success 204 "Contato excluído com sucesso"
 // @Failure 400 {object} map[string]interface{} "ID inválido ou contato tem projetos associados"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
-// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 404 {object} map[string]interface{} "Contato ou contato de destino não encontrado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/contacts/{id} [delete]
 func (h *ContactHandler) Delete(c *gin.Context) {
@@ -252,8 +313,20 @@ func (h *ContactHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	// Contato opcional que receberá as tarefas
+	var reassignTo *uint
+	if raw := c.Query("reassign_to"); raw != "" {
+		target, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.Error(errors.NewBadRequestError("Parâmetro reassign_to inválido"))
+			return
+		}
+		targetID := uint(target)
+		reassignTo = &targetID
+	}
+
 	// Chamar service para excluir contato
-	err = h.contactService.Delete(userID, uint(contactID))
+	err = h.contactService.Delete(userID, uint(contactID), reassignTo)
 	if err != nil {
 		c.Error(err)
 		return
@@ -262,6 +335,292 @@ func (h *ContactHandler) Delete(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// Restore restaura um contato excluído
+// @Summary Restaurar contato excluído
+// @Description Restaura um contato excluído (soft delete) do usuário. Com restore_interactions=true, as interações excluídas do contato também são restauradas
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Param restore_interactions query bool false "Restaurar também as interações excluídas"
+// @Success 200 {object} models.Contact
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 404 {object} map[string]interface{} "Contato excluído não encontrado"
+// @Failure 409 {object} map[string]interface{} "Contato não está excluído"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/restore [post]
+func (h *ContactHandler) Restore(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactIDStr := c.Param("id")
+	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	restoreInteractions, _ := strconv.ParseBool(c.Query("restore_interactions"))
+
+	// Chamar service para restaurar contato
+	contact, err := h.contactService.Restore(userID, uint(contactID), restoreInteractions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contact)
+}
+
+// Purge remove definitivamente um contato
+// @Summary Remover contato definitivamente
+// @Description Remove permanentemente um contato (ativo ou na lixeira), junto com suas interações e tarefas. Esta operação não pode ser desfeita
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Success 204 "Contato removido definitivamente"
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 409 {object} map[string]interface{} "Cliente possui projetos associados"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/purge [delete]
+func (h *ContactHandler) Purge(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactIDStr := c.Param("id")
+	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	// Chamar service para remover contato definitivamente
+	if err := h.contactService.PurgeContact(userID, uint(contactID)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Touch registra um contato rápido
+// @Summary Registrar contato rápido
+// @Description Atualiza a data do último contato para agora, sem criar uma interação
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Success 200 {object} models.Contact
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/touch [post]
+func (h *ContactHandler) Touch(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactIDStr := c.Param("id")
+	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	// Chamar service para registrar o contato
+	contact, err := h.contactService.Touch(userID, uint(contactID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contact)
+}
+
+// Stale lista clientes sem contato recente
+// @Summary Listar clientes sem contato recente
+// @Description Lista os clientes sem contato (interação ou toque manual) há pelo menos N dias, incluindo os nunca contatados
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Param days query int false "Dias sem contato (padrão: 30)"
+// @Param limit query int false "Limite de resultados (padrão: 50)"
+// @Success 200 {array} models.Contact
+// @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/stale [get]
+func (h *ContactHandler) Stale(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter parâmetros da query string
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil {
+		c.Error(errors.NewBadRequestError("Número de dias inválido"))
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.Error(errors.NewBadRequestError("Limite inválido"))
+		return
+	}
+
+	// Chamar service para obter clientes sem contato recente
+	contacts, err := h.contactService.GetStaleClients(userID, days, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contacts)
+}
+
+// FollowUp lista contatos que precisam de acompanhamento
+// @Summary Listar contatos que precisam de acompanhamento
+// @Description Lista os contatos com tarefas não concluídas vencendo hoje ou atrasadas (TASK_DUE) e os leads sem interação há pelo menos N dias (STALE_LEAD), com os motivos de cada um. Contatos com tarefas vencidas vêm primeiro
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Param days query int false "Dias sem interação para considerar um lead parado (padrão: 30)"
+// @Param limit query int false "Limite de resultados (padrão: 50)"
+// @Success 200 {array} models.ContactFollowUp
+// @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/follow-up [get]
+func (h *ContactHandler) FollowUp(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter parâmetros da query string
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil {
+		c.Error(errors.NewBadRequestError("Número de dias inválido"))
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.Error(errors.NewBadRequestError("Limite inválido"))
+		return
+	}
+
+	// Chamar service para obter contatos que precisam de acompanhamento
+	followUps, err := h.contactService.GetNeedingFollowUp(userID, days, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, followUps)
+}
+
+// Duplicates lista grupos de contatos possivelmente duplicados
+// @Summary Listar possíveis contatos duplicados
+// @Description Agrupa os contatos do usuário com o mesmo e-mail (SAME_EMAIL) ou com nome e empresa equivalentes após normalização (SIMILAR_NAME): sem diferenciar maiúsculas, acentos, pontuação, ordem das palavras do nome e sufixos societários
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.ContactDuplicateGroup
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/duplicates [get]
+func (h *ContactHandler) Duplicates(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Chamar service para agrupar os possíveis duplicados
+	groups, err := h.contactService.FindDuplicates(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, groups)
+}
+
+// AddTags adiciona etiquetas a um contato
+// @Summary Adicionar etiquetas ao contato
+// @Description Adiciona etiquetas ao contato. Os nomes são normalizados (sem espaços nas pontas e em minúsculas) e etiquetas inexistentes são criadas
+// @Tags contacts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Param tags body models.ContactTagsRequest true "Etiquetas a adicionar"
+// @Success 200 {array} models.Tag
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/tags [post]
+func (h *ContactHandler) AddTags(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactIDStr := c.Param("id")
+	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	// Validar entrada JSON
+	var req models.ContactTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+		return
+	}
+
+	// Chamar service para adicionar etiquetas
+	tags, err := h.contactService.AddTags(userID, uint(contactID), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// RemoveTag remove uma etiqueta de um contato
+// @Summary Remover etiqueta do contato
+// @Description Remove a associação da etiqueta com o contato. A etiqueta continua disponível para os demais contatos
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Param tag path string true "Nome da etiqueta"
+// @Success 200 {array} models.Tag
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Contato ou etiqueta não encontrados"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/tags/{tag} [delete]
+func (h *ContactHandler) RemoveTag(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactIDStr := c.Param("id")
+	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	// Chamar service para remover a etiqueta
+	tags, err := h.contactService.RemoveTag(userID, uint(contactID), c.Param("tag"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
 // Search busca contatos por nome
 // @Summary Buscar contatos por nome
 // @Description Busca contatos do usuário por nome (busca parcial)
@@ -331,13 +690,13 @@ func (h *ContactHandler) GetSummary(c *gin.Context) {
 
 // ConvertToClient converte um lead em cliente
 // @Summary Converter lead em cliente
-// @Description Converte um lead em cliente
+// @Description Converte um lead em cliente. Idempotente: se o contato já for cliente, retorna 200 com o contato inalterado e already_client true
 // @Tags contacts
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "ID do contato (lead)"
-// @Success 200 {object} models.Contact
-// @Failure 400 {object} map[string]interface{} "ID inválido ou contato não é lead"
+// @Success 200 {object} map[string]interface{} "Mensagem, contato e already_client"
+// @Failure 400 {object} map[string]interface{} "ID inválido"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
 // @Failure 404 {object} map[string]interface{} "Contato não encontrado"
@@ -355,14 +714,171 @@ func (h *ContactHandler) ConvertToClient(c *gin.Context) {
 	}
 
 	// Chamar service para converter lead em cliente
-	contact, err := h.contactService.ConvertLeadToClient(userID, uint(contactID))
+	contact, alreadyClient, err := h.contactService.ConvertLeadToClient(userID, uint(contactID))
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
+	message := "Lead convertido em cliente com sucesso"
+	if alreadyClient {
+		message = "O contato já é cliente"
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Lead convertido em cliente com sucesso",
-		"contact": contact,
+		"message":        message,
+		"contact":        contact,
+		"already_client": alreadyClient,
 	})
 }
+
+// BatchUpdateType altera o tipo de vários contatos de uma vez
+// @Summary Alterar tipo de contatos em lote
+// @Description Altera o tipo (CLIENT ou LEAD) de até 500 contatos em uma única transação. IDs inexistentes, de outros usuários, já do tipo informado ou de clientes com projetos (ao alterar para LEAD) são retornados em skipped. Leads convertidos em clientes recebem converted_at.
+// @Tags contacts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body models.ContactBatchTypeRequest true "IDs dos contatos e novo tipo"
+// @Success 200 {object} models.BatchResult
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 409 {object} map[string]interface{} "Contato alterado durante a operação"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/batch/type [put]
+func (h *ContactHandler) BatchUpdateType(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req models.ContactBatchTypeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados inválidos: " + err.Error()))
+		return
+	}
+
+	result, err := h.contactService.BatchUpdateType(userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Export exporta os contatos do usuário em CSV ou JSON
+// @Summary Exportar contatos
+// @Description Exporta todos os contatos do usuário (respeitando os filtros de tipo e busca) em CSV ou JSON
+// @Tags contacts
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce json
+// @Param format query string false "Formato de exportação (csv ou json, padrão: csv)"
+// @Param type query string false "Tipo de contato (CLIENT ou LEAD)"
+// @Param search query string false "Busca por nome, email ou empresa"
+// @Param company query string false "Filtra pela empresa (busca parcial)"
+// @Param has_projects query bool false "true: apenas clientes com projetos; false: contatos sem projetos"
+// @Param tags query string false "Etiquetas separadas por vírgula (retorna contatos com qualquer uma delas)"
+// @Success 200 {file} file "Arquivo de contatos"
+// @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/export [get]
+func (h *ContactHandler) Export(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var filter models.ContactListFilter
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(errors.NewBadRequestError("Parâmetros de consulta inválidos: " + err.Error()))
+		return
+	}
+
+	format := c.DefaultQuery("format", services.ExportFormatCSV)
+	var contentType string
+	switch format {
+	case services.ExportFormatCSV:
+		contentType = "text/csv; charset=utf-8"
+	case services.ExportFormatJSON:
+		contentType = "application/json; charset=utf-8"
+	default:
+		c.Error(errors.NewBadRequestError("Formato de exportação inválido. Use: csv ou json"))
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="contacts.`+format+`"`)
+	c.Status(http.StatusOK)
+
+	// Escrever diretamente na resposta, sem carregar todos os contatos em memória
+	if err := h.contactService.ExportContacts(userID, &filter, format, c.Writer); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Error(err)
+			return
+		}
+		logger.LogError(err, "Contact Export", withRequestID(c, map[string]interface{}{
+			"user_id": userID,
+			"format":  format,
+		}))
+	}
+}
+
+// Report gera o relatório de um contato
+// @Summary Relatório do contato
+// @Description Gera um relatório do contato para compartilhamento: perfil, estatísticas e as últimas 50 interações em ordem cronológica. Em HTML (padrão, datas no fuso horário do usuário) ou JSON
+// @Tags contacts
+// @Security BearerAuth
+// @Produce html
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Param format query string false "Formato do relatório (html ou json, padrão: html)"
+// @Success 200 {file} file "Relatório do contato"
+// @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/report [get]
+func (h *ContactHandler) Report(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactIDStr := c.Param("id")
+	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	format := c.DefaultQuery("format", services.ReportFormatHTML)
+	var contentType, disposition string
+	switch format {
+	case services.ReportFormatHTML:
+		contentType = "text/html; charset=utf-8"
+		disposition = "inline"
+	case services.ReportFormatJSON:
+		contentType = "application/json; charset=utf-8"
+		disposition = "attachment"
+	default:
+		c.Error(errors.NewBadRequestError("Formato de relatório inválido. Use: html ou json"))
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`%s; filename="contact-%d-report.%s"`, disposition, contactID, format))
+	c.Status(http.StatusOK)
+
+	if err := h.contactService.ExportContactReport(userID, uint(contactID), format, c.Writer); err != nil {
+		if !c.Writer.Written() {
+			// Erros (ex.: contato não encontrado) são respondidos em JSON pelo ErrorHandler
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.Error(err)
+			return
+		}
+		logger.LogError(err, "Contact Report", withRequestID(c, map[string]interface{}{
+			"user_id":    userID,
+			"contact_id": contactID,
+			"format":     format,
+		}))
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"crm-backend/pkg/logger"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// readinessTimeout define o tempo máximo de espera pelo ping do banco de dados
+const readinessTimeout = 2 * time.Second
+
+// HealthHandler gerencia as rotas de verificação de saúde da aplicação
+type HealthHandler struct {
+	db      *gorm.DB
+	version string
+}
+
+// NewHealthHandler cria uma nova instância do handler de saúde
+func NewHealthHandler(db *gorm.DB, version string) *HealthHandler {
+	return &HealthHandler{
+		db:      db,
+		version: version,
+	}
+}
+
+// Live indica se o processo está em execução (liveness)
+// @Summary Verificação de liveness
+// @Description Retorna 200 enquanto o processo estiver em execução
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Aplicação em execução"
+// @Router /health [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"version": h.version,
+	})
+}
+
+// Ready indica se a aplicação está pronta para receber tráfego (readiness)
+// @Summary Verificação de readiness
+// @Description Verifica a conexão com o banco de dados. Retorna 503 se o banco estiver inacessível
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Aplicação pronta"
+// @Failure 503 {object} map[string]interface{} "Banco de dados inacessível"
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	start := time.Now()
+
+	err := h.pingDatabase(c.Request.Context())
+	latency := time.Since(start)
+
+	if err != nil {
+		logger.LogError(err, "Health Readiness Check", withRequestID(c, map[string]interface{}{
+			"db_latency": latency,
+		}))
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":   "unavailable",
+			"version":  h.version,
+			"database": "unreachable",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        "ready",
+		"version":       h.version,
+		"database":      "ok",
+		"db_latency_ms": float64(latency.Microseconds()) / 1000,
+	})
+}
+
+// pingDatabase verifica a conexão com o banco de dados respeitando o tempo limite
+func (h *HealthHandler) pingDatabase(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportantDateHandler gerencia as rotas de datas importantes dos contatos
+type ImportantDateHandler struct {
+	importantDateService services.ImportantDateService
+}
+
+// NewImportantDateHandler cria uma nova instância do handler de datas importantes
+func NewImportantDateHandler(importantDateService services.ImportantDateService) *ImportantDateHandler {
+	return &ImportantDateHandler{
+		importantDateService: importantDateService,
+	}
+}
+
+// Create cadastra uma data importante para um contato
+// @Summary Cadastrar data importante
+// @Description Cadastra uma data pessoal do contato (aniversário, data de fundação etc.). Datas recorrentes (padrão) se repetem todo ano
+// @Tags contacts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Param request body models.ImportantDateCreateRequest true "Dados da data"
+// @Success 201 {object} models.ImportantDate
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/dates [post]
+func (h *ImportantDateHandler) Create(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	// Validar entrada JSON
+	var req models.ImportantDateCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+		return
+	}
+
+	// Chamar service para cadastrar a data
+	date, err := h.importantDateService.Create(userID, uint(contactID), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, date)
+}
+
+// ListByContact lista as datas importantes de um contato
+// @Summary Listar datas importantes do contato
+// @Description Lista as datas importantes do contato, ordenadas por mês e dia
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Success 200 {array} models.ImportantDate
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/dates [get]
+func (h *ImportantDateHandler) ListByContact(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	// Chamar service para listar as datas do contato
+	dates, err := h.importantDateService.GetByContactID(userID, uint(contactID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dates)
+}
+
+// Upcoming lista as datas importantes dos próximos dias
+// @Summary Listar próximas datas importantes
+// @Description Lista as datas dos contatos do usuário que ocorrem de hoje até daqui a N dias (no fuso horário do usuário), da mais próxima para a mais distante. Datas recorrentes são projetadas para a próxima ocorrência; 29/02 é considerado 28/02 em anos não bissextos
+// @Tags contacts
+// @Security BearerAuth
+// @Produce json
+// @Param days query int false "Janela em dias, de 1 a 366 (padrão: 30)"
+// @Success 200 {array} models.UpcomingImportantDate
+// @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/dates/upcoming [get]
+func (h *ImportantDateHandler) Upcoming(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter parâmetros da query string
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil {
+		c.Error(errors.NewBadRequestError("Número de dias inválido"))
+		return
+	}
+
+	// Chamar service para obter as próximas datas
+	dates, err := h.importantDateService.GetUpcoming(userID, days)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dates)
+}
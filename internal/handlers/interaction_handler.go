@@ -26,7 +26,7 @@ func NewInteractionHandler(interactionService services.InteractionService) *Inte
 
 // Create cria uma nova interação para um contato
 // @Summary Criar nova interação
-// @Description Cria uma nova interação para um contato específico
+// @Description Cria uma nova interação para um contato específico. Com follow_up_date, cria também uma tarefa de follow-up (retornada em follow_up_task_id)
 // @Tags interactions
 // @Security BearerAuth
 // @Accept json
@@ -51,20 +51,20 @@ func (h *InteractionHandler) Create(c *gin.Context) {
 
 	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
 	if err != nil {
-		logger.LogError(err, "Erro ao converter ID do contato", map[string]interface{}{
+		logger.LogError(err, "Erro ao converter ID do contato", withRequestID(c, map[string]interface{}{
 			"contact_id_str": contactIDStr,
 			"user_id":        userID,
-		})
+		}))
 		c.Error(errors.NewBadRequestError("ID do contato inválido"))
 		return
 	}
 
 	// Validar entrada JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.LogError(err, "Erro ao validar dados de entrada", map[string]interface{}{
+		logger.LogError(err, "Erro ao validar dados de entrada", withRequestID(c, map[string]interface{}{
 			"contact_id": contactID,
 			"user_id":    userID,
-		})
+		}))
 		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
 		return
 	}
@@ -72,39 +72,85 @@ func (h *InteractionHandler) Create(c *gin.Context) {
 	// Chamar service para criar interação
 	interaction, err := h.interactionService.Create(userID, uint(contactID), &req)
 	if err != nil {
-		logger.LogError(err, "Erro ao criar interação", map[string]interface{}{
+		logger.LogError(err, "Erro ao criar interação", withRequestID(c, map[string]interface{}{
 			"contact_id": contactID,
 			"user_id":    userID,
 			"request":    req,
-		})
+		}))
 		c.Error(err)
 		return
 	}
 
 	duration := time.Since(start)
-	logger.WithFields("INFO", "Interaction Created", map[string]interface{}{
+	logger.WithFields("INFO", "Interaction Created", withRequestID(c, map[string]interface{}{
 		"user_id":        userID,
 		"contact_id":     contactID,
 		"interaction_id": interaction.ID,
 		"duration":       duration,
-	})
+	}))
+
+	c.JSON(http.StatusCreated, interaction)
+}
+
+// CreateByContactEmail cria uma interação identificando o contato pelo email
+// @Summary Criar interação pelo email do contato
+// @Description Cria uma interação para o contato do usuário com o email informado em contact_email (sem diferenciar maiúsculas). Se houver mais de um contato com o mesmo email, usa o cadastrado primeiro (menor ID). Aceita os mesmos campos da criação por contato, inclusive o follow-up.
+// @Tags interactions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body models.InteractionCreateByEmailRequest true "Dados da interação"
+// @Success 201 {object} models.Interaction
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/interactions [post]
+func (h *InteractionHandler) CreateByContactEmail(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req models.InteractionCreateByEmailRequest
+
+	// Validar entrada JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+		return
+	}
+
+	// Chamar service para criar interação
+	interaction, err := h.interactionService.CreateByContactEmail(userID, &req)
+	if err != nil {
+		logger.LogError(err, "Erro ao criar interação pelo email do contato", withRequestID(c, map[string]interface{}{
+			"user_id": userID,
+		}))
+		c.Error(err)
+		return
+	}
+
+	logger.WithFields("INFO", "Interaction Created", withRequestID(c, map[string]interface{}{
+		"user_id":        userID,
+		"contact_id":     interaction.ContactID,
+		"interaction_id": interaction.ID,
+	}))
 
 	c.JSON(http.StatusCreated, interaction)
 }
 
 // ListByContact lista interações de um contato específico
 // @Summary Listar interações de um contato
-// @Description Lista todas as interações de um contato específico
+// @Description Lista as interações de um contato específico com paginação; total considera os filtros aplicados
 // @Tags interactions
 // @Security BearerAuth
 // @Produce json
 // @Param contactId path int true "ID do contato"
 // @Param type query string false "Tipo de interação (EMAIL, CALL, MEETING, OTHER)"
+// @Param outcome query string false "Resultado da interação (POSITIVE, NEUTRAL, NEGATIVE, NO_RESPONSE)"
 // @Param date_from query string false "Data inicial (formato: 2006-01-02T15:04:05Z)"
 // @Param date_to query string false "Data final (formato: 2006-01-02T15:04:05Z)"
+// @Param search query string false "Busca parcial no assunto ou na descrição"
 // @Param limit query int false "Limite de resultados (padrão: 50)"
 // @Param offset query int false "Offset para paginação (padrão: 0)"
-// @Success 200 {array} models.Interaction
+// @Param legacy query bool false "Retornar array simples sem metadados de paginação"
+// @Success 200 {object} models.PaginatedResponse[models.Interaction]
 // @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
@@ -122,60 +168,65 @@ func (h *InteractionHandler) ListByContact(c *gin.Context) {
 
 	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
 	if err != nil {
-		logger.LogError(err, "Erro ao converter ID do contato", map[string]interface{}{
+		logger.LogError(err, "Erro ao converter ID do contato", withRequestID(c, map[string]interface{}{
 			"contact_id_str": contactIDStr,
 			"user_id":        userID,
-		})
+		}))
 		c.Error(errors.NewBadRequestError("ID do contato inválido"))
 		return
 	}
 
 	// Bind query parameters
 	if err := c.ShouldBindQuery(&filter); err != nil {
-		logger.LogError(err, "Erro ao validar parâmetros de consulta", map[string]interface{}{
+		logger.LogError(err, "Erro ao validar parâmetros de consulta", withRequestID(c, map[string]interface{}{
 			"contact_id": contactID,
 			"user_id":    userID,
-		})
+		}))
 		c.Error(errors.NewBadRequestError("Parâmetros de consulta inválidos: " + err.Error()))
 		return
 	}
 
 	// Chamar service para listar interações do contato
-	interactions, err := h.interactionService.GetByContactID(userID, uint(contactID), &filter)
+	page, err := h.interactionService.GetPaginatedByContactID(userID, uint(contactID), &filter)
 	if err != nil {
-		logger.LogError(err, "Erro ao listar interações", map[string]interface{}{
+		logger.LogError(err, "Erro ao listar interações", withRequestID(c, map[string]interface{}{
 			"contact_id": contactID,
 			"user_id":    userID,
 			"filter":     filter,
-		})
+		}))
 		c.Error(err)
 		return
 	}
 
 	duration := time.Since(start)
-	logger.WithFields("INFO", "Interactions Listed", map[string]interface{}{
+	logger.WithFields("INFO", "Interactions Listed", withRequestID(c, map[string]interface{}{
 		"user_id":      userID,
 		"contact_id":   contactID,
-		"interactions": len(interactions),
+		"interactions": len(page.Data),
+		"total":        page.Total,
 		"duration":     duration,
-	})
+	}))
 
-	c.JSON(http.StatusOK, interactions)
+	respondPaginated(c, page)
 }
 
 // List lista todas as interações do usuário
 // @Summary Listar todas as interações
-// @Description Lista todas as interações do usuário com filtros opcionais
+// @Description Lista as interações de todos os contatos do usuário, com filtros combináveis e o total filtrado (ex.: ligações com resultado negativo no mês)
 // @Tags interactions
 // @Security BearerAuth
 // @Produce json
 // @Param type query string false "Tipo de interação (EMAIL, CALL, MEETING, OTHER)"
+// @Param outcome query string false "Resultado da interação (POSITIVE, NEUTRAL, NEGATIVE, NO_RESPONSE)"
 // @Param contact_id query int false "ID do contato específico"
 // @Param date_from query string false "Data inicial (formato: 2006-01-02T15:04:05Z)"
 // @Param date_to query string false "Data final (formato: 2006-01-02T15:04:05Z)"
+// @Param search query string false "Busca parcial no assunto ou na descrição"
 // @Param limit query int false "Limite de resultados (padrão: 50)"
 // @Param offset query int false "Offset para paginação (padrão: 0)"
-// @Success 200 {array} models.Interaction
+// @Param cursor query string false "Cursor retornado em next_cursor; tem precedência sobre offset"
+// @Param legacy query bool false "Retornar array simples sem metadados de paginação"
+// @Success 200 {object} models.PaginatedResponse[models.Interaction]
 // @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
@@ -191,13 +242,13 @@ func (h *InteractionHandler) List(c *gin.Context) {
 	}
 
 	// Chamar service para listar interações do usuário
-	interactions, err := h.interactionService.GetByUserID(userID, &filter)
+	page, err := h.interactionService.GetPaginatedByUserID(userID, &filter)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, interactions)
+	respondPaginated(c, page)
 }
 
 // GetByID obtém uma interação específica
@@ -207,7 +258,10 @@ func (h *InteractionHandler) List(c *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "ID da interação"
+// @Param If-None-Match header string false "ETag recebido anteriormente"
 // @Success 200 {object} models.Interaction
+// @Header 200 {string} ETag "Versão do recurso para requisições condicionais (If-None-Match)"
+// @Failure 304 "Não modificado (If-None-Match corresponde ao ETag atual)"
 // @Failure 400 {object} map[string]interface{} "ID inválido"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
@@ -232,7 +286,39 @@ func (h *InteractionHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, interaction)
+	respondWithETag(c, interaction.ID, interaction.UpdatedAt, interaction)
+}
+
+// GetWithContext obtém uma interação com o contexto do contato
+// @Summary Obter interação com contexto
+// @Description Obtém uma interação junto com as 5 outras interações mais recentes e até 10 tarefas em aberto do mesmo contato
+// @Tags interactions
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID da interação"
+// @Success 200 {object} services.InteractionContext
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Interação não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/interactions/{id}/context [get]
+func (h *InteractionHandler) GetWithContext(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	interactionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da interação inválido"))
+		return
+	}
+
+	interactionContext, err := h.interactionService.GetWithContext(userID, uint(interactionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, interactionContext)
 }
 
 // Update atualiza uma interação existente
@@ -316,12 +402,14 @@ func (h *InteractionHandler) Delete(c *gin.Context) {
 
 // GetRecent obtém interações recentes do usuário
 // @Summary Obter interações recentes
-// @Description Obtém as interações mais recentes do usuário
+// @Description Obtém as interações mais recentes do usuário dentro da janela de dias
 // @Tags interactions
 // @Security BearerAuth
 // @Produce json
 // @Param limit query int false "Limite de resultados (padrão: 10)"
+// @Param days query int false "Janela em dias (1 a 365; padrão: RECENT_INTERACTIONS_DAYS, 7)"
 // @Success 200 {array} models.Interaction
+// @Failure 400 {object} map[string]interface{} "Janela inválida"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/interactions/recent [get]
@@ -335,8 +423,14 @@ func (h *InteractionHandler) GetRecent(c *gin.Context) {
 		limit = 10
 	}
 
+	days, err := parseDaysParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	// Chamar service para obter interações recentes
-	interactions, err := h.interactionService.GetRecentInteractions(userID, limit)
+	interactions, err := h.interactionService.GetRecentInteractions(userID, days, limit)
 	if err != nil {
 		c.Error(err)
 		return
@@ -345,25 +439,16 @@ func (h *InteractionHandler) GetRecent(c *gin.Context) {
 	c.JSON(http.StatusOK, interactions)
 }
 
-// GetRecentInteractions obtém interações recentes dos últimos 7 dias
-// @Summary Obter interações recentes
-// @Description Obtém interações recentes do usuário dos últimos 7 dias
-// @Tags interactions
-// @Security BearerAuth
-// @Produce json
-// @Param limit query int false "Limite de resultados (padrão: 10)"
-// @Success 200 {object} map[string]interface{} "Lista de interações recentes"
-// @Failure 401 {object} map[string]interface{} "Não autorizado"
-// @Failure 500 {object} map[string]interface{} "Erro interno"
-// @Router /api/interactions/recent [get]
-// GetRecentInteractionsCount retorna apenas o número de interações recentes dos últimos 7 dias
+// GetRecentInteractionsCount retorna apenas o número de interações recentes dentro da janela de dias
 // @Summary Contar interações recentes
-// @Description Retorna o número de interações recentes do usuário dos últimos 7 dias
+// @Description Retorna o número de interações recentes do usuário dentro da janela de dias
 // @Tags interactions
 // @Security BearerAuth
 // @Produce json
 // @Param limit query int false "Limite de resultados (padrão: 10)"
+// @Param days query int false "Janela em dias (1 a 365; padrão: RECENT_INTERACTIONS_DAYS, 7)"
 // @Success 200 {object} map[string]int "Quantidade de interações recentes"
+// @Failure 400 {object} map[string]interface{} "Janela inválida"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/interactions/recent/count [get]
@@ -377,13 +462,19 @@ func (h *InteractionHandler) GetRecentInteractionsCount(c *gin.Context) {
 		limit = 10
 	}
 
+	days, err := parseDaysParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	// Chamar service para obter interações recentes
-	interactions, err := h.interactionService.GetRecentInteractions(userID, limit)
+	interactions, err := h.interactionService.GetRecentInteractions(userID, days, limit)
 	if err != nil {
-		logger.LogError(err, "Erro ao buscar interações recentes", map[string]interface{}{
+		logger.LogError(err, "Erro ao buscar interações recentes", withRequestID(c, map[string]interface{}{
 			"user_id": userID,
 			"limit":   limit,
-		})
+		}))
 		c.Error(err)
 		return
 	}
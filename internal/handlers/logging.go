@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"crm-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// withRequestID adiciona o ID da requisição aos campos de log, permitindo correlacionar os logs dos handlers
+func withRequestID(c *gin.Context, fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		fields = make(map[string]interface{})
+	}
+	if requestID := c.GetString(middleware.RequestIDKey); requestID != "" {
+		fields[middleware.RequestIDKey] = requestID
+	}
+	return fields
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler gerencia as rotas de notificações
+type NotificationHandler struct {
+	notificationService services.NotificationService
+}
+
+// NewNotificationHandler cria uma nova instância do handler de notificações
+func NewNotificationHandler(notificationService services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// List lista as notificações do usuário
+// @Summary Listar notificações
+// @Description Lista as notificações do usuário, das mais recentes às mais antigas
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param unread query bool false "Apenas notificações não lidas"
+// @Param limit query int false "Limite de resultados (padrão: 50)"
+// @Param offset query int false "Offset para paginação (padrão: 0)"
+// @Success 200 {array} models.Notification
+// @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/notifications [get]
+func (h *NotificationHandler) List(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var filter models.NotificationListFilter
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(errors.NewBadRequestError("Parâmetros de consulta inválidos: " + err.Error()))
+		return
+	}
+
+	notifications, err := h.notificationService.GetByUserID(userID, &filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// MarkAsRead marca uma notificação como lida
+// @Summary Marcar notificação como lida
+// @Description Marca uma notificação do usuário como lida
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID da notificação"
+// @Success 200 {object} models.Notification
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Notificação não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/notifications/{id}/read [put]
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID da notificação da URL
+	notificationIDStr := c.Param("id")
+	notificationID, err := strconv.ParseUint(notificationIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da notificação inválido"))
+		return
+	}
+
+	notification, err := h.notificationService.MarkAsRead(userID, uint(notificationID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notification)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordResetHandler gerencia as rotas de redefinição de senha
+type PasswordResetHandler struct {
+	passwordResetService services.PasswordResetService
+}
+
+// NewPasswordResetHandler cria uma nova instância do handler de redefinição de senha
+func NewPasswordResetHandler(passwordResetService services.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetService: passwordResetService,
+	}
+}
+
+// ForgotPassword solicita a redefinição de senha
+// @Summary Solicitar redefinição de senha
+// @Description Envia um link de redefinição de senha para o email informado. Por segurança, a resposta é sempre 200, mesmo que o email não esteja cadastrado
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordRequest true "Email da conta"
+// @Success 200 {object} map[string]interface{} "Solicitação recebida"
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/auth/forgot-password [post]
+func (h *PasswordResetHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+		return
+	}
+
+	if err := h.passwordResetService.RequestReset(req.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Se o email estiver cadastrado, você receberá um link para redefinir a senha",
+	})
+}
+
+// ResetPassword redefine a senha a partir do token recebido por email
+// @Summary Redefinir senha
+// @Description Redefine a senha do usuário usando o token enviado por email. O token expira em 1 hora e só pode ser usado uma vez
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordRequest true "Token e nova senha"
+// @Success 200 {object} map[string]interface{} "Senha redefinida com sucesso"
+// @Failure 400 {object} map[string]interface{} "Dados inválidos ou token inválido/expirado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/auth/reset-password [post]
+func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+		return
+	}
+
+	if req.NewPassword != req.ConfirmPassword {
+		c.Error(errors.NewBadRequestError("Nova senha e confirmação não conferem"))
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Senha redefinida com sucesso",
+	})
+}
+
+// ForgotPasswordRequest representa os dados para solicitar a redefinição de senha
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email" example:"joao@example.com"`
+}
+
+// ResetPasswordRequest representa os dados para redefinir a senha
+type ResetPasswordRequest struct {
+	Token           string `json:"token" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6" example:"novaSenha456"`
+	ConfirmPassword string `json:"confirm_password" binding:"required" example:"novaSenha456"`
+}
@@ -4,6 +4,7 @@
 	"crm-backend/internal/models"
 	"crm-backend/internal/services"
 	"crm-backend/pkg/errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -33,7 +34,7 @@ func NewProjectHandler(projectService services.ProjectService) *ProjectHandler {
 // @Success 201 {object} models.Project
 // @Failure 400 {object} map[string]interface{} "Dados inválidos"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
-// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado ou limite de projetos do plano atingido"
 // @Failure 404 {object} map[string]interface{} "Cliente não encontrado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/projects [post]
@@ -67,7 +68,9 @@ func (h *ProjectHandler) Create(c *gin.Context) {
 // @Param client_id query int false "ID do cliente específico"
 // @Param limit query int false "Limite de resultados (padrão: 50)"
 // @Param offset query int false "Offset para paginação (padrão: 0)"
-// @Success 200 {array} models.Project
+// @Param changed_since query string false "Sincronização: apenas os alterados ou excluídos a partir deste momento (RFC3339), incluindo os excluídos com deleted e deleted_at"
+// @Param legacy query bool false "Retornar array simples sem metadados de paginação"
+// @Success 200 {object} models.PaginatedResponse[models.Project]
 // @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
@@ -98,14 +101,25 @@ func (h *ProjectHandler) List(c *gin.Context) {
 		}
 	}
 
+	// Sincronização incremental: inclui os excluídos, indicados em cada item
+	if filter.ChangedSince != nil {
+		page, err := h.projectService.GetChangedByUserID(userID, &filter)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		respondPaginated(c, page)
+		return
+	}
+
 	// Chamar service para listar projetos
-	projects, err := h.projectService.GetByUserID(userID, &filter)
+	page, err := h.projectService.GetPaginatedByUserID(userID, &filter)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, projects)
+	respondPaginated(c, page)
 }
 
 // GetByID obtém um projeto específico
@@ -115,7 +129,10 @@ func (h *ProjectHandler) List(c *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "ID do projeto"
+// @Param If-None-Match header string false "ETag recebido anteriormente"
 // @Success 200 {object} models.Project
+// @Header 200 {string} ETag "Versão do recurso para requisições condicionais (If-None-Match)"
+// @Failure 304 "Não modificado (If-None-Match corresponde ao ETag atual)"
 // @Failure 400 {object} map[string]interface{} "ID inválido"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
@@ -140,7 +157,7 @@ func (h *ProjectHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, project)
+	respondWithETag(c, project.ID, project.UpdatedAt, project)
 }
 
 // GetWithTasks obtém um projeto com suas tarefas
@@ -192,6 +209,7 @@ func (h *ProjectHandler) GetWithTasks(c *gin.Context) {
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
 // @Failure 404 {object} map[string]interface{} "Projeto não encontrado"
+// @Failure 409 {object} map[string]interface{} "Versão desatualizada (projeto alterado por outra requisição)"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/projects/{id} [put]
 func (h *ProjectHandler) Update(c *gin.Context) {
@@ -257,6 +275,107 @@ func (h *ProjectHandler) Delete(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// Restore restaura um projeto excluído
+// @Summary Restaurar projeto excluído
+// @Description Restaura um projeto excluído (soft delete) do usuário
+// @Tags projects
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do projeto"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 404 {object} map[string]interface{} "Projeto excluído não encontrado"
+// @Failure 409 {object} map[string]interface{} "Projeto não está excluído"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/projects/{id}/restore [post]
+func (h *ProjectHandler) Restore(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do projeto da URL
+	projectIDStr := c.Param("id")
+	projectID, err := strconv.ParseUint(projectIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do projeto inválido"))
+		return
+	}
+
+	// Chamar service para restaurar projeto
+	project, err := h.projectService.Restore(userID, uint(projectID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// Duplicate duplica um projeto
+// @Summary Duplicar projeto
+// @Description Cria uma cópia do projeto (nome com o sufixo " (copy)", descrição e cliente), em andamento. Com include_tasks, as tarefas também são copiadas como PENDING, com os vencimentos removidos ou deslocados em shift_days dias
+// @Tags projects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do projeto"
+// @Param request body models.ProjectDuplicateRequest false "Opções da cópia"
+// @Success 201 {object} models.Project
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado ou limite de projetos do plano atingido"
+// @Failure 404 {object} map[string]interface{} "Projeto não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/projects/{id}/duplicate [post]
+func (h *ProjectHandler) Duplicate(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do projeto da URL
+	projectIDStr := c.Param("id")
+	projectID, err := strconv.ParseUint(projectIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do projeto inválido"))
+		return
+	}
+
+	// Validar entrada JSON (corpo opcional)
+	var req models.ProjectDuplicateRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+		return
+	}
+
+	// Chamar service para duplicar projeto
+	project, err := h.projectService.DuplicateProject(userID, uint(projectID), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+// GetBoard lista os projetos do usuário agrupados por status
+// @Summary Quadro de projetos
+// @Description Retorna os projetos do usuário agrupados por status (IN_PROGRESS, COMPLETED, CANCELLED), com todas as colunas presentes
+// @Tags projects
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} services.ProjectBoard
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/projects/board [get]
+func (h *ProjectHandler) GetBoard(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	board, err := h.projectService.GetBoard(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
 // GetByClient lista projetos de um cliente específico
 // @Summary Listar projetos de um cliente
 // @Description Lista todos os projetos associados a um cliente específico
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"crm-backend/internal/models"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondPaginated envia uma listagem paginada, mantendo o formato antigo (array simples) quando ?legacy=true
+func respondPaginated[T any](c *gin.Context, page *models.PaginatedResponse[T]) {
+	if legacy, _ := strconv.ParseBool(c.Query("legacy")); legacy {
+		c.JSON(http.StatusOK, page.Data)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// respondWithETag envia um recurso individual com ETag derivado do ID e da data de atualização.
+// Se o cliente enviar If-None-Match com o mesmo ETag, responde 304 sem corpo.
+func respondWithETag(c *gin.Context, id uint, updatedAt time.Time, body interface{}) {
+	etag := fmt.Sprintf(`"%d-%d"`, id, updatedAt.UnixNano())
+	c.Header("ETag", etag)
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, body)
+}
+
+// etagMatches verifica se algum dos ETags do cabeçalho If-None-Match corresponde ao atual (comparação fraca)
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"crm-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionHandler gerencia as rotas administrativas da limpeza de registros excluídos
+type RetentionHandler struct {
+	retentionService services.RetentionService
+}
+
+// NewRetentionHandler cria uma nova instância do handler de limpeza
+func NewRetentionHandler(retentionService services.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+	}
+}
+
+// Purge executa imediatamente a limpeza dos registros excluídos
+// @Summary Executar limpeza de registros excluídos
+// @Description Remove definitivamente, de todos os usuários, os registros excluídos há mais que o período de retenção, sem aguardar a execução diária. Apenas administradores.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Quantidade de registros removidos por tabela"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Router /api/admin/retention/purge [post]
+func (h *RetentionHandler) Purge(c *gin.Context) {
+	purged := h.retentionService.PurgeExpired()
+
+	c.JSON(http.StatusOK, gin.H{"purged": purged})
+}
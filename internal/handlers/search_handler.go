@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler gerencia a rota de busca global
+type SearchHandler struct {
+	searchService services.SearchService
+}
+
+// NewSearchHandler cria uma nova instância do handler de busca
+func NewSearchHandler(searchService services.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// Search busca um termo em contatos, tarefas, projetos e interações
+// @Summary Busca global
+// @Description Busca o termo em contatos (nome, email, empresa), tarefas (título, descrição), projetos (nome, descrição) e interações (assunto, descrição)
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Param q query string true "Termo de busca"
+// @Success 200 {object} services.SearchResults
+// @Failure 400 {object} map[string]interface{} "Termo de busca obrigatório"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter termo de busca
+	searchTerm := strings.TrimSpace(c.Query("q"))
+	if searchTerm == "" {
+		c.Error(errors.NewBadRequestError("Termo de busca é obrigatório"))
+		return
+	}
+
+	// Chamar service para realizar a busca
+	results, err := h.searchService.Search(userID, searchTerm)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
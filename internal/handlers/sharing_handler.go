@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SharingHandler gerencia as rotas de compartilhamento de projetos
+type SharingHandler struct {
+	sharingService services.SharingService
+}
+
+// NewSharingHandler cria uma nova instância do handler de compartilhamento
+func NewSharingHandler(sharingService services.SharingService) *SharingHandler {
+	return &SharingHandler{
+		sharingService: sharingService,
+	}
+}
+
+// ShareProject compartilha um projeto com outro usuário
+// @Summary Compartilhar projeto
+// @Description Compartilha o projeto (somente leitura) com outro usuário, identificado pelo email. A resposta é a mesma para emails cadastrados ou não, e para projetos já compartilhados com o usuário
+// @Tags projects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do projeto"
+// @Param share body models.ShareRequest true "Usuário e permissão"
+// @Success 202 {object} map[string]interface{} "Solicitação de compartilhamento aceita"
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Projeto não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/projects/{id}/shares [post]
+func (h *SharingHandler) ShareProject(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do projeto da URL
+	projectIDStr := c.Param("id")
+	projectID, err := strconv.ParseUint(projectIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do projeto inválido"))
+		return
+	}
+
+	var req models.ShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+		return
+	}
+
+	if err := h.sharingService.ShareProject(userID, uint(projectID), &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Se o email pertencer a um usuário cadastrado, o projeto foi compartilhado com ele",
+	})
+}
+
+// ListProjectShares lista os compartilhamentos de um projeto
+// @Summary Listar compartilhamentos do projeto
+// @Description Lista os usuários com quem o projeto foi compartilhado
+// @Tags projects
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do projeto"
+// @Success 200 {array} models.SharedResource
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Projeto não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/projects/{id}/shares [get]
+func (h *SharingHandler) ListProjectShares(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do projeto da URL
+	projectIDStr := c.Param("id")
+	projectID, err := strconv.ParseUint(projectIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do projeto inválido"))
+		return
+	}
+
+	shares, err := h.sharingService.GetProjectShares(userID, uint(projectID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shares)
+}
+
+// RevokeProjectShare remove um compartilhamento de projeto
+// @Summary Remover compartilhamento do projeto
+// @Description Revoga o acesso de um usuário a um projeto compartilhado
+// @Tags projects
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do projeto"
+// @Param shareId path int true "ID do compartilhamento"
+// @Success 204 "Compartilhamento removido com sucesso"
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Projeto ou compartilhamento não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/projects/{id}/shares/{shareId} [delete]
+func (h *SharingHandler) RevokeProjectShare(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter IDs da URL
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do projeto inválido"))
+		return
+	}
+	shareID, err := strconv.ParseUint(c.Param("shareId"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do compartilhamento inválido"))
+		return
+	}
+
+	if err := h.sharingService.RevokeProjectShare(userID, uint(projectID), uint(shareID)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListSharedProjects lista os projetos compartilhados com o usuário
+// @Summary Listar projetos compartilhados comigo
+// @Description Lista os projetos de outros usuários compartilhados com o usuário autenticado
+// @Tags projects
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.Project
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/projects/shared [get]
+func (h *SharingHandler) ListSharedProjects(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	projects, err := h.sharingService.GetProjectsSharedWithUser(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaskCommentHandler gerencia as rotas de comentários das tarefas
+type TaskCommentHandler struct {
+	commentService services.TaskCommentService
+}
+
+// NewTaskCommentHandler cria uma nova instância do handler de comentários de tarefas
+func NewTaskCommentHandler(commentService services.TaskCommentService) *TaskCommentHandler {
+	return &TaskCommentHandler{
+		commentService: commentService,
+	}
+}
+
+// Create registra um comentário em uma tarefa
+// @Summary Comentar tarefa
+// @Description Registra um comentário na tarefa. Apenas o dono da tarefa pode comentar
+// @Tags tasks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID da tarefa"
+// @Param request body models.TaskCommentCreateRequest true "Texto do comentário"
+// @Success 201 {object} models.TaskCommentResponse
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Tarefa não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/tasks/{id}/comments [post]
+func (h *TaskCommentHandler) Create(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID da tarefa da URL
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da tarefa inválido"))
+		return
+	}
+
+	// Validar entrada JSON
+	var req models.TaskCommentCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+		return
+	}
+
+	// Chamar service para registrar o comentário
+	comment, err := h.commentService.Create(userID, uint(taskID), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// List lista os comentários de uma tarefa
+// @Summary Listar comentários da tarefa
+// @Description Lista os comentários da tarefa, dos mais recentes aos mais antigos, com o nome do autor
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID da tarefa"
+// @Success 200 {array} models.TaskCommentResponse
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Tarefa não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/tasks/{id}/comments [get]
+func (h *TaskCommentHandler) List(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID da tarefa da URL
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da tarefa inválido"))
+		return
+	}
+
+	// Chamar service para listar os comentários
+	comments, err := h.commentService.GetByTaskID(userID, uint(taskID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
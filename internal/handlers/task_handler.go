@@ -6,6 +6,7 @@
 	"crm-backend/pkg/errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,7 +25,7 @@ func NewTaskHandler(taskService services.TaskService) *TaskHandler {
 
 // Create cria uma nova tarefa
 // @Summary Criar nova tarefa
-// @Description Cria uma nova tarefa para o usuário
+// @Description Cria uma nova tarefa para o usuário. Toda tarefa começa como PENDING; um status enviado na criação é ignorado (use a atualização ou a conclusão para alterá-lo).
 // @Tags tasks
 // @Security BearerAuth
 // @Accept json
@@ -63,15 +64,19 @@ func (h *TaskHandler) Create(c *gin.Context) {
 // @Tags tasks
 // @Security BearerAuth
 // @Produce json
-// @Param status query string false "Status da tarefa (PENDING, COMPLETED)"
-// @Param priority query string false "Prioridade (LOW, MEDIUM, HIGH)"
+// @Param status query string false "Status da tarefa, um ou vários separados por vírgula (PENDING, IN_PROGRESS, COMPLETED)"
+// @Param priority query string false "Prioridade, uma ou várias separadas por vírgula (LOW, MEDIUM, HIGH)"
 // @Param contact_id query int false "ID do contato específico"
 // @Param project_id query int false "ID do projeto específico"
 // @Param due_before query string false "Vencimento antes de (formato: 2006-01-02T15:04:05Z)"
 // @Param due_after query string false "Vencimento depois de (formato: 2006-01-02T15:04:05Z)"
+// @Param has_due_date query bool false "true: apenas tarefas com vencimento; false: apenas tarefas sem vencimento"
+// @Param include_snoozed query bool false "Incluir tarefas adiadas (padrão: false)"
 // @Param limit query int false "Limite de resultados (padrão: 50)"
 // @Param offset query int false "Offset para paginação (padrão: 0)"
-// @Success 200 {array} models.Task
+// @Param changed_since query string false "Sincronização: apenas os alterados ou excluídos a partir deste momento (RFC3339), incluindo os excluídos com deleted e deleted_at"
+// @Param legacy query bool false "Retornar array simples sem metadados de paginação"
+// @Success 200 {object} models.PaginatedResponse[models.Task]
 // @Failure 400 {object} map[string]interface{} "Parâmetros inválidos"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
@@ -86,14 +91,25 @@ func (h *TaskHandler) List(c *gin.Context) {
 		return
 	}
 
+	// Sincronização incremental: inclui os excluídos, indicados em cada item
+	if filter.ChangedSince != nil {
+		page, err := h.taskService.GetChangedByUserID(userID, &filter)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		respondPaginated(c, page)
+		return
+	}
+
 	// Chamar service para listar tarefas
-	tasks, err := h.taskService.GetByUserID(userID, &filter)
+	page, err := h.taskService.GetPaginatedByUserID(userID, &filter)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, tasks)
+	respondPaginated(c, page)
 }
 
 // GetByID obtém uma tarefa específica
@@ -103,7 +119,10 @@ func (h *TaskHandler) List(c *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "ID da tarefa"
+// @Param If-None-Match header string false "ETag recebido anteriormente"
 // @Success 200 {object} models.Task
+// @Header 200 {string} ETag "Versão do recurso para requisições condicionais (If-None-Match)"
+// @Failure 304 "Não modificado (If-None-Match corresponde ao ETag atual)"
 // @Failure 400 {object} map[string]interface{} "ID inválido"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
@@ -128,7 +147,7 @@ func (h *TaskHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, task)
+	respondWithETag(c, task.ID, task.UpdatedAt, task)
 }
 
 // Update atualiza uma tarefa existente
@@ -145,6 +164,7 @@ func (h *TaskHandler) GetByID(c *gin.Context) {
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 403 {object} map[string]interface{} "Acesso negado"
 // @Failure 404 {object} map[string]interface{} "Tarefa não encontrada"
+// @Failure 409 {object} map[string]interface{} "Versão desatualizada (tarefa alterada por outra requisição)"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/tasks/{id} [put]
 func (h *TaskHandler) Update(c *gin.Context) {
@@ -210,9 +230,78 @@ func (h *TaskHandler) Delete(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// DeleteCompleted exclui as tarefas concluídas do usuário
+// @Summary Excluir tarefas concluídas
+// @Description Exclui (soft delete) todas as tarefas concluídas do usuário, opcionalmente apenas as concluídas antes de uma data. Tarefas pendentes ou em andamento não são afetadas
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Param before query string false "Excluir apenas as concluídas antes desta data (RFC3339 ou YYYY-MM-DD)"
+// @Success 200 {object} map[string]interface{} "Quantidade de tarefas excluídas"
+// @Failure 400 {object} map[string]interface{} "Data inválida"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/tasks/completed [delete]
+func (h *TaskHandler) DeleteCompleted(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var before *time.Time
+	if raw := c.Query("before"); raw != "" {
+		parsed, _, err := parseDateParam(raw)
+		if err != nil {
+			c.Error(errors.NewBadRequestError("Parâmetro 'before' inválido, use RFC3339 ou YYYY-MM-DD"))
+			return
+		}
+		before = &parsed
+	}
+
+	deleted, err := h.taskService.DeleteCompleted(userID, before)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": deleted})
+}
+
+// Restore restaura uma tarefa excluída
+// @Summary Restaurar tarefa excluída
+// @Description Restaura uma tarefa excluída (soft delete) do usuário
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID da tarefa"
+// @Success 200 {object} models.Task
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 404 {object} map[string]interface{} "Tarefa excluída não encontrada"
+// @Failure 409 {object} map[string]interface{} "Tarefa não está excluída"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/tasks/{id}/restore [post]
+func (h *TaskHandler) Restore(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID da tarefa da URL
+	taskIDStr := c.Param("id")
+	taskID, err := strconv.ParseUint(taskIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da tarefa inválido"))
+		return
+	}
+
+	// Chamar service para restaurar tarefa
+	task, err := h.taskService.Restore(userID, uint(taskID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
 // MarkTaskAsCompleted marca uma tarefa como concluída
 // @Summary Marcar tarefa como concluída
-// @Description Marca uma tarefa específica como concluída
+// @Description Marca uma tarefa específica como concluída. Para tarefas recorrentes, a próxima ocorrência é criada e referenciada em next_occurrence_id
 // @Tags tasks
 // @Security BearerAuth
 // @Produce json
@@ -280,6 +369,84 @@ func (h *TaskHandler) MarkTaskAsPending(c *gin.Context) {
 	c.JSON(http.StatusOK, task)
 }
 
+// MarkTaskAsInProgress marca uma tarefa como em andamento
+// @Summary Iniciar tarefa
+// @Description Marca uma tarefa específica como em andamento (IN_PROGRESS)
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID da tarefa"
+// @Success 200 {object} models.Task
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Tarefa não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/tasks/{id}/start [put]
+func (h *TaskHandler) MarkTaskAsInProgress(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID da tarefa da URL
+	taskIDStr := c.Param("id")
+	taskID, err := strconv.ParseUint(taskIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da tarefa inválido"))
+		return
+	}
+
+	// Chamar service para marcar tarefa como em andamento
+	task, err := h.taskService.MarkAsInProgress(userID, uint(taskID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// Snooze adia uma tarefa
+// @Summary Adiar tarefa
+// @Description Adia uma tarefa não concluída: até o momento informado ela fica fora das listas de tarefas pendentes (exceto com include_snoozed=true), atrasadas e próximas, e não gera notificações de vencimento
+// @Tags tasks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID da tarefa"
+// @Param request body models.TaskSnoozeRequest true "Momento até o qual a tarefa fica adiada (RFC3339, no futuro)"
+// @Success 200 {object} models.Task
+// @Failure 400 {object} map[string]interface{} "Dados inválidos ou tarefa concluída"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Tarefa não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/tasks/{id}/snooze [put]
+func (h *TaskHandler) Snooze(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID da tarefa da URL
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID da tarefa inválido"))
+		return
+	}
+
+	// Validar entrada JSON
+	var req models.TaskSnoozeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+		return
+	}
+
+	// Chamar service para adiar a tarefa
+	task, err := h.taskService.Snooze(userID, uint(taskID), req.Until)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
 // GetByContact lista tarefas de um contato específico
 // @Summary Listar tarefas de um contato
 // @Description Lista todas as tarefas associadas a um contato específico
@@ -373,6 +540,111 @@ func (h *TaskHandler) GetOverdue(c *gin.Context) {
 	c.JSON(http.StatusOK, tasks)
 }
 
+// GetAllByContact obtém todas as tarefas de um contato
+// @Summary Obter todas as tarefas de um contato
+// @Description Obtém as tarefas associadas diretamente ao contato e as dos projetos em que ele é o cliente, sem repetições
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Success 200 {array} models.Task
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/all-tasks [get]
+func (h *TaskHandler) GetAllByContact(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactIDStr := c.Param("id")
+	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	// Chamar service para obter todas as tarefas do contato
+	tasks, err := h.taskService.GetAllByContactID(userID, uint(contactID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetOverdueByContact obtém as tarefas em atraso de um contato
+// @Summary Obter tarefas em atraso de um contato
+// @Description Obtém as tarefas não concluídas com vencimento já passado associadas ao contato
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do contato"
+// @Success 200 {array} models.Task
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Contato não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/contacts/{id}/tasks/overdue [get]
+func (h *TaskHandler) GetOverdueByContact(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do contato da URL
+	contactIDStr := c.Param("id")
+	contactID, err := strconv.ParseUint(contactIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do contato inválido"))
+		return
+	}
+
+	// Chamar service para obter tarefas em atraso do contato
+	tasks, err := h.taskService.GetOverdueByContactID(userID, uint(contactID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetOverdueByProject obtém as tarefas em atraso de um projeto
+// @Summary Obter tarefas em atraso de um projeto
+// @Description Obtém as tarefas não concluídas com vencimento já passado associadas ao projeto (próprio ou compartilhado)
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do projeto"
+// @Success 200 {array} models.Task
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Projeto não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/projects/{id}/tasks/overdue [get]
+func (h *TaskHandler) GetOverdueByProject(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Obter ID do projeto da URL
+	projectIDStr := c.Param("id")
+	projectID, err := strconv.ParseUint(projectIDStr, 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do projeto inválido"))
+		return
+	}
+
+	// Chamar service para obter tarefas em atraso do projeto
+	tasks, err := h.taskService.GetOverdueByProjectID(userID, uint(projectID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
 // GetUpcoming obtém tarefas próximas do vencimento
 // @Summary Obter tarefas próximas do vencimento
 // @Description Obtém tarefas que vencem nos próximos dias
@@ -403,3 +675,73 @@ func (h *TaskHandler) GetUpcoming(c *gin.Context) {
 
 	c.JSON(http.StatusOK, tasks)
 }
+
+// MyDay obtém a visão diária das tarefas do usuário
+// @Summary Meu dia
+// @Description Retorna em uma única chamada as tarefas atrasadas, as que vencem até o fim de hoje e as que vencem nos próximos 7 dias, com os dias no fuso horário do usuário. Tarefas concluídas ou adiadas ficam de fora e cada grupo é ordenado por prioridade e depois pelo vencimento.
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} services.MyDayTasks
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/tasks/my-day [get]
+func (h *TaskHandler) MyDay(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	// Chamar service para montar a visão diária
+	myDay, err := h.taskService.GetMyDay(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, myDay)
+}
+
+// GetCalendar obtém as tarefas agrupadas pelo dia de vencimento
+// @Summary Calendário de tarefas
+// @Description Agrupa as tarefas do usuário (de qualquer status) com vencimento no intervalo pelo dia de vencimento, no fuso horário do usuário. Dias sem tarefas não aparecem e tarefas sem vencimento são ignoradas. O intervalo é de no máximo 90 dias.
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Param from query string true "Primeiro dia (YYYY-MM-DD)"
+// @Param to query string true "Último dia, inclusivo (YYYY-MM-DD)"
+// @Success 200 {object} map[string][]models.Task "Tarefas por dia (YYYY-MM-DD)"
+// @Failure 400 {object} map[string]interface{} "Intervalo inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/tasks/calendar [get]
+func (h *TaskHandler) GetCalendar(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	calendar, err := h.taskService.GetCalendar(userID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// GetStats obtém a contagem das tarefas por status e por prioridade
+// @Summary Estatísticas de tarefas
+// @Description Conta as tarefas do usuário agrupadas por status e por prioridade. Valores sem tarefas aparecem com 0.
+// @Tags tasks
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} services.TaskStats
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/tasks/stats [get]
+func (h *TaskHandler) GetStats(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	stats, err := h.taskService.GetStats(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
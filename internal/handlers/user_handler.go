@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"crm-backend/internal/events"
 	"crm-backend/internal/models"
 	"crm-backend/internal/services"
 	"crm-backend/pkg/errors"
 	"crm-backend/pkg/logger"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -59,18 +63,18 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 
 	profile, err := h.userService.GetProfile(userID)
 	if err != nil {
-		logger.LogError(err, "Erro ao buscar perfil do usuário", map[string]interface{}{
+		logger.LogError(err, "Erro ao buscar perfil do usuário", withRequestID(c, map[string]interface{}{
 			"user_id": userID,
-		})
+		}))
 		c.Error(err)
 		return
 	}
 
 	duration := time.Since(start)
-	logger.WithFields("INFO", "User Profile Retrieved", map[string]interface{}{
+	logger.WithFields("INFO", "User Profile Retrieved", withRequestID(c, map[string]interface{}{
 		"user_id":  userID,
 		"duration": duration,
-	})
+	}))
 
 	c.JSON(http.StatusOK, profile)
 }
@@ -202,18 +206,65 @@ func (h *UserHandler) DeleteAccount(c *gin.Context) {
 
 // GetStats obtém estatísticas do usuário
 // @Summary Obter estatísticas do usuário
-// @Description Retorna estatísticas consolidadas do usuário (contatos, tarefas, projetos)
+// @Description Retorna estatísticas consolidadas do usuário (contatos, tarefas, projetos). Sem from/to, considera todo o período
 // @Tags users
 // @Security BearerAuth
 // @Produce json
+// @Param from query string false "Início do intervalo (RFC3339 ou YYYY-MM-DD)"
+// @Param to query string false "Fim do intervalo, inclusivo (RFC3339 ou YYYY-MM-DD)"
+// @Param days query int false "Janela, em dias, de recent_interactions (1 a 365; padrão: RECENT_INTERACTIONS_DAYS)"
 // @Success 200 {object} services.UserStats
+// @Failure 400 {object} map[string]interface{} "Intervalo de datas ou janela inválidos"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/users/stats [get]
 func (h *UserHandler) GetStats(c *gin.Context) {
 	userID := c.GetUint("user_id")
 
-	stats, err := h.userService.GetUserStats(userID)
+	dateRange, err := parseDateRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	days, err := parseDaysParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	stats, err := h.userService.GetUserStats(userID, dateRange, days)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetConversionStats obtém as métricas de conversão de leads em clientes
+// @Summary Obter taxa de conversão de leads
+// @Description Retorna totais de leads e clientes, quantidade de leads convertidos e a taxa de conversão (percentual dos clientes atuais convertidos de leads). from/to filtram pela data de conversão
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param from query string false "Início do intervalo (RFC3339 ou YYYY-MM-DD)"
+// @Param to query string false "Fim do intervalo, inclusivo (RFC3339 ou YYYY-MM-DD)"
+// @Success 200 {object} services.ConversionStats
+// @Failure 400 {object} map[string]interface{} "Intervalo de datas inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/users/stats/conversion [get]
+func (h *UserHandler) GetConversionStats(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	dateRange, err := parseDateRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	stats, err := h.userService.GetConversionStats(userID, dateRange)
 	if err != nil {
 		c.Error(err)
 		return
@@ -229,7 +280,10 @@ func (h *UserHandler) GetStats(c *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Param limit query int false "Limite de resultados (padrão: 10)"
+// @Param days query int false "Janela, em dias, das interações consideradas (1 a 365; padrão: RECENT_ACTIVITIES_DAYS)"
+// @Param types query string false "Tipos de atividade separados por vírgula (task, project, contact, interaction; padrão: todos)"
 // @Success 200 {object} models.RecentActivityResponse
+// @Failure 400 {object} map[string]interface{} "Janela ou tipos inválidos"
 // @Failure 401 {object} map[string]interface{} "Não autorizado"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /api/users/activities [get]
@@ -244,27 +298,136 @@ func (h *UserHandler) GetRecentActivities(c *gin.Context) {
 		limit = 10
 	}
 
-	activities, err := h.userService.GetRecentActivities(userID, limit)
+	days, err := parseDaysParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	types, err := parseActivityTypesParam(c)
 	if err != nil {
-		logger.LogError(err, "Erro ao buscar atividades recentes", map[string]interface{}{
+		c.Error(err)
+		return
+	}
+
+	activities, err := h.userService.GetRecentActivities(userID, days, limit, types)
+	if err != nil {
+		logger.LogError(err, "Erro ao buscar atividades recentes", withRequestID(c, map[string]interface{}{
 			"user_id": userID,
 			"limit":   limit,
-		})
+		}))
 		c.Error(err)
 		return
 	}
 
 	duration := time.Since(start)
-	logger.WithFields("INFO", "User Recent Activities Retrieved", map[string]interface{}{
+	logger.WithFields("INFO", "User Recent Activities Retrieved", withRequestID(c, map[string]interface{}{
 		"user_id":        userID,
 		"limit":          limit,
 		"activity_count": activities.Count,
 		"duration":       duration,
-	})
+	}))
 
 	c.JSON(http.StatusOK, activities)
 }
 
+// StreamActivities transmite as atividades do usuário em tempo real via server-sent events
+// @Summary Fluxo de atividades em tempo real
+// @Description Mantém uma conexão aberta (text/event-stream) e envia cada nova atividade do usuário autenticado. Clientes que se reconectam podem enviar o cabeçalho Last-Event-ID para receber os eventos recentes perdidos
+// @Tags users
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "ID do último evento recebido"
+// @Success 200 {object} events.Event
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/users/activities/stream [get]
+func (h *UserHandler) StreamActivities(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var lastEventID uint64
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		if id, err := strconv.ParseUint(header, 10, 64); err == nil {
+			lastEventID = id
+		}
+	}
+
+	replay, stream, unsubscribe, err := h.userService.SubscribeActivities(userID, lastEventID)
+	if err != nil {
+		logger.LogError(err, "Erro ao assinar fluxo de atividades", withRequestID(c, map[string]interface{}{
+			"user_id": userID,
+		}))
+		c.Error(err)
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	logger.WithFields("INFO", "User Activity Stream Opened", withRequestID(c, map[string]interface{}{
+		"user_id":       userID,
+		"last_event_id": lastEventID,
+		"replayed":      len(replay),
+	}))
+
+	for _, event := range replay {
+		if err := writeActivityEvent(c, event); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(activityStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			logger.WithFields("INFO", "User Activity Stream Closed", withRequestID(c, map[string]interface{}{
+				"user_id": userID,
+			}))
+			return
+		case event, ok := <-stream:
+			if !ok {
+				// Barramento encerrado no desligamento do servidor
+				logger.WithFields("INFO", "User Activity Stream Closed", withRequestID(c, map[string]interface{}{
+					"user_id": userID,
+					"reason":  "shutdown",
+				}))
+				return
+			}
+			if err := writeActivityEvent(c, event); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			// Comentário SSE para manter a conexão viva através de proxies
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// activityStreamHeartbeat define o intervalo entre os comentários de keep-alive do fluxo de atividades
+const activityStreamHeartbeat = 30 * time.Second
+
+// writeActivityEvent escreve um evento no formato server-sent events
+func writeActivityEvent(c *gin.Context, event events.Event) error {
+	data, err := json.Marshal(event.Activity)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: activity\ndata: %s\n\n", event.ID, data)
+	return err
+}
+
 // GetDashboardData obtém dados específicos para o dashboard
 // @Summary Obter dados do dashboard
 // @Description Retorna dados específicos para o dashboard (projetos ativos, interações recentes, tarefas pendentes)
@@ -281,22 +444,78 @@ func (h *UserHandler) GetDashboardData(c *gin.Context) {
 
 	dashboardData, err := h.userService.GetDashboardData(userID)
 	if err != nil {
-		logger.LogError(err, "Erro ao buscar dados do dashboard", map[string]interface{}{
+		logger.LogError(err, "Erro ao buscar dados do dashboard", withRequestID(c, map[string]interface{}{
 			"user_id": userID,
-		})
+		}))
 		c.Error(err)
 		return
 	}
 
 	duration := time.Since(start)
-	logger.WithFields("INFO", "User Dashboard Data Retrieved", map[string]interface{}{
+	logger.WithFields("INFO", "User Dashboard Data Retrieved", withRequestID(c, map[string]interface{}{
 		"user_id":  userID,
 		"duration": duration,
-	})
+	}))
 
 	c.JSON(http.StatusOK, dashboardData)
 }
 
+// GetDailyDigest obtém o resumo diário do usuário
+// @Summary Obter resumo diário
+// @Description Retorna os totais e listas (limitadas a 20 itens cada) de tarefas concluídas, interações registradas, contatos adicionados e projetos alterados no dia, no fuso horário do usuário
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param date query string false "Dia do resumo (YYYY-MM-DD, padrão: hoje)"
+// @Success 200 {object} services.DailyDigest
+// @Failure 400 {object} map[string]interface{} "Data inválida"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/users/digest [get]
+func (h *UserHandler) GetDailyDigest(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	digest, err := h.userService.GetDailyDigest(userID, c.Query("date"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, digest)
+}
+
+// ExportAllData exporta todos os dados do usuário
+// @Summary Exportar todos os dados da conta
+// @Description Gera um único documento JSON com o perfil e todos os contatos, interações, tarefas e projetos do usuário (backup/portabilidade). Senhas e campos internos não são incluídos
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {file} file "Arquivo JSON com os dados da conta"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 404 {object} map[string]interface{} "Usuário não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/users/export [get]
+func (h *UserHandler) ExportAllData(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	filename := "crm-export-" + time.Now().UTC().Format("2006-01-02") + ".json"
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	// Escrever diretamente na resposta, sem carregar todos os dados em memória
+	if err := h.userService.ExportAllData(userID, c.Writer); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Error(err)
+			return
+		}
+		logger.LogError(err, "Account Export", withRequestID(c, map[string]interface{}{
+			"user_id": userID,
+		}))
+	}
+}
+
 // ChangePasswordRequest representa os dados para alteração de senha
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"current_password" binding:"required" example:"senhaAtual123"`
@@ -308,3 +527,82 @@ type ChangePasswordRequest struct {
 type DeleteAccountRequest struct {
 	Password string `json:"password" binding:"required" example:"minhaSenh123"`
 }
+
+// parseDateRange interpreta os parâmetros from/to aceitando RFC3339 ou YYYY-MM-DD.
+// Um "to" informado apenas como data cobre o dia inteiro.
+func parseDateRange(fromStr, toStr string) (*models.DateRange, error) {
+	dateRange := &models.DateRange{}
+
+	if fromStr != "" {
+		from, _, err := parseDateParam(fromStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("Parâmetro 'from' inválido, use RFC3339 ou YYYY-MM-DD")
+		}
+		dateRange.From = &from
+	}
+
+	if toStr != "" {
+		to, dateOnly, err := parseDateParam(toStr)
+		if err != nil {
+			return nil, errors.NewBadRequestError("Parâmetro 'to' inválido, use RFC3339 ou YYYY-MM-DD")
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		dateRange.To = &to
+	}
+
+	if dateRange.From != nil && dateRange.To != nil && dateRange.From.After(*dateRange.To) {
+		return nil, errors.NewBadRequestError("Parâmetro 'from' deve ser anterior ou igual a 'to'")
+	}
+
+	return dateRange, nil
+}
+
+// parseDateParam interpreta uma data em RFC3339 ou YYYY-MM-DD, indicando se veio apenas a data
+func parseDateParam(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
+
+// parseDaysParam interpreta o parâmetro opcional "days" (janela de itens recentes); ausente retorna 0,
+// que faz o service usar a janela padrão configurada
+func parseDaysParam(c *gin.Context) (int, error) {
+	value := c.Query("days")
+	if value == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.NewBadRequestError("Número de dias inválido")
+	}
+	return days, nil
+}
+
+// parseActivityTypesParam lê o parâmetro opcional "types", com tipos de atividade separados por
+// vírgula e sem diferenciar maiúsculas. Ausente retorna nil (todos os tipos).
+func parseActivityTypesParam(c *gin.Context) ([]models.ActivityType, error) {
+	value := c.Query("types")
+	if value == "" {
+		return nil, nil
+	}
+
+	var types []models.ActivityType
+	for _, part := range strings.Split(value, ",") {
+		activityType := models.ActivityType(strings.ToUpper(strings.TrimSpace(part)))
+		if activityType == "" {
+			continue
+		}
+		if !activityType.IsValid() {
+			return nil, errors.NewBadRequestError("Tipo de atividade inválido: " + part + ". Use: task, project, contact ou interaction")
+		}
+		types = append(types, activityType)
+	}
+	return types, nil
+}
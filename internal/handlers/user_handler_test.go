@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/pkg/errors"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestContext cria um contexto do gin para a requisição GET informada
+func newTestContext(target string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c
+}
+
+func TestParseActivityTypesParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []models.ActivityType
+		wantErr bool
+	}{
+		{name: "ausente", query: "", want: nil},
+		{name: "um tipo", query: "?types=task", want: []models.ActivityType{models.ActivityTypeTask}},
+		{name: "vários tipos com espaços e maiúsculas", query: "?types=Task,%20INTERACTION", want: []models.ActivityType{models.ActivityTypeTask, models.ActivityTypeInteraction}},
+		{name: "itens vazios ignorados", query: "?types=project,,", want: []models.ActivityType{models.ActivityTypeProject}},
+		{name: "tipo inválido", query: "?types=task,meeting", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseActivityTypesParam(newTestContext("/api/users/activities" + tt.query))
+			if tt.wantErr {
+				var appErr *errors.AppError
+				if !stderrors.As(err, &appErr) || appErr.Code != http.StatusBadRequest {
+					t.Fatalf("err = %v, want 400", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("tipos = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/services"
+	"crm-backend/pkg/errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler gerencia as rotas de webhooks
+type WebhookHandler struct {
+	webhookService services.WebhookService
+}
+
+// NewWebhookHandler cria uma nova instância do handler de webhooks
+func NewWebhookHandler(webhookService services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// Create cadastra um novo webhook
+// @Summary Cadastrar webhook
+// @Description Cadastra uma URL para receber eventos do CRM (ex.: contact.created, task.completed, project.status_changed). Cada entrega é um POST JSON assinado com HMAC-SHA256 do corpo no cabeçalho X-Signature ("sha256=<hex>"). Sem secret, um segredo é gerado e retornado apenas nesta resposta.
+// @Tags webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param webhook body models.WebhookCreateRequest true "Dados do webhook"
+// @Success 201 {object} models.WebhookCreateResponse
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/webhooks/create [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req models.WebhookCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados inválidos: " + err.Error()))
+		return
+	}
+
+	webhook, err := h.webhookService.Create(userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// List lista os webhooks do usuário
+// @Summary Listar webhooks
+// @Description Lista os webhooks cadastrados pelo usuário
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.Webhook
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/webhooks/list [get]
+func (h *WebhookHandler) List(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	webhooks, err := h.webhookService.GetByUserID(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetByID obtém um webhook específico
+// @Summary Obter webhook
+// @Description Obtém um webhook do usuário pelo ID
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do webhook"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Webhook não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/webhooks/{id} [get]
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do webhook inválido"))
+		return
+	}
+
+	webhook, err := h.webhookService.GetByID(userID, uint(webhookID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// Update atualiza um webhook
+// @Summary Atualizar webhook
+// @Description Atualiza a URL, os eventos, o segredo ou o estado (ativo/inativo) de um webhook
+// @Tags webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do webhook"
+// @Param webhook body models.WebhookUpdateRequest true "Dados para atualização"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} map[string]interface{} "Dados inválidos"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Webhook não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/webhooks/{id} [put]
+func (h *WebhookHandler) Update(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do webhook inválido"))
+		return
+	}
+
+	var req models.WebhookUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBadRequestError("Dados inválidos: " + err.Error()))
+		return
+	}
+
+	webhook, err := h.webhookService.Update(userID, uint(webhookID), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// Delete remove um webhook
+// @Summary Excluir webhook
+// @Description Remove um webhook do usuário
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID do webhook"
+// @Success 204 "Webhook excluído"
+// @Failure 400 {object} map[string]interface{} "ID inválido"
+// @Failure 401 {object} map[string]interface{} "Não autorizado"
+// @Failure 403 {object} map[string]interface{} "Acesso negado"
+// @Failure 404 {object} map[string]interface{} "Webhook não encontrado"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /api/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewBadRequestError("ID do webhook inválido"))
+		return
+	}
+
+	if err := h.webhookService.Delete(userID, uint(webhookID)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
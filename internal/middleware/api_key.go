@@ -0,0 +1,34 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// APIKeyHeader é o cabeçalho HTTP com a chave de API usada no lugar do token JWT
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator identifica o usuário dono de uma chave de API
+type APIKeyAuthenticator interface {
+	Authenticate(key string) (uint, error)
+}
+
+// APIKeyAuth middleware que aceita uma chave de API como alternativa ao token JWT.
+// Requisições com o cabeçalho X-API-Key são autenticadas pela chave, definindo "user_id" no
+// contexto como o middleware JWT; as demais seguem para tokenAuth (ex.: AuthMiddleware).
+func APIKeyAuth(authenticator APIKeyAuthenticator, tokenAuth gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			tokenAuth(c)
+			return
+		}
+
+		userID, err := authenticator.Authenticate(key)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", userID)
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"crm-backend/pkg/errors"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit middleware que limita o tamanho do corpo das requisições a maxBytes, respondendo 413
+// quando o limite é excedido. Corpos com Content-Length maior são recusados antes de serem lidos;
+// nos demais, a leitura é interrompida ao atingir o limite. As rotas em skipRoutes (caminho
+// registrado, ex.: "/api/interactions/:id/attachments") aplicam o próprio limite, maior.
+// Deve ser registrado depois do ErrorHandler.
+func BodyLimit(maxBytes int64, skipRoutes ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(skipRoutes))
+	for _, route := range skipRoutes {
+		skip[route] = true
+	}
+	tooLarge := errors.NewAppError(http.StatusRequestEntityTooLarge, "Corpo da requisição muito grande",
+		fmt.Sprintf("O limite é de %d bytes", maxBytes))
+
+	return func(c *gin.Context) {
+		if maxBytes <= 0 || c.Request.Body == nil || c.Request.Body == http.NoBody || skip[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > maxBytes {
+			c.Error(tooLarge)
+			c.Abort()
+			return
+		}
+
+		body := &limitedBody{ReadCloser: http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)}
+		c.Request.Body = body
+
+		c.Next()
+
+		// O handler trata a falha de leitura como dados inválidos; substituir pelo 413
+		if body.exceeded && !c.Writer.Written() {
+			c.Errors = c.Errors[:0]
+			c.Error(tooLarge)
+		}
+	}
+}
+
+// limitedBody registra se a leitura do corpo foi interrompida pelo limite de tamanho
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+// Read lê do corpo limitado, marcando quando o limite é atingido
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if stderrors.As(err, &maxBytesErr) {
+		b.exceeded = true
+	}
+	return n, err
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"crm-backend/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBodyLimit(t *testing.T) {
+	bind := func(c *gin.Context) {
+		var body map[string]interface{}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(errors.NewBadRequestError("Dados de entrada inválidos: " + err.Error()))
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+
+	router := gin.New()
+	router.Use(ErrorHandler(), BodyLimit(64, "/uploads"))
+	router.POST("/items", bind)
+	router.POST("/uploads", bind)
+
+	small := `{"name":"Ana"}`
+	large := `{"name":"` + strings.Repeat("a", 200) + `"}`
+
+	tests := []struct {
+		name          string
+		path          string
+		body          string
+		unknownLength bool // Sem Content-Length (ex.: chunked): o limite vale durante a leitura
+		wantStatus    int
+	}{
+		{name: "corpo dentro do limite", path: "/items", body: small, wantStatus: http.StatusOK},
+		{name: "Content-Length acima do limite", path: "/items", body: large, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "corpo sem tamanho acima do limite", path: "/items", body: large, unknownLength: true, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "corpo sem tamanho dentro do limite", path: "/items", body: small, unknownLength: true, wantStatus: http.StatusOK},
+		{name: "rota com limite próprio", path: "/uploads", body: large, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.unknownLength {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
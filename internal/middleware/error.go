@@ -21,7 +21,12 @@ func ErrorHandler() gin.HandlerFunc {
 
 			// Verificar se é um erro da aplicação
 			if appErr, ok := err.Err.(*errors.AppError); ok {
-				logger.Warning("Application error:", appErr.Message, "Details:", appErr.Details)
+				logger.WithFields("WARNING", "Application error", map[string]interface{}{
+					"error":      appErr.Message,
+					"details":    appErr.Details,
+					"path":       c.Request.URL.Path,
+					RequestIDKey: c.GetString(RequestIDKey),
+				})
 				c.JSON(appErr.Code, gin.H{
 					"error":   appErr.Message,
 					"details": appErr.Details,
@@ -30,7 +35,11 @@ func ErrorHandler() gin.HandlerFunc {
 			}
 
 			// Erro genérico
-			logger.Error("Unexpected error:", err.Error())
+			logger.WithFields("ERROR", "Unexpected error", map[string]interface{}{
+				"error":      err.Error(),
+				"path":       c.Request.URL.Path,
+				RequestIDKey: c.GetString(RequestIDKey),
+			})
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Erro interno do servidor",
 			})
@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader é o cabeçalho com a chave de idempotência enviada pelo cliente
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader indica que a resposta foi reproduzida a partir de uma requisição anterior
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// idempotencySweepInterval é o intervalo mínimo entre as limpezas de chaves expiradas
+	idempotencySweepInterval = time.Minute
+)
+
+// idempotencyEntry guarda o estado de uma chave de idempotência
+type idempotencyEntry struct {
+	method      string
+	path        string
+	done        bool // false enquanto a primeira requisição ainda está em processamento
+	status      int
+	contentType string
+	body        []byte
+	expiresAt   time.Time
+}
+
+// IdempotencyStore armazena em memória as respostas associadas a cada (usuário, chave)
+type IdempotencyStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]*idempotencyEntry
+	lastSweep time.Time
+}
+
+// NewIdempotencyStore cria um armazenamento de chaves de idempotência com o tempo de expiração informado
+func NewIdempotencyStore(ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		ttl:     ttl,
+		entries: make(map[string]*idempotencyEntry),
+	}
+}
+
+// begin reserva a chave para a requisição atual. Se a chave já existir (e não estiver expirada),
+// retorna a entrada existente e false.
+func (s *IdempotencyStore) begin(key, method, path string) (*idempotencyEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		copied := *entry
+		return &copied, false
+	}
+
+	s.entries[key] = &idempotencyEntry{
+		method:    method,
+		path:      path,
+		expiresAt: now.Add(s.ttl),
+	}
+	return nil, true
+}
+
+// complete grava a resposta da requisição que reservou a chave
+func (s *IdempotencyStore) complete(key string, status int, contentType string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok {
+		entry.done = true
+		entry.status = status
+		entry.contentType = contentType
+		entry.body = body
+	}
+}
+
+// release libera a chave para que o cliente possa tentar novamente
+func (s *IdempotencyStore) release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+// sweep remove as chaves expiradas (no máximo uma vez por idempotencySweepInterval)
+func (s *IdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < idempotencySweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// idempotencyWriter copia o corpo da resposta enquanto ele é enviado ao cliente
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency middleware que torna requisições POST autenticadas seguras para reenvio.
+// A primeira requisição com um cabeçalho Idempotency-Key tem sua resposta de sucesso (2xx) armazenada
+// por usuário e chave; repetições dentro do prazo recebem a mesma resposta sem executar o handler.
+// Respostas de erro não são armazenadas, permitindo que o cliente tente novamente com a mesma chave.
+// Deve ser registrado após o AuthMiddleware.
+func Idempotency(store *IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		userID := c.GetUint("user_id")
+		if c.Request.Method != http.MethodPost || key == "" || userID == 0 {
+			c.Next()
+			return
+		}
+
+		// Mesmas regras do X-Request-ID: curta e apenas com caracteres ASCII visíveis
+		if !isValidRequestID(key) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "Cabeçalho Idempotency-Key inválido",
+			})
+			return
+		}
+
+		storeKey := strconv.FormatUint(uint64(userID), 10) + ":" + key
+		method, path := c.Request.Method, c.Request.URL.Path
+
+		entry, reserved := store.begin(storeKey, method, path)
+		if !reserved {
+			switch {
+			case entry.method != method || entry.path != path:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+					"error": "Idempotency-Key já utilizada em outra requisição",
+				})
+			case !entry.done:
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+					"error": "Requisição com esta Idempotency-Key ainda está em processamento",
+				})
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(entry.status, entry.contentType, entry.body)
+				c.Abort()
+			}
+			return
+		}
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		// Liberar a chave também se o handler entrar em pânico
+		stored := false
+		defer func() {
+			if !stored {
+				store.release(storeKey)
+			}
+		}()
+
+		c.Next()
+
+		status := writer.Status()
+		if len(c.Errors) == 0 && status >= 200 && status < 300 {
+			store.complete(storeKey, status, writer.Header().Get("Content-Type"), writer.body.Bytes())
+			stored = true
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"crm-backend/internal/config"
 	"crm-backend/pkg/logger"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -23,8 +25,14 @@ func Logger() gin.HandlerFunc {
 	})
 }
 
-// CustomLogger middleware mais detalhado
-func CustomLogger() gin.HandlerFunc {
+// CustomLogger middleware mais detalhado.
+// Com LOG_FORMAT=json, cada requisição é registrada como uma entrada JSON do StructuredLog.
+func CustomLogger(loggingConfig *config.LoggingConfig) gin.HandlerFunc {
+	jsonFormat := strings.EqualFold(loggingConfig.Format, "json")
+	if jsonFormat && logger.StructuredLog == nil {
+		logger.InitStructuredLogger()
+	}
+
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -61,6 +69,20 @@ func CustomLogger() gin.HandlerFunc {
 		if len(c.Errors) > 0 {
 			fields["errors"] = c.Errors.String()
 		}
+		if requestID := c.GetString(RequestIDKey); requestID != "" {
+			fields[RequestIDKey] = requestID
+		}
+
+		if jsonFormat {
+			// Campos adicionais para correlação; a latência vai em milissegundos para facilitar consultas
+			fields["latency"] = float64(latency.Microseconds()) / 1000
+			if userID, exists := c.Get("user_id"); exists {
+				fields["user_id"] = userID
+			}
+
+			logger.StructuredLog.Log(requestLogLevel(statusCode), "HTTP Request", fields, nil)
+			return
+		}
 
 		// Log baseado no status code
 		if statusCode >= 500 {
@@ -72,3 +94,15 @@ func CustomLogger() gin.HandlerFunc {
 		}
 	}
 }
+
+// requestLogLevel retorna o nível de log adequado ao status code da resposta
+func requestLogLevel(statusCode int) string {
+	switch {
+	case statusCode >= 500:
+		return "ERROR"
+	case statusCode >= 400:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
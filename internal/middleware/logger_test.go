@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"crm-backend/internal/config"
+	"crm-backend/pkg/logger"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCustomLoggerJSONFormat(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.log")
+	if err := logger.Init(logger.Options{Output: logger.OutputFile, File: file}); err != nil {
+		t.Fatalf("logger.Init: %v", err)
+	}
+	t.Cleanup(func() { logger.Init(logger.Options{}) })
+
+	router := gin.New()
+	router.Use(RequestID())
+	router.Use(func(c *gin.Context) { c.Set("user_id", uint(7)) })
+	router.Use(CustomLogger(&config.LoggingConfig{Format: "json"}))
+	router.GET("/api/contacts/:id", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/contacts/3?legacy=true", nil))
+	logger.Close()
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry logger.LogEntry
+	line := strings.TrimSpace(string(data))
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("a requisição deveria gerar uma linha JSON: %v\n%s", err, line)
+	}
+
+	if entry.Level != "WARNING" || entry.Message != "HTTP Request" {
+		t.Errorf("nível/mensagem = %s/%s, want WARNING/HTTP Request", entry.Level, entry.Message)
+	}
+	want := map[string]interface{}{
+		"method":      "GET",
+		"path":        "/api/contacts/3?legacy=true",
+		"status_code": float64(http.StatusNotFound),
+		"user_id":     float64(7),
+		RequestIDKey:  w.Header().Get(RequestIDHeader),
+	}
+	for key, value := range want {
+		if entry.Fields[key] != value {
+			t.Errorf("campo %s = %v, want %v", key, entry.Fields[key], value)
+		}
+	}
+	if _, ok := entry.Fields["latency"].(float64); !ok {
+		t.Errorf("latency = %v, want número em milissegundos", entry.Fields["latency"])
+	}
+}
+
+func TestRequestLogLevel(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusOK, "INFO"},
+		{http.StatusNotModified, "INFO"},
+		{http.StatusBadRequest, "WARNING"},
+		{http.StatusNotFound, "WARNING"},
+		{http.StatusInternalServerError, "ERROR"},
+		{http.StatusServiceUnavailable, "ERROR"},
+	}
+
+	for _, tt := range tests {
+		if got := requestLogLevel(tt.status); got != tt.want {
+			t.Errorf("requestLogLevel(%d) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
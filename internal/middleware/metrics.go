@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"crm-backend/pkg/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute é o rótulo usado para requisições que não correspondem a nenhuma rota registrada
+const unmatchedRoute = "unmatched"
+
+// Metrics middleware que registra contagem, latência e erros das requisições por método e modelo de rota.
+// Usa o modelo da rota (ex.: /api/contacts/:id) em vez do caminho bruto para evitar uma série por ID.
+func Metrics(registry *metrics.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		registry.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
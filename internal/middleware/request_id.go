@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader é o cabeçalho HTTP usado para propagar o ID da requisição
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey é a chave do ID da requisição no contexto do Gin
+	RequestIDKey = "request_id"
+
+	// maxRequestIDLength limita o tamanho de IDs recebidos de clientes
+	maxRequestIDLength = 128
+)
+
+// RequestID middleware que atribui um ID de correlação a cada requisição.
+// Reutiliza o cabeçalho X-Request-ID recebido (se válido) ou gera um UUID, disponibiliza-o
+// no contexto em "request_id" e o devolve no cabeçalho da resposta.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = newUUID()
+		}
+
+		c.Set(RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+// isValidRequestID aceita apenas IDs curtos com caracteres ASCII visíveis, evitando injeção nos logs
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newUUID gera um UUID versão 4 aleatório
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // versão 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variante RFC 4122
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleContextKey é a chave do contexto com o papel do usuário autenticado, definida a partir da
+// claim "role" do token JWT
+const RoleContextKey = "role"
+
+// RoleResolver obtém o papel atual de um usuário
+type RoleResolver interface {
+	GetRole(userID uint) (models.UserRole, error)
+}
+
+// RequireRole middleware que só permite a requisição a usuários com o papel informado (ou
+// administradores), respondendo 403 aos demais. O papel vem da claim do token, quando presente no
+// contexto; caso contrário (ex.: autenticação por chave de API) é consultado pelo resolver.
+// Deve ser registrado depois da autenticação.
+func RequireRole(resolver RoleResolver, role models.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		current := models.UserRole(c.GetString(RoleContextKey))
+		if !current.IsValid() {
+			resolved, err := resolver.GetRole(c.GetUint("user_id"))
+			if err != nil {
+				c.Error(err)
+				c.Abort()
+				return
+			}
+			current = resolved
+			c.Set(RoleContextKey, string(current))
+		}
+
+		if !current.Allows(role) {
+			c.Error(errors.ErrForbidden)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
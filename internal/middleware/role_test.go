@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/pkg/errors"
+	"crm-backend/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	if err := logger.Init(logger.Options{}); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeRoleResolver devolve os papéis cadastrados e conta as consultas
+type fakeRoleResolver struct {
+	roles map[uint]models.UserRole
+	calls int
+}
+
+func (r *fakeRoleResolver) GetRole(userID uint) (models.UserRole, error) {
+	r.calls++
+	role, ok := r.roles[userID]
+	if !ok {
+		return "", errors.ErrUnauthorized
+	}
+	return role, nil
+}
+
+func TestRequireRole(t *testing.T) {
+	resolver := &fakeRoleResolver{roles: map[uint]models.UserRole{
+		1: models.RoleUser,
+		2: models.RoleAdmin,
+	}}
+
+	tests := []struct {
+		name         string
+		userID       uint
+		claim        string // papel vindo do token; vazio quando ausente
+		required     models.UserRole
+		wantStatus   int
+		wantResolver bool
+	}{
+		{name: "papel exigido", userID: 1, required: models.RoleUser, wantStatus: http.StatusOK, wantResolver: true},
+		{name: "papel insuficiente", userID: 1, required: models.RoleAdmin, wantStatus: http.StatusForbidden, wantResolver: true},
+		{name: "administrador acessa rota de usuário", userID: 2, required: models.RoleUser, wantStatus: http.StatusOK, wantResolver: true},
+		{name: "administrador acessa rota administrativa", userID: 2, required: models.RoleAdmin, wantStatus: http.StatusOK, wantResolver: true},
+		{name: "claim do token dispensa o resolver", userID: 1, claim: "ADMIN", required: models.RoleAdmin, wantStatus: http.StatusOK},
+		{name: "claim do token insuficiente", userID: 2, claim: "USER", required: models.RoleAdmin, wantStatus: http.StatusForbidden},
+		{name: "claim inválida consulta o resolver", userID: 2, claim: "ROOT", required: models.RoleAdmin, wantStatus: http.StatusOK, wantResolver: true},
+		{name: "usuário inexistente", userID: 3, required: models.RoleUser, wantStatus: http.StatusUnauthorized, wantResolver: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver.calls = 0
+			router := gin.New()
+			router.Use(ErrorHandler())
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				if tt.claim != "" {
+					c.Set(RoleContextKey, tt.claim)
+				}
+			})
+			router.GET("/admin", RequireRole(resolver, tt.required), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if called := resolver.calls > 0; called != tt.wantResolver {
+				t.Fatalf("resolver consultado = %v, want %v", called, tt.wantResolver)
+			}
+		})
+	}
+}
@@ -13,6 +13,23 @@
 	ActivityTypeInteraction ActivityType = "INTERACTION" // Nova interação, editada, excluída
 )
 
+// ActivityTypes lista todos os tipos de atividade
+var ActivityTypes = []ActivityType{
+	ActivityTypeTask,
+	ActivityTypeProject,
+	ActivityTypeContact,
+	ActivityTypeInteraction,
+}
+
+// IsValid verifica se o tipo de atividade é conhecido
+func (t ActivityType) IsValid() bool {
+	switch t {
+	case ActivityTypeTask, ActivityTypeProject, ActivityTypeContact, ActivityTypeInteraction:
+		return true
+	}
+	return false
+}
+
 // ActivityAction define o tipo de ação realizada
 type ActivityAction string
 
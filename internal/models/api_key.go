@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// MaxAPIKeyNameLength é o tamanho máximo do nome de uma chave de API
+const MaxAPIKeyNameLength = 100
+
+// APIKey representa uma chave de API de um usuário, usada por integrações servidor-a-servidor
+// no lugar do token JWT. Apenas o hash da chave é armazenado.
+type APIKey struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	KeyHash    string     `json:"-" gorm:"not null;uniqueIndex"`
+	Name       string     `json:"name" gorm:"size:100;not null"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// Relacionamentos
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// GetOwnerID retorna o ID do usuário dono da chave
+func (k *APIKey) GetOwnerID() uint {
+	return k.UserID
+}
+
+// APIKeyCreateRequest representa os dados para criação de uma chave de API
+type APIKeyCreateRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// APIKeyCreateResponse representa a chave criada, incluindo o valor em texto puro
+// (retornado apenas nesta resposta)
+type APIKeyCreateResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Attachment representa um arquivo anexado a uma interação
+type Attachment struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	InteractionID uint           `json:"interaction_id" gorm:"not null;index"`
+	Filename      string         `json:"filename" gorm:"not null"`
+	ContentType   string         `json:"content_type" gorm:"not null"`
+	Size          int64          `json:"size" gorm:"not null"`
+	StoragePath   string         `json:"-" gorm:"not null"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relacionamentos
+	Interaction Interaction `json:"-" gorm:"foreignKey:InteractionID"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntityType representa o tipo de entidade registrada no log de auditoria
+type AuditEntityType string
+
+const (
+	AuditEntityContact     AuditEntityType = "contact"
+	AuditEntityTask        AuditEntityType = "task"
+	AuditEntityProject     AuditEntityType = "project"
+	AuditEntityInteraction AuditEntityType = "interaction"
+)
+
+// AuditAction representa a operação registrada no log de auditoria
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "CREATE"
+	AuditActionUpdate  AuditAction = "UPDATE"
+	AuditActionDelete  AuditAction = "DELETE"
+	AuditActionRestore AuditAction = "RESTORE" // Restauração de um registro excluído (soft delete)
+	AuditActionPurge   AuditAction = "PURGE"   // Remoção definitiva
+)
+
+// AuditLog representa o registro de uma alteração em uma entidade
+type AuditLog struct {
+	ID          uint            `json:"id" gorm:"primaryKey"`
+	UserID      uint            `json:"user_id" gorm:"not null;index"`
+	EntityType  AuditEntityType `json:"entity_type" gorm:"not null;index:idx_audit_logs_entity"`
+	EntityID    uint            `json:"entity_id" gorm:"not null;index:idx_audit_logs_entity"`
+	Action      AuditAction     `json:"action" gorm:"not null"`
+	ChangesJSON string          `json:"-" gorm:"column:changes_json;type:jsonb"`
+	Changes     json.RawMessage `json:"changes,omitempty" gorm:"-"` // Preenchido a partir de ChangesJSON nas consultas
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// AuditLogFilter representa os filtros para consulta do log de auditoria
+type AuditLogFilter struct {
+	EntityType AuditEntityType `form:"entity_type" validate:"omitempty,oneof=contact task project interaction"`
+	EntityID   uint            `form:"entity_id"`
+	Limit      int             `form:"limit" validate:"omitempty,min=0"`
+	Offset     int             `form:"offset" validate:"omitempty,min=0"`
+}
@@ -14,26 +14,53 @@
 	ContactTypeLead   ContactType = "LEAD"
 )
 
+// IsValid verifica se o tipo de contato é conhecido
+func (t ContactType) IsValid() bool {
+	switch t {
+	case ContactTypeClient, ContactTypeLead:
+		return true
+	}
+	return false
+}
+
+// MaxContactBatchSize limita a quantidade de contatos de uma operação em lote
+const MaxContactBatchSize = 500
+
 // Contact representa um contato (cliente ou lead)
 type Contact struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Name      string         `json:"name" gorm:"not null" validate:"required,min=2,max=255"`
-	Email     string         `json:"email" gorm:"not null" validate:"required,email"`
-	Phone     string         `json:"phone,omitempty" validate:"omitempty,max=50"`
-	Company   string         `json:"company,omitempty" validate:"omitempty,max=255"`
-	Position  string         `json:"position,omitempty" validate:"omitempty,max=255"`
-	Type      ContactType    `json:"type" gorm:"not null" validate:"required,oneof=CLIENT LEAD"`
-	Notes     string         `json:"notes,omitempty"`
-	UserID    uint           `json:"user_id" gorm:"not null"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Name            string         `json:"name" gorm:"not null" validate:"required,min=2,max=255"`
+	Email           string         `json:"email" gorm:"not null" validate:"required,email"`
+	Phone           string         `json:"phone,omitempty" validate:"omitempty,max=50"`
+	Company         string         `json:"company,omitempty" validate:"omitempty,max=255"`
+	Position        string         `json:"position,omitempty" validate:"omitempty,max=255"`
+	Type            ContactType    `json:"type" gorm:"not null" validate:"required,oneof=CLIENT LEAD"`
+	Notes           string         `json:"notes,omitempty"`
+	UserID          uint           `json:"user_id" gorm:"not null"`
+	ConvertedAt     *time.Time     `json:"converted_at,omitempty" gorm:"index"`      // Preenchido quando um lead é convertido em cliente
+	LastContactedAt *time.Time     `json:"last_contacted_at,omitempty" gorm:"index"` // Último contato (interação registrada ou toque manual)
+	Version         uint           `json:"version" gorm:"not null;default:1"`        // Incrementada a cada atualização (controle de concorrência otimista)
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"index"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relacionamentos
 	User         User          `json:"user,omitempty" gorm:"foreignKey:UserID"`
 	Interactions []Interaction `json:"interactions,omitempty" gorm:"foreignKey:ContactID"`
 	Tasks        []Task        `json:"tasks,omitempty" gorm:"foreignKey:ContactID"`
 	Projects     []Project     `json:"projects,omitempty" gorm:"foreignKey:ClientID"`
+	Tags         []Tag         `json:"tags,omitempty" gorm:"many2many:contact_tags"`
+}
+
+// GetOwnerID retorna o ID do usuário dono do contato
+func (c *Contact) GetOwnerID() uint {
+	return c.UserID
+}
+
+// DeletedContact representa um contato na lixeira (excluído via soft delete)
+type DeletedContact struct {
+	Contact
+	DeletedAt time.Time `json:"deleted_at"`
 }
 
 // ContactCreateRequest representa os dados para criação de contato
@@ -47,21 +74,73 @@ type ContactCreateRequest struct {
 	Notes    string      `json:"notes,omitempty"`
 }
 
-// ContactUpdateRequest representa os dados para atualização de contato
+// ContactUpdateRequest representa os dados para atualização de contato.
+// Nos campos opcionais (ponteiros), ausente mantém o valor atual e "" limpa o campo.
 type ContactUpdateRequest struct {
 	Name     string      `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
 	Email    string      `json:"email,omitempty" validate:"omitempty,email"`
-	Phone    string      `json:"phone,omitempty" validate:"omitempty,max=50"`
-	Company  string      `json:"company,omitempty" validate:"omitempty,max=255"`
-	Position string      `json:"position,omitempty" validate:"omitempty,max=255"`
+	Phone    *string     `json:"phone,omitempty" validate:"omitempty,max=50"`
+	Company  *string     `json:"company,omitempty" validate:"omitempty,max=255"`
+	Position *string     `json:"position,omitempty" validate:"omitempty,max=255"`
 	Type     ContactType `json:"type,omitempty" validate:"omitempty,oneof=CLIENT LEAD"`
-	Notes    string      `json:"notes,omitempty"`
+	Notes    *string     `json:"notes,omitempty"`
+	Version  *uint       `json:"version,omitempty"` // Versão esperada do registro; se informada e divergente da atual, retorna 409
+}
+
+// ContactBatchTypeRequest representa os dados para alteração do tipo de vários contatos
+type ContactBatchTypeRequest struct {
+	IDs  []uint      `json:"ids" binding:"required,min=1"`
+	Type ContactType `json:"type" binding:"required"` // CLIENT ou LEAD
+}
+
+// BatchResult representa o resultado de uma operação em lote: os IDs alterados e os ignorados
+// (inexistentes, de outro usuário ou que não podiam ser alterados)
+type BatchResult struct {
+	Updated []uint `json:"updated"`
+	Skipped []uint `json:"skipped"`
+}
+
+// DuplicateReason indica o critério pelo qual contatos foram considerados possíveis duplicatas
+type DuplicateReason string
+
+const (
+	DuplicateReasonEmail DuplicateReason = "SAME_EMAIL"   // Mesmo e-mail, sem diferenciar maiúsculas
+	DuplicateReasonName  DuplicateReason = "SIMILAR_NAME" // Mesmo nome e empresa após normalização
+)
+
+// ContactDuplicateGroup representa um grupo de contatos que provavelmente são a mesma pessoa
+type ContactDuplicateGroup struct {
+	Reason     DuplicateReason `json:"reason"`
+	Key        string          `json:"key"` // Valor em comum (e-mail ou nome/empresa normalizados)
+	ContactIDs []uint          `json:"contact_ids"`
+}
+
+// FollowUpReason indica por que um contato precisa de acompanhamento
+type FollowUpReason string
+
+const (
+	FollowUpReasonTaskDue   FollowUpReason = "TASK_DUE"   // Tarefa não concluída vencendo hoje ou atrasada
+	FollowUpReasonStaleLead FollowUpReason = "STALE_LEAD" // Lead sem interação há pelo menos N dias
+)
+
+// ContactFollowUp representa um contato que precisa de acompanhamento e os motivos
+type ContactFollowUp struct {
+	Contact           Contact          `json:"contact"`
+	Reasons           []FollowUpReason `json:"reasons"`
+	DueTasks          int64            `json:"due_tasks"`                     // Tarefas não concluídas vencendo até o fim de hoje
+	LastInteractionAt *time.Time       `json:"last_interaction_at,omitempty"` // Última interação realizada (ausente se nunca houve)
 }
 
 // ContactListFilter representa os filtros para listagem de contatos
 type ContactListFilter struct {
-	Type   ContactType `form:"type" validate:"omitempty,oneof=CLIENT LEAD"`
-	Search string      `form:"search"`
-	Limit  int         `form:"limit" validate:"omitempty,min=1,max=100"`
-	Offset int         `form:"offset" validate:"omitempty,min=0"`
+	Type         ContactType `form:"type" validate:"omitempty,oneof=CLIENT LEAD"`
+	Search       string      `form:"search"`
+	Company      string      `form:"company"`       // Busca parcial pela empresa, sem diferenciar maiúsculas
+	HasProjects  *bool       `form:"has_projects"`  // true: apenas clientes com projetos; false: contatos sem projetos
+	Tags         string      `form:"tags"`          // Lista separada por vírgulas; retorna contatos com qualquer uma das etiquetas
+	ChangedSince *time.Time  `form:"changed_since"` // Sincronização: alterados ou excluídos a partir deste momento, incluindo os excluídos
+	Sort         string      `form:"sort" validate:"omitempty,oneof=name email company created_at updated_at"`
+	Order        string      `form:"order" validate:"omitempty,oneof=asc desc"`
+	Limit        int         `form:"limit" validate:"omitempty,min=0"`
+	Offset       int         `form:"offset" validate:"omitempty,min=0"`
 }
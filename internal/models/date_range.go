@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// DateRange representa um intervalo de datas opcional (limites inclusivos).
+// Limites nulos deixam o intervalo aberto naquele extremo.
+type DateRange struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+// IsZero indica se o intervalo não possui limites (todo o período)
+func (r *DateRange) IsZero() bool {
+	return r == nil || (r.From == nil && r.To == nil)
+}
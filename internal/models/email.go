@@ -0,0 +1,8 @@
+package models
+
+import "strings"
+
+// NormalizeEmail padroniza um email para armazenamento e comparação (sem espaços nas pontas e em minúsculas)
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{name: "já normalizado", email: "a@b.com", want: "a@b.com"},
+		{name: "maiúsculas", email: "A@B.com", want: "a@b.com"},
+		{name: "espaços nas pontas", email: "  Ana.Silva@Example.COM ", want: "ana.silva@example.com"},
+		{name: "vazio", email: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeEmail(tt.email); got != tt.want {
+				t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.email, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// ImportantDateLayout é o formato das datas importantes na API (YYYY-MM-DD)
+const ImportantDateLayout = "2006-01-02"
+
+// MaxImportantDateLabelLength é o tamanho máximo da descrição de uma data importante
+const MaxImportantDateLabelLength = 100
+
+// ImportantDate representa uma data pessoal de um contato (aniversário, data de fundação etc.).
+// Datas recorrentes se repetem todo ano no mesmo dia e mês.
+type ImportantDate struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ContactID uint      `json:"contact_id" gorm:"not null;index"`
+	Label     string    `json:"label" gorm:"size:100;not null"`
+	Date      time.Time `json:"date" gorm:"type:date;not null"`
+	Recurring bool      `json:"recurring" gorm:"not null;default:true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relacionamentos
+	Contact Contact `json:"-" gorm:"foreignKey:ContactID"`
+}
+
+// NextOccurrence retorna a próxima ocorrência da data a partir do dia de from (inclusive), no fuso
+// horário de from. Datas não recorrentes só ocorrem uma vez; datas passadas retornam false.
+// Em anos não bissextos, 29 de fevereiro é comemorado em 28 de fevereiro.
+func (d *ImportantDate) NextOccurrence(from time.Time) (time.Time, bool) {
+	year, month, day := from.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, from.Location())
+
+	if !d.Recurring {
+		y, m, dd := d.Date.Date()
+		occurrence := time.Date(y, m, dd, 0, 0, 0, 0, from.Location())
+		return occurrence, !occurrence.Before(today)
+	}
+
+	occurrence := d.occurrenceIn(year, from.Location())
+	if occurrence.Before(today) {
+		occurrence = d.occurrenceIn(year+1, from.Location())
+	}
+	return occurrence, true
+}
+
+// occurrenceIn retorna a ocorrência da data recorrente no ano informado
+func (d *ImportantDate) occurrenceIn(year int, loc *time.Location) time.Time {
+	_, month, day := d.Date.Date()
+	if month == time.February && day == 29 && !isLeapYear(year) {
+		day = 28
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// isLeapYear verifica se o ano é bissexto
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// ImportantDateCreateRequest representa os dados para cadastro de uma data importante
+type ImportantDateCreateRequest struct {
+	Label     string `json:"label" binding:"required"`
+	Date      string `json:"date" binding:"required"` // YYYY-MM-DD
+	Recurring *bool  `json:"recurring,omitempty"`     // Padrão: true (repete todo ano)
+}
+
+// UpcomingImportantDate representa uma data importante que ocorre nos próximos dias
+type UpcomingImportantDate struct {
+	ImportantDate
+	ContactName    string `json:"contact_name"`
+	NextOccurrence string `json:"next_occurrence"` // YYYY-MM-DD
+	DaysUntil      int    `json:"days_until"`
+	Years          *int   `json:"years,omitempty"` // Anos completados na próxima ocorrência (apenas recorrentes)
+}
@@ -16,17 +16,55 @@
 	InteractionTypeOther   InteractionType = "OTHER"
 )
 
+// InteractionTypes lista todos os tipos de interação válidos
+var InteractionTypes = []InteractionType{
+	InteractionTypeEmail,
+	InteractionTypeCall,
+	InteractionTypeMeeting,
+	InteractionTypeOther,
+}
+
+// InteractionOutcome representa o resultado de uma interação
+type InteractionOutcome string
+
+const (
+	InteractionOutcomePositive   InteractionOutcome = "POSITIVE"
+	InteractionOutcomeNeutral    InteractionOutcome = "NEUTRAL"
+	InteractionOutcomeNegative   InteractionOutcome = "NEGATIVE"
+	InteractionOutcomeNoResponse InteractionOutcome = "NO_RESPONSE"
+)
+
+// InteractionOutcomes lista todos os resultados de interação válidos
+var InteractionOutcomes = []InteractionOutcome{
+	InteractionOutcomePositive,
+	InteractionOutcomeNeutral,
+	InteractionOutcomeNegative,
+	InteractionOutcomeNoResponse,
+}
+
+// IsValid verifica se o resultado da interação é conhecido
+func (o InteractionOutcome) IsValid() bool {
+	switch o {
+	case InteractionOutcomePositive, InteractionOutcomeNeutral, InteractionOutcomeNegative, InteractionOutcomeNoResponse:
+		return true
+	}
+	return false
+}
+
 // Interaction representa uma interação com um contato
 type Interaction struct {
-	ID          uint            `json:"id" gorm:"primaryKey"`
-	Type        InteractionType `json:"type" gorm:"not null" validate:"required,oneof=EMAIL CALL MEETING OTHER"`
-	Date        time.Time       `json:"date" gorm:"not null" validate:"required"`
-	Subject     string          `json:"subject,omitempty" validate:"omitempty,max=255"`
-	Description string          `json:"description,omitempty"`
-	ContactID   uint            `json:"contact_id" gorm:"not null"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt  `json:"-" gorm:"index"`
+	ID             uint               `json:"id" gorm:"primaryKey"`
+	Type           InteractionType    `json:"type" gorm:"not null" validate:"required,oneof=EMAIL CALL MEETING OTHER"`
+	Date           time.Time          `json:"date" gorm:"not null" validate:"required"`
+	Subject        string             `json:"subject,omitempty" validate:"omitempty,max=255"`
+	Description    string             `json:"description,omitempty"`
+	Outcome        InteractionOutcome `json:"outcome" gorm:"not null;default:NEUTRAL" validate:"required,oneof=POSITIVE NEUTRAL NEGATIVE NO_RESPONSE"`
+	ContactID      uint               `json:"contact_id" gorm:"not null"`
+	Planned        bool               `json:"planned" gorm:"not null;default:false"` // Interação agendada (pode ter data futura)
+	FollowUpTaskID *uint              `json:"follow_up_task_id,omitempty"`           // Tarefa de follow-up criada junto com a interação
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt     `json:"-" gorm:"index"`
 
 	// Relacionamentos
 	Contact Contact `json:"contact,omitempty" gorm:"foreignKey:ContactID"`
@@ -34,26 +72,45 @@ type Interaction struct {
 
 // InteractionCreateRequest representa os dados para criação de interação
 type InteractionCreateRequest struct {
-	Type        InteractionType `json:"type" validate:"required,oneof=EMAIL CALL MEETING OTHER"`
-	Date        time.Time       `json:"date" validate:"required"`
-	Subject     string          `json:"subject,omitempty" validate:"omitempty,max=255"`
-	Description string          `json:"description,omitempty"`
+	Type        InteractionType    `json:"type" validate:"required,oneof=EMAIL CALL MEETING OTHER"`
+	Date        time.Time          `json:"date" validate:"required"`
+	Subject     string             `json:"subject,omitempty" validate:"omitempty,max=255"`
+	Description string             `json:"description,omitempty"`
+	Outcome     InteractionOutcome `json:"outcome,omitempty" validate:"omitempty,oneof=POSITIVE NEUTRAL NEGATIVE NO_RESPONSE"` // Padrão: NEUTRAL
+	Planned     bool               `json:"planned,omitempty"`                                                                  // Permite datas futuras para interações agendadas
+
+	// Follow-up opcional: com FollowUpDate, uma tarefa vinculada ao contato é criada junto com a interação
+	FollowUpDate  *time.Time `json:"follow_up_date,omitempty"`
+	FollowUpTitle string     `json:"follow_up_title,omitempty" validate:"omitempty,max=255"`
+}
+
+// InteractionCreateByEmailRequest representa os dados para criação de interação identificando
+// o contato pelo email em vez do ID (ex.: registro automático de emails recebidos)
+type InteractionCreateByEmailRequest struct {
+	ContactEmail string `json:"contact_email" binding:"required"`
+	InteractionCreateRequest
 }
 
 // InteractionUpdateRequest representa os dados para atualização de interação
 type InteractionUpdateRequest struct {
-	Type        InteractionType `json:"type,omitempty" validate:"omitempty,oneof=EMAIL CALL MEETING OTHER"`
-	Date        *time.Time      `json:"date,omitempty"`
-	Subject     string          `json:"subject,omitempty" validate:"omitempty,max=255"`
-	Description string          `json:"description,omitempty"`
+	Type        InteractionType    `json:"type,omitempty" validate:"omitempty,oneof=EMAIL CALL MEETING OTHER"`
+	Date        *time.Time         `json:"date,omitempty"`
+	Subject     *string            `json:"subject,omitempty" validate:"omitempty,max=255"` // Ausente mantém o valor atual; "" limpa
+	Description *string            `json:"description,omitempty"`                          // Ausente mantém o valor atual; "" limpa
+	Outcome     InteractionOutcome `json:"outcome,omitempty" validate:"omitempty,oneof=POSITIVE NEUTRAL NEGATIVE NO_RESPONSE"`
+	Planned     *bool              `json:"planned,omitempty"`
 }
 
 // InteractionListFilter representa os filtros para listagem de interações
 type InteractionListFilter struct {
-	Type      InteractionType `form:"type" validate:"omitempty,oneof=EMAIL CALL MEETING OTHER"`
-	DateFrom  *time.Time      `form:"date_from"`
-	DateTo    *time.Time      `form:"date_to"`
-	ContactID uint            `form:"contact_id"`
-	Limit     int             `form:"limit" validate:"omitempty,min=1,max=100"`
-	Offset    int             `form:"offset" validate:"omitempty,min=0"`
+	Type      InteractionType    `form:"type" validate:"omitempty,oneof=EMAIL CALL MEETING OTHER"`
+	Outcome   InteractionOutcome `form:"outcome" validate:"omitempty,oneof=POSITIVE NEUTRAL NEGATIVE NO_RESPONSE"`
+	DateFrom  *time.Time         `form:"date_from"`
+	DateTo    *time.Time         `form:"date_to"`
+	ContactID uint               `form:"contact_id"`
+	Search    string             `form:"search"` // Busca parcial no assunto ou na descrição, sem diferenciar maiúsculas
+	Limit     int                `form:"limit" validate:"omitempty,min=0"`
+	Offset    int                `form:"offset" validate:"omitempty,min=0"`
+	Cursor    string             `form:"cursor"` // Cursor opaco retornado em next_cursor; tem precedência sobre offset
+	After     *Cursor            `form:"-"`      // Cursor decodificado, preenchido pelo serviço
 }
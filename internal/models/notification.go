@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// NotificationType representa o tipo de notificação
+type NotificationType string
+
+const (
+	NotificationTypeTaskDueSoon NotificationType = "TASK_DUE_SOON"
+)
+
+// Notification representa uma notificação para o usuário
+type Notification struct {
+	ID        uint             `json:"id" gorm:"primaryKey"`
+	UserID    uint             `json:"user_id" gorm:"not null;index"`
+	Type      NotificationType `json:"type" gorm:"not null"`
+	Message   string           `json:"message" gorm:"not null"`
+	Read      bool             `json:"read" gorm:"not null;default:false"`
+	TaskID    *uint            `json:"task_id,omitempty" gorm:"index"`
+	DueDate   *time.Time       `json:"due_date,omitempty"` // Vencimento da tarefa ao qual a notificação se refere
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NotificationListFilter representa os filtros para listagem de notificações
+type NotificationListFilter struct {
+	Unread bool `form:"unread"`
+	Limit  int  `form:"limit" validate:"omitempty,min=0"`
+	Offset int  `form:"offset" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidCursor indica um cursor de paginação malformado
+var ErrInvalidCursor = errors.New("cursor de paginação inválido")
+
+// Cursor identifica a posição do último item visto em uma listagem ordenada por (data, id) decrescentes
+type Cursor struct {
+	Date time.Time `json:"d"`
+	ID   uint      `json:"id"`
+}
+
+// EncodeCursor serializa o cursor em um token opaco (JSON em base64 seguro para URL)
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor interpreta um token gerado por EncodeCursor
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// Tamanhos de página das listagens (ajustados na inicialização por ConfigurePageSize)
+var (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// ConfigurePageSize define o tamanho de página padrão e o máximo aplicados por NormalizeLimit.
+// Valores não positivos mantêm a configuração atual; o padrão nunca excede o máximo.
+func ConfigurePageSize(defaultSize, maxSize int) {
+	if maxSize > 0 {
+		maxPageSize = maxSize
+	}
+	if defaultSize > 0 {
+		defaultPageSize = defaultSize
+	}
+	if defaultPageSize > maxPageSize {
+		defaultPageSize = maxPageSize
+	}
+}
+
+// maxOffset é o maior offset aceito nas listagens (ajustado na inicialização por ConfigureMaxOffset; 0: sem limite)
+var maxOffset = 10000
+
+// ErrOffsetTooLarge indica um offset acima do máximo configurado
+var ErrOffsetTooLarge = errors.New("offset acima do máximo permitido")
+
+// ConfigureMaxOffset define o maior offset aceito por CheckOffset (0 desativa a verificação).
+// Valores negativos mantêm a configuração atual.
+func ConfigureMaxOffset(max int) {
+	if max >= 0 {
+		maxOffset = max
+	}
+}
+
+// CheckOffset recusa offsets acima do máximo configurado, que obrigariam o banco a percorrer e
+// descartar muitas linhas a cada página
+func CheckOffset(offset int) error {
+	if maxOffset > 0 && offset > maxOffset {
+		return fmt.Errorf("%w: o máximo é %d; para avançar mais, use a paginação por cursor (quando disponível) ou filtros mais restritos",
+			ErrOffsetTooLarge, maxOffset)
+	}
+	return nil
+}
+
+// NormalizeLimit retorna o limite efetivo de uma listagem: o padrão quando não informado
+// (ou inválido) e o máximo quando o solicitado o excede
+func NormalizeLimit(requested int) int {
+	if requested <= 0 {
+		return defaultPageSize
+	}
+	if requested > maxPageSize {
+		return maxPageSize
+	}
+	return requested
+}
+
+// PaginatedResponse representa uma resposta de listagem paginada
+type PaginatedResponse[T any] struct {
+	Data    []T   `json:"data"`
+	Total   int64 `json:"total"`
+	Limit   int   `json:"limit"`
+	Offset  int   `json:"offset"`
+	HasMore bool  `json:"has_more"`
+	// NextCursor é preenchido nas listagens que suportam paginação por cursor quando há mais itens
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPaginatedResponse cria uma resposta paginada a partir dos itens da página e do total filtrado
+func NewPaginatedResponse[T any](data []T, total int64, limit, offset int) *PaginatedResponse[T] {
+	if data == nil {
+		data = []T{}
+	}
+
+	return &PaginatedResponse[T]{
+		Data:    data,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: int64(offset+len(data)) < total,
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+// setMaxOffset altera MAX_OFFSET durante o teste
+func setMaxOffset(t *testing.T, max int) {
+	t.Helper()
+	original := maxOffset
+	ConfigureMaxOffset(max)
+	t.Cleanup(func() { maxOffset = original })
+}
+
+func TestCheckOffset(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxOffset int
+		offset    int
+		wantErr   bool
+	}{
+		{name: "abaixo do máximo", maxOffset: 100, offset: 99},
+		{name: "no máximo", maxOffset: 100, offset: 100},
+		{name: "acima do máximo", maxOffset: 100, offset: 101, wantErr: true},
+		{name: "zero desativa a verificação", maxOffset: 0, offset: 1_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setMaxOffset(t, tt.maxOffset)
+
+			err := CheckOffset(tt.offset)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("CheckOffset(%d) = %v, wantErr %v", tt.offset, err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrOffsetTooLarge) {
+				t.Fatalf("CheckOffset(%d) = %v, want ErrOffsetTooLarge", tt.offset, err)
+			}
+		})
+	}
+}
+
+func TestConfigureMaxOffsetIgnoresNegative(t *testing.T) {
+	setMaxOffset(t, 50)
+	ConfigureMaxOffset(-1)
+
+	if err := CheckOffset(51); !errors.Is(err, ErrOffsetTooLarge) {
+		t.Fatalf("CheckOffset(51) = %v, want ErrOffsetTooLarge (máximo mantido em 50)", err)
+	}
+}
+
+func TestNewPaginatedResponseHasMore(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   int
+		total   int64
+		offset  int
+		hasMore bool
+	}{
+		{name: "primeira página de várias", items: 10, total: 25, offset: 0, hasMore: true},
+		{name: "última página", items: 5, total: 25, offset: 20, hasMore: false},
+		{name: "vazia", items: 0, total: 0, offset: 0, hasMore: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewPaginatedResponse(make([]int, tt.items), tt.total, 10, tt.offset)
+			if page.HasMore != tt.hasMore {
+				t.Fatalf("HasMore = %v, want %v", page.HasMore, tt.hasMore)
+			}
+			if page.Data == nil {
+				t.Fatal("Data não deveria ser nil")
+			}
+		})
+	}
+}
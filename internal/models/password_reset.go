@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PasswordReset representa um token de redefinição de senha
+type PasswordReset struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	// Relacionamentos
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// IsUsable verifica se o token ainda pode ser usado (não utilizado e não expirado)
+func (p *PasswordReset) IsUsable() bool {
+	return p.UsedAt == nil && time.Now().Before(p.ExpiresAt)
+}
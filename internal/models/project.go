@@ -15,17 +15,30 @@
 	ProjectStatusCancelled  ProjectStatus = "CANCELLED"
 )
 
+// ProjectStatuses lista todos os status de projeto válidos
+var ProjectStatuses = []ProjectStatus{
+	ProjectStatusInProgress,
+	ProjectStatusCompleted,
+	ProjectStatusCancelled,
+}
+
 // Project representa um projeto
 type Project struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	Name        string         `json:"name" gorm:"not null" validate:"required,min=2,max=255"`
-	Description string         `json:"description,omitempty"`
-	Status      ProjectStatus  `json:"status" gorm:"not null" validate:"required,oneof=IN_PROGRESS COMPLETED CANCELLED"`
-	UserID      uint           `json:"user_id" gorm:"not null"`
-	ClientID    uint           `json:"client_id" gorm:"not null"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	Name             string         `json:"name" gorm:"not null" validate:"required,min=2,max=255"`
+	Description      string         `json:"description,omitempty"`
+	Status           ProjectStatus  `json:"status" gorm:"not null" validate:"required,oneof=IN_PROGRESS COMPLETED CANCELLED"`
+	UserID           uint           `json:"user_id" gorm:"not null"`
+	ClientID         uint           `json:"client_id" gorm:"not null"` // Contato do tipo CLIENT; enquanto houver projetos ele não pode voltar a LEAD
+	StartDate        *time.Time     `json:"start_date,omitempty"`
+	EndDate          *time.Time     `json:"end_date,omitempty"`
+	Budget           int64          `json:"budget" gorm:"not null;default:0"`       // Orçamento em centavos
+	SpentAmount      int64          `json:"spent_amount" gorm:"not null;default:0"` // Valor gasto em centavos
+	ProgressOverride *float64       `json:"progress_override"`                      // Progresso informado manualmente (0-100); nulo usa o progresso das tarefas
+	Version          uint           `json:"version" gorm:"not null;default:1"`      // Incrementada a cada atualização (controle de concorrência otimista)
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"index"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relacionamentos
 	User   User    `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -33,26 +46,52 @@ type Project struct {
 	Tasks  []Task  `json:"tasks,omitempty" gorm:"foreignKey:ProjectID"`
 }
 
+// GetOwnerID retorna o ID do usuário dono do projeto
+func (p *Project) GetOwnerID() uint {
+	return p.UserID
+}
+
 // ProjectCreateRequest representa os dados para criação de projeto
 type ProjectCreateRequest struct {
 	Name        string        `json:"name" validate:"required,min=2,max=255"`
 	Description string        `json:"description,omitempty"`
 	Status      ProjectStatus `json:"status" validate:"required,oneof=IN_PROGRESS COMPLETED CANCELLED"`
 	ClientID    uint          `json:"client_id" validate:"required"`
+	StartDate   *time.Time    `json:"start_date,omitempty"`
+	EndDate     *time.Time    `json:"end_date,omitempty"`                                // Não pode ser anterior a StartDate
+	Budget      int64         `json:"budget,omitempty" validate:"omitempty,min=0"`       // Em centavos
+	SpentAmount int64         `json:"spent_amount,omitempty" validate:"omitempty,min=0"` // Em centavos
+}
+
+// ProjectCopySuffix é acrescentado ao nome dos projetos duplicados
+const ProjectCopySuffix = " (copy)"
+
+// ProjectDuplicateRequest representa as opções para duplicar um projeto
+type ProjectDuplicateRequest struct {
+	IncludeTasks bool `json:"include_tasks"`                                                // Copiar as tarefas, reabertas como PENDING
+	ShiftDays    *int `json:"shift_days,omitempty" validate:"omitempty,min=-3650,max=3650"` // Desloca os vencimentos das tarefas copiadas; ausente limpa os vencimentos
 }
 
 // ProjectUpdateRequest representa os dados para atualização de projeto
 type ProjectUpdateRequest struct {
-	Name        string        `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
-	Description string        `json:"description,omitempty"`
-	Status      ProjectStatus `json:"status,omitempty" validate:"omitempty,oneof=IN_PROGRESS COMPLETED CANCELLED"`
-	ClientID    uint          `json:"client_id,omitempty"`
+	Name                  string        `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	Description           *string       `json:"description,omitempty"` // Ausente mantém o valor atual; "" limpa
+	Status                ProjectStatus `json:"status,omitempty" validate:"omitempty,oneof=IN_PROGRESS COMPLETED CANCELLED"`
+	ClientID              uint          `json:"client_id,omitempty"`
+	StartDate             *time.Time    `json:"start_date,omitempty"`
+	EndDate               *time.Time    `json:"end_date,omitempty"`                                // Não pode ser anterior a StartDate
+	Budget                *int64        `json:"budget,omitempty" validate:"omitempty,min=0"`       // Em centavos
+	SpentAmount           *int64        `json:"spent_amount,omitempty" validate:"omitempty,min=0"` // Em centavos
+	ProgressOverride      *float64      `json:"progress_override,omitempty"`                       // Progresso manual (0-100)
+	ClearProgressOverride bool          `json:"clear_progress_override,omitempty"`                 // Volta ao progresso calculado pelas tarefas
+	Version               *uint         `json:"version,omitempty"`                                 // Versão esperada do registro; se informada e divergente da atual, retorna 409
 }
 
 // ProjectListFilter representa os filtros para listagem de projetos
 type ProjectListFilter struct {
-	Status   string `form:"status" validate:"omitempty,oneof=IN_PROGRESS COMPLETED CANCELLED"`
-	ClientID *uint  `form:"client_id"`
-	Limit    int    `form:"limit" validate:"omitempty,min=1,max=100"`
-	Offset   int    `form:"offset" validate:"omitempty,min=0"`
+	Status       string     `form:"status" validate:"omitempty,oneof=IN_PROGRESS COMPLETED CANCELLED"`
+	ClientID     *uint      `form:"client_id"`
+	ChangedSince *time.Time `form:"changed_since"` // Sincronização: alterados ou excluídos a partir deste momento, incluindo os excluídos
+	Limit        int        `form:"limit" validate:"omitempty,min=0"`
+	Offset       int        `form:"offset" validate:"omitempty,min=0"`
 }
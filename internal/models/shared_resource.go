@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// SharedResourceType representa o tipo de recurso compartilhado
+type SharedResourceType string
+
+const (
+	SharedResourceProject SharedResourceType = "PROJECT"
+	SharedResourceContact SharedResourceType = "CONTACT"
+)
+
+// SharePermission representa a permissão concedida em um compartilhamento
+type SharePermission string
+
+const (
+	SharePermissionRead SharePermission = "READ"
+)
+
+// SharedResource representa o compartilhamento de um recurso com outro usuário
+type SharedResource struct {
+	ID           uint               `json:"id" gorm:"primaryKey"`
+	ResourceType SharedResourceType `json:"resource_type" gorm:"not null;uniqueIndex:idx_shared_resource"`
+	ResourceID   uint               `json:"resource_id" gorm:"not null;uniqueIndex:idx_shared_resource"`
+	OwnerID      uint               `json:"owner_id" gorm:"not null;index"`
+	SharedWithID uint               `json:"shared_with_id" gorm:"not null;uniqueIndex:idx_shared_resource;index"`
+	Permission   SharePermission    `json:"permission" gorm:"not null;default:READ"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+
+	// Relacionamentos
+	Owner      User `json:"-" gorm:"foreignKey:OwnerID"`
+	SharedWith User `json:"shared_with,omitempty" gorm:"foreignKey:SharedWithID"`
+}
+
+// ShareRequest representa os dados para compartilhar um recurso com outro usuário
+type ShareRequest struct {
+	Email      string          `json:"email" validate:"required,email"`
+	Permission SharePermission `json:"permission,omitempty" validate:"omitempty,oneof=READ"`
+}
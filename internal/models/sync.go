@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ChangeState indica, nas listagens de sincronização (parâmetro changed_since), se o registro foi
+// excluído (soft delete), para que o cliente replique a exclusão
+type ChangeState struct {
+	Deleted   bool       `json:"deleted"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+// NewChangeState cria o estado de exclusão a partir da coluna deleted_at do registro
+func NewChangeState(deletedAt gorm.DeletedAt) ChangeState {
+	if !deletedAt.Valid {
+		return ChangeState{}
+	}
+	return ChangeState{Deleted: true, DeletedAt: &deletedAt.Time}
+}
+
+// ChangedContact representa um contato alterado ou excluído desde o momento informado
+type ChangedContact struct {
+	Contact
+	ChangeState
+}
+
+// ChangedTask representa uma tarefa alterada ou excluída desde o momento informado
+type ChangedTask struct {
+	Task
+	ChangeState
+}
+
+// ChangedProject representa um projeto alterado ou excluído desde o momento informado
+type ChangedProject struct {
+	Project
+	ChangeState
+}
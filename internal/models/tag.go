@@ -0,0 +1,42 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxTagNameLength é o tamanho máximo do nome de uma etiqueta
+const MaxTagNameLength = 50
+
+// Tag representa uma etiqueta livre usada para categorizar contatos (única por usuário)
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"size:50;not null;uniqueIndex:idx_tags_user_name"`
+	UserID    uint      `json:"-" gorm:"not null;uniqueIndex:idx_tags_user_name"`
+	CreatedAt time.Time `json:"-"`
+}
+
+// ContactTagsRequest representa os dados para adicionar etiquetas a um contato
+type ContactTagsRequest struct {
+	Tags []string `json:"tags" validate:"required,min=1,dive,max=50"`
+}
+
+// NormalizeTagName padroniza o nome da etiqueta (sem espaços nas pontas e em minúsculas)
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeTagNames padroniza os nomes informados, descartando vazios e repetidos
+func NormalizeTagNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
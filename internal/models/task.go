@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,28 +16,98 @@
 	PriorityHigh   Priority = "HIGH"
 )
 
+// Priorities lista todas as prioridades válidas
+var Priorities = []Priority{
+	PriorityLow,
+	PriorityMedium,
+	PriorityHigh,
+}
+
+// IsValid verifica se a prioridade é conhecida
+func (p Priority) IsValid() bool {
+	switch p {
+	case PriorityLow, PriorityMedium, PriorityHigh:
+		return true
+	}
+	return false
+}
+
 // TaskStatus representa o status de uma tarefa
 type TaskStatus string
 
 const (
-	TaskStatusPending   TaskStatus = "PENDING"
-	TaskStatusCompleted TaskStatus = "COMPLETED"
+	TaskStatusPending    TaskStatus = "PENDING"
+	TaskStatusInProgress TaskStatus = "IN_PROGRESS"
+	TaskStatusCompleted  TaskStatus = "COMPLETED"
 )
 
+// TaskStatuses lista todos os status de tarefa válidos
+var TaskStatuses = []TaskStatus{
+	TaskStatusPending,
+	TaskStatusInProgress,
+	TaskStatusCompleted,
+}
+
+// IsValid verifica se o status da tarefa é conhecido
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case TaskStatusPending, TaskStatusInProgress, TaskStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// RecurrenceRule representa a regra de recorrência de uma tarefa
+type RecurrenceRule string
+
+const (
+	RecurrenceNone    RecurrenceRule = "NONE"
+	RecurrenceDaily   RecurrenceRule = "DAILY"
+	RecurrenceWeekly  RecurrenceRule = "WEEKLY"
+	RecurrenceMonthly RecurrenceRule = "MONTHLY"
+)
+
+// IsValid verifica se a regra de recorrência é conhecida
+func (r RecurrenceRule) IsValid() bool {
+	switch r {
+	case RecurrenceNone, RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	}
+	return false
+}
+
+// Next calcula a próxima data a partir da data informada segundo a regra
+func (r RecurrenceRule) Next(from time.Time) time.Time {
+	switch r {
+	case RecurrenceDaily:
+		return from.AddDate(0, 0, 1)
+	case RecurrenceWeekly:
+		return from.AddDate(0, 0, 7)
+	case RecurrenceMonthly:
+		return from.AddDate(0, 1, 0)
+	}
+	return from
+}
+
 // Task representa uma tarefa
 type Task struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	Title       string         `json:"title" gorm:"not null" validate:"required,min=2,max=255"`
-	Description string         `json:"description,omitempty"`
-	DueDate     *time.Time     `json:"due_date,omitempty"`
-	Priority    Priority       `json:"priority" gorm:"not null" validate:"required,oneof=LOW MEDIUM HIGH"`
-	Status      TaskStatus     `json:"status" gorm:"not null" validate:"required,oneof=PENDING COMPLETED"`
-	UserID      uint           `json:"user_id" gorm:"not null"`
-	ContactID   *uint          `json:"contact_id,omitempty"`
-	ProjectID   *uint          `json:"project_id,omitempty"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	Title            string         `json:"title" gorm:"not null" validate:"required,min=2,max=255"`
+	Description      string         `json:"description,omitempty"`
+	DueDate          *time.Time     `json:"due_date,omitempty"`
+	Priority         Priority       `json:"priority" gorm:"not null" validate:"required,oneof=LOW MEDIUM HIGH"`
+	Status           TaskStatus     `json:"status" gorm:"not null" validate:"required,oneof=PENDING IN_PROGRESS COMPLETED"`
+	UserID           uint           `json:"user_id" gorm:"not null"`
+	ContactID        *uint          `json:"contact_id,omitempty"`
+	ProjectID        *uint          `json:"project_id,omitempty"`
+	CompletedAt      *time.Time     `json:"completed_at,omitempty"`
+	SnoozedUntil     *time.Time     `json:"snoozed_until,omitempty" gorm:"index"` // Fora das listas de pendências, atrasadas e próximas até este momento
+	RecurrenceRule   RecurrenceRule `json:"recurrence_rule" gorm:"not null;default:NONE" validate:"omitempty,oneof=NONE DAILY WEEKLY MONTHLY"`
+	NextOccurrenceID *uint          `json:"next_occurrence_id,omitempty"`      // Próxima ocorrência gerada ao concluir uma tarefa recorrente
+	Version          uint           `json:"version" gorm:"not null;default:1"` // Incrementada a cada atualização (controle de concorrência otimista)
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"index"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relacionamentos
 	User    User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -44,36 +115,83 @@ type Task struct {
 	Project *Project `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
 }
 
+// GetOwnerID retorna o ID do usuário dono da tarefa
+func (t *Task) GetOwnerID() uint {
+	return t.UserID
+}
+
 // TaskCreateRequest representa os dados para criação de tarefa
 type TaskCreateRequest struct {
-	Title       string     `json:"title" validate:"required,min=2,max=255"`
-	Description string     `json:"description,omitempty"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
-	Priority    Priority   `json:"priority" validate:"required,oneof=LOW MEDIUM HIGH"`
-	Status      TaskStatus `json:"status,omitempty" validate:"omitempty,oneof=PENDING COMPLETED"` // Opcional, será ignorado
-	ContactID   *uint      `json:"contact_id,omitempty"`
-	ProjectID   *uint      `json:"project_id,omitempty"`
+	Title          string         `json:"title" validate:"required,min=2,max=255"`
+	Description    string         `json:"description,omitempty"`
+	DueDate        *time.Time     `json:"due_date,omitempty"`
+	Priority       Priority       `json:"priority" validate:"required,oneof=LOW MEDIUM HIGH"`
+	Status         TaskStatus     `json:"status,omitempty"` // Ignorado: novas tarefas sempre começam como PENDING
+	ContactID      *uint          `json:"contact_id,omitempty"`
+	ProjectID      *uint          `json:"project_id,omitempty"`
+	RecurrenceRule RecurrenceRule `json:"recurrence_rule,omitempty" validate:"omitempty,oneof=NONE DAILY WEEKLY MONTHLY"` // Padrão: NONE
 }
 
-// TaskUpdateRequest representa os dados para atualização de tarefa
+// TaskUpdateRequest representa os dados para atualização de tarefa.
+// Description ausente mantém o valor atual e "" limpa o campo; ClearDueDate remove o vencimento.
 type TaskUpdateRequest struct {
-	Title       string     `json:"title,omitempty" validate:"omitempty,min=2,max=255"`
-	Description string     `json:"description,omitempty"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
-	Priority    Priority   `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
-	Status      TaskStatus `json:"status,omitempty" validate:"omitempty,oneof=PENDING COMPLETED"`
-	ContactID   *uint      `json:"contact_id,omitempty"`
-	ProjectID   *uint      `json:"project_id,omitempty"`
+	Title          string         `json:"title,omitempty" validate:"omitempty,min=2,max=255"`
+	Description    *string        `json:"description,omitempty"`
+	DueDate        *time.Time     `json:"due_date,omitempty"`
+	ClearDueDate   bool           `json:"clear_due_date,omitempty"` // Remove o vencimento (não pode ser usado com due_date)
+	Priority       Priority       `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
+	Status         TaskStatus     `json:"status,omitempty" validate:"omitempty,oneof=PENDING IN_PROGRESS COMPLETED"`
+	ContactID      *uint          `json:"contact_id,omitempty"`
+	ProjectID      *uint          `json:"project_id,omitempty"`
+	RecurrenceRule RecurrenceRule `json:"recurrence_rule,omitempty" validate:"omitempty,oneof=NONE DAILY WEEKLY MONTHLY"`
+	Version        *uint          `json:"version,omitempty"` // Versão esperada do registro; se informada e divergente da atual, retorna 409
+}
+
+// TaskSnoozeRequest representa os dados para adiar uma tarefa
+type TaskSnoozeRequest struct {
+	Until time.Time `json:"until" binding:"required"`
 }
 
 // TaskListFilter representa os filtros para listagem de tarefas
 type TaskListFilter struct {
-	Status    TaskStatus `form:"status" validate:"omitempty,oneof=PENDING COMPLETED"`
-	Priority  Priority   `form:"priority" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
-	ContactID *uint      `form:"contact_id"`
-	ProjectID *uint      `form:"project_id"`
-	DueBefore *time.Time `form:"due_before"`
-	DueAfter  *time.Time `form:"due_after"`
-	Limit     int        `form:"limit" validate:"omitempty,min=1,max=100"`
-	Offset    int        `form:"offset" validate:"omitempty,min=0"`
+	Status         TaskStatus `form:"status"`   // Um status ou lista separada por vírgulas (ex.: PENDING,IN_PROGRESS)
+	Priority       Priority   `form:"priority"` // Uma prioridade ou lista separada por vírgulas (ex.: HIGH,MEDIUM)
+	ContactID      *uint      `form:"contact_id"`
+	ProjectID      *uint      `form:"project_id"`
+	DueBefore      *time.Time `form:"due_before"`
+	DueAfter       *time.Time `form:"due_after"`
+	IncludeSnoozed bool       `form:"include_snoozed"` // Incluir tarefas adiadas (padrão: false)
+	HasDueDate     *bool      `form:"has_due_date"`    // true: apenas com vencimento; false: apenas sem vencimento
+	ChangedSince   *time.Time `form:"changed_since"`   // Sincronização: alteradas ou excluídas a partir deste momento, incluindo as excluídas
+	Limit          int        `form:"limit" validate:"omitempty,min=0"`
+	Offset         int        `form:"offset" validate:"omitempty,min=0"`
+}
+
+// Statuses retorna os status informados no filtro (vazio se nenhum)
+func (f *TaskListFilter) Statuses() []TaskStatus {
+	var statuses []TaskStatus
+	for _, value := range splitFilterList(string(f.Status)) {
+		statuses = append(statuses, TaskStatus(value))
+	}
+	return statuses
+}
+
+// Priorities retorna as prioridades informadas no filtro (vazio se nenhuma)
+func (f *TaskListFilter) Priorities() []Priority {
+	var priorities []Priority
+	for _, value := range splitFilterList(string(f.Priority)) {
+		priorities = append(priorities, Priority(value))
+	}
+	return priorities
+}
+
+// splitFilterList separa uma lista separada por vírgulas, descartando espaços e itens vazios
+func splitFilterList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// MaxTaskCommentLength é o tamanho máximo do texto de um comentário de tarefa
+const MaxTaskCommentLength = 5000
+
+// TaskComment representa um comentário registrado em uma tarefa
+type TaskComment struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TaskID    uint      `json:"task_id" gorm:"not null;index"`
+	UserID    uint      `json:"user_id" gorm:"not null"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relacionamentos
+	Task Task `json:"-" gorm:"foreignKey:TaskID"`
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TaskCommentCreateRequest representa os dados para registrar um comentário em uma tarefa
+type TaskCommentCreateRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// TaskCommentResponse representa um comentário com o nome do autor
+type TaskCommentResponse struct {
+	TaskComment
+	AuthorName string `json:"author_name"`
+}
@@ -6,20 +6,45 @@
 	"gorm.io/gorm"
 )
 
+// UserRole representa o papel do usuário, usado na autorização de rotas administrativas
+type UserRole string
+
+const (
+	RoleUser  UserRole = "USER"  // Usuário comum (padrão)
+	RoleAdmin UserRole = "ADMIN" // Administrador
+)
+
+// IsValid verifica se o papel é conhecido
+func (r UserRole) IsValid() bool {
+	switch r {
+	case RoleUser, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Allows verifica se o papel dá acesso a rotas que exigem o papel required.
+// Administradores têm acesso a todas as rotas.
+func (r UserRole) Allows(required UserRole) bool {
+	return r == required || r == RoleAdmin
+}
+
 // User representa um usuário do sistema
 type User struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
 	Name      string         `json:"name" gorm:"not null" validate:"required,min=2,max=255"`
 	Email     string         `json:"email" gorm:"uniqueIndex;not null" validate:"required,email"`
 	Password  string         `json:"-" gorm:"not null" validate:"required,min=6"`
+	Timezone  string         `json:"timezone,omitempty" gorm:"size:64"` // Nome IANA (ex.: America/Sao_Paulo); vazio equivale a UTC
+	Role      UserRole       `json:"role" gorm:"size:20;not null;default:USER"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relacionamentos
-	Contacts     []Contact `json:"contacts,omitempty" gorm:"foreignKey:UserID"`
-	Tasks        []Task    `json:"tasks,omitempty" gorm:"foreignKey:UserID"`
-	Projects     []Project `json:"projects,omitempty" gorm:"foreignKey:UserID"`
+	Contacts []Contact `json:"contacts,omitempty" gorm:"foreignKey:UserID"`
+	Tasks    []Task    `json:"tasks,omitempty" gorm:"foreignKey:UserID"`
+	Projects []Project `json:"projects,omitempty" gorm:"foreignKey:UserID"`
 }
 
 // UserCreateRequest representa os dados para criação de usuário
@@ -31,8 +56,9 @@ type UserCreateRequest struct {
 
 // UserUpdateRequest representa os dados para atualização de usuário
 type UserUpdateRequest struct {
-	Name  string `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
-	Email string `json:"email,omitempty" validate:"omitempty,email"`
+	Name     string  `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	Email    string  `json:"email,omitempty" validate:"omitempty,email"`
+	Timezone *string `json:"timezone,omitempty"` // Nome IANA; "" volta ao padrão (UTC)
 }
 
 // UserResponse representa a resposta de usuário (sem senha)
@@ -40,6 +66,8 @@ type UserResponse struct {
 	ID        uint      `json:"id"`
 	Name      string    `json:"name"`
 	Email     string    `json:"email"`
+	Timezone  string    `json:"timezone"`
+	Role      UserRole  `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -50,8 +78,21 @@ func (u *User) ToResponse() UserResponse {
 		ID:        u.ID,
 		Name:      u.Name,
 		Email:     u.Email,
+		Timezone:  u.Location().String(),
+		Role:      u.Role,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
 }
 
+// Location retorna o fuso horário do usuário (UTC se não definido ou inválido)
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
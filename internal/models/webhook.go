@@ -0,0 +1,121 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// WebhookEvent define os eventos que podem ser assinados por um webhook
+type WebhookEvent string
+
+const (
+	WebhookEventContactCreated       WebhookEvent = "contact.created"
+	WebhookEventContactUpdated       WebhookEvent = "contact.updated"
+	WebhookEventContactDeleted       WebhookEvent = "contact.deleted"
+	WebhookEventTaskCreated          WebhookEvent = "task.created"
+	WebhookEventTaskUpdated          WebhookEvent = "task.updated"
+	WebhookEventTaskCompleted        WebhookEvent = "task.completed"
+	WebhookEventTaskDeleted          WebhookEvent = "task.deleted"
+	WebhookEventProjectCreated       WebhookEvent = "project.created"
+	WebhookEventProjectUpdated       WebhookEvent = "project.updated"
+	WebhookEventProjectStatusChanged WebhookEvent = "project.status_changed"
+	WebhookEventProjectDeleted       WebhookEvent = "project.deleted"
+	WebhookEventInteractionCreated   WebhookEvent = "interaction.created"
+	WebhookEventInteractionUpdated   WebhookEvent = "interaction.updated"
+	WebhookEventInteractionDeleted   WebhookEvent = "interaction.deleted"
+)
+
+// webhookEvents lista os eventos suportados
+var webhookEvents = map[WebhookEvent]bool{
+	WebhookEventContactCreated:       true,
+	WebhookEventContactUpdated:       true,
+	WebhookEventContactDeleted:       true,
+	WebhookEventTaskCreated:          true,
+	WebhookEventTaskUpdated:          true,
+	WebhookEventTaskCompleted:        true,
+	WebhookEventTaskDeleted:          true,
+	WebhookEventProjectCreated:       true,
+	WebhookEventProjectUpdated:       true,
+	WebhookEventProjectStatusChanged: true,
+	WebhookEventProjectDeleted:       true,
+	WebhookEventInteractionCreated:   true,
+	WebhookEventInteractionUpdated:   true,
+	WebhookEventInteractionDeleted:   true,
+}
+
+// IsValid verifica se o evento é suportado
+func (e WebhookEvent) IsValid() bool {
+	return webhookEvents[e]
+}
+
+// WebhookEventFromActivity retorna o evento de webhook correspondente a uma atividade.
+// Início, conclusão e cancelamento de projetos são publicados como project.status_changed.
+func WebhookEventFromActivity(activity UserActivity) WebhookEvent {
+	if activity.Type == ActivityTypeProject {
+		switch activity.Action {
+		case ActionStarted, ActionCompleted, ActionCancelled:
+			return WebhookEventProjectStatusChanged
+		}
+	}
+	return WebhookEvent(strings.ToLower(string(activity.Type)) + "." + strings.ToLower(string(activity.Action)))
+}
+
+// Webhook representa um endpoint externo notificado sobre eventos do CRM do usuário
+type Webhook struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	URL       string    `json:"url" gorm:"size:2048;not null"`
+	Events    []string  `json:"events" gorm:"serializer:json;type:text;not null"`
+	Secret    string    `json:"-" gorm:"not null"` // Usado para assinar o corpo (HMAC-SHA256)
+	Active    bool      `json:"active" gorm:"not null;default:true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetOwnerID retorna o ID do usuário dono do webhook
+func (w *Webhook) GetOwnerID() uint {
+	return w.UserID
+}
+
+// Subscribes verifica se o webhook está ativo e assina o evento
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	if !w.Active {
+		return false
+	}
+	for _, e := range w.Events {
+		if WebhookEvent(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookCreateRequest representa os dados para cadastro de um webhook.
+// Sem secret, um segredo aleatório é gerado e retornado apenas na criação.
+type WebhookCreateRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required,min=1"`
+	Secret string   `json:"secret"`
+}
+
+// WebhookUpdateRequest representa os dados para atualização de um webhook
+type WebhookUpdateRequest struct {
+	URL    *string  `json:"url"`
+	Events []string `json:"events"`
+	Secret *string  `json:"secret"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookCreateResponse representa o webhook criado, incluindo o segredo de assinatura
+type WebhookCreateResponse struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhookPayload representa o corpo enviado aos webhooks
+type WebhookPayload struct {
+	ID         uint64       `json:"id"` // ID do evento, igual em todas as tentativas de entrega
+	Event      WebhookEvent `json:"event"`
+	OccurredAt time.Time    `json:"occurred_at"`
+	Data       UserActivity `json:"data"`
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository define a interface para operações de chaves de API no banco de dados
+type APIKeyRepository interface {
+	Create(key *models.APIKey) error
+	GetByID(id uint) (*models.APIKey, error)
+	GetByKeyHash(keyHash string) (*models.APIKey, error)
+	GetByUserID(userID uint) ([]models.APIKey, error)
+	UpdateLastUsed(id uint, usedAt time.Time) error
+	Delete(id uint) error
+}
+
+// apiKeyRepository implementa APIKeyRepository
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository cria uma nova instância do repositório de chaves de API
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// Create cria uma nova chave de API no banco de dados
+func (r *apiKeyRepository) Create(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+// GetByID busca uma chave de API pelo ID
+func (r *apiKeyRepository) GetByID(id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.First(&key, id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByKeyHash busca uma chave de API pelo hash
+func (r *apiKeyRepository) GetByKeyHash(keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByUserID busca as chaves de API de um usuário, das mais antigas às mais recentes
+func (r *apiKeyRepository) GetByUserID(userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	if err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// UpdateLastUsed registra o último uso de uma chave de API
+func (r *apiKeyRepository) UpdateLastUsed(id uint, usedAt time.Time) error {
+	return r.db.Model(&models.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
+
+// Delete remove (revoga) uma chave de API
+func (r *apiKeyRepository) Delete(id uint) error {
+	return r.db.Delete(&models.APIKey{}, id).Error
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository define a interface para operações de anexo no banco de dados
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment) error
+	GetByInteractionID(interactionID uint) ([]models.Attachment, error)
+}
+
+// attachmentRepository implementa AttachmentRepository
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository cria uma nova instância do repositório de anexos
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// Create cria um novo anexo no banco de dados
+func (r *attachmentRepository) Create(attachment *models.Attachment) error {
+	if err := r.db.Create(attachment).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByInteractionID busca os anexos de uma interação
+func (r *attachmentRepository) GetByInteractionID(interactionID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if err := r.db.Where("interaction_id = ?", interactionID).
+		Order("created_at DESC").
+		Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository define a interface para operações do log de auditoria no banco de dados
+type AuditLogRepository interface {
+	Create(auditLog *models.AuditLog) error
+	GetByUserID(userID uint, filter *models.AuditLogFilter) ([]models.AuditLog, error)
+}
+
+// auditLogRepository implementa AuditLogRepository
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository cria uma nova instância do repositório de auditoria
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+// Create grava um registro de auditoria
+func (r *auditLogRepository) Create(auditLog *models.AuditLog) error {
+	if err := r.db.Create(auditLog).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByUserID busca os registros de auditoria do usuário, dos mais recentes aos mais antigos
+func (r *auditLogRepository) GetByUserID(userID uint, filter *models.AuditLogFilter) ([]models.AuditLog, error) {
+	var auditLogs []models.AuditLog
+	query := r.db.Where("user_id = ?", userID)
+
+	if filter != nil {
+		if filter.EntityType != "" {
+			query = query.Where("entity_type = ?", filter.EntityType)
+		}
+		if filter.EntityID != 0 {
+			query = query.Where("entity_id = ?", filter.EntityID)
+		}
+
+		// Paginação
+		if filter.Limit > 0 {
+			query = query.Limit(filter.Limit)
+		}
+		if filter.Offset > 0 {
+			query = query.Offset(filter.Offset)
+		}
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Find(&auditLogs).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range auditLogs {
+		if auditLogs[i].ChangesJSON != "" {
+			auditLogs[i].Changes = json.RawMessage(auditLogs[i].ChangesJSON)
+		}
+	}
+
+	return auditLogs, nil
+}
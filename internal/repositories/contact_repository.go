@@ -2,24 +2,45 @@
 
 import (
 	"crm-backend/internal/models"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 // ContactRepository define a interface para operações de contato no banco de dados
 type ContactRepository interface {
+	WithTx(tx *gorm.DB) ContactRepository
 	Create(contact *models.Contact) error
 	GetByID(id uint) (*models.Contact, error)
 	GetByUserID(userID uint, filter *models.ContactListFilter) ([]models.Contact, error)
+	CountByUserIDWithFilter(userID uint, filter *models.ContactListFilter) (int64, error)
+	StreamByUserID(userID uint, filter *models.ContactListFilter, batchSize int, fn func(batch []models.Contact) error) error
 	Update(contact *models.Contact) error
 	Delete(id uint) error
+	GetDeletedByID(id uint) (*models.Contact, error)
+	GetDeletedByUserID(userID uint, filter *models.ContactListFilter) ([]models.Contact, error)
+	CountDeletedByUserID(userID uint, filter *models.ContactListFilter) (int64, error)
+	Restore(id uint, restoreInteractions bool) error
+	Purge(id uint) error
 	GetByEmail(email string) (*models.Contact, error)
+	GetByEmailAndUserID(email string, userID uint) (*models.Contact, error)
 	CountByUserID(userID uint) (int64, error)
 	CountByType(userID uint, contactType models.ContactType) (int64, error)
+	CountByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error)
+	GetByUserIDInRange(userID uint, dateRange *models.DateRange, limit int) ([]models.Contact, error)
+	CountByTypeInRange(userID uint, contactType models.ContactType, dateRange *models.DateRange) (int64, error)
+	CountConvertedInRange(userID uint, dateRange *models.DateRange) (int64, error)
 	SearchByName(userID uint, name string) ([]models.Contact, error)
 	GetWithInteractions(id uint) (*models.Contact, error)
 	GetWithTasks(id uint) (*models.Contact, error)
 	GetWithProjects(id uint) (*models.Contact, error)
+	TouchLastContacted(id uint, at time.Time) error
+	GetStaleClients(userID uint, before time.Time, limit int) ([]models.Contact, error)
+	GetStaleLeads(userID uint, before, until time.Time, limit int) ([]models.Contact, error)
+	GetWithDuplicateEmails(userID uint) ([]models.Contact, error)
+	GetNamesByUserID(userID uint) ([]models.Contact, error)
+	GetByIDs(userID uint, ids []uint) ([]models.Contact, error)
 }
 
 // contactRepository implementa ContactRepository
@@ -32,6 +53,11 @@ func NewContactRepository(db *gorm.DB) ContactRepository {
 	return &contactRepository{db: db}
 }
 
+// WithTx retorna uma cópia do repositório que executa as operações na transação informada
+func (r *contactRepository) WithTx(tx *gorm.DB) ContactRepository {
+	return &contactRepository{db: tx}
+}
+
 // Create cria um novo contato no banco de dados
 func (r *contactRepository) Create(contact *models.Contact) error {
 	if err := r.db.Create(contact).Error; err != nil {
@@ -43,7 +69,9 @@ func (r *contactRepository) Create(contact *models.Contact) error {
 // GetByID busca um contato pelo ID
 func (r *contactRepository) GetByID(id uint) (*models.Contact, error) {
 	var contact models.Contact
-	if err := r.db.Preload("User").First(&contact, id).Error; err != nil {
+	if err := r.db.Preload("User").Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tags.name ASC")
+	}).First(&contact, id).Error; err != nil {
 		return nil, err
 	}
 	return &contact, nil
@@ -88,21 +116,60 @@ func (r *contactRepository) GetWithProjects(id uint) (*models.Contact, error) {
 	return &contact, nil
 }
 
+// applyFilters aplica os filtros de listagem de contatos à consulta (sem paginação)
+func (r *contactRepository) applyFilters(query *gorm.DB, filter *models.ContactListFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Search != "" {
+		searchTerm := "%" + filter.Search + "%"
+		query = query.Where("name ILIKE ? OR email ILIKE ? OR company ILIKE ?",
+			searchTerm, searchTerm, searchTerm)
+	}
+	if filter.Company != "" {
+		query = query.Where("company ILIKE ?", "%"+filter.Company+"%")
+	}
+	if filter.HasProjects != nil {
+		projects := r.db.Model(&models.Project{}).Select("1").Where("projects.client_id = contacts.id")
+		if *filter.HasProjects {
+			query = query.Where("type = ? AND EXISTS (?)", models.ContactTypeClient, projects)
+		} else {
+			query = query.Where("NOT EXISTS (?)", projects)
+		}
+	}
+	if filter.Tags != "" {
+		if names := models.NormalizeTagNames(strings.Split(filter.Tags, ",")); len(names) > 0 {
+			tagged := r.db.Table("contact_tags").Select("1").
+				Joins("JOIN tags ON tags.id = contact_tags.tag_id").
+				Where("contact_tags.contact_id = contacts.id AND tags.name IN ?", names)
+			query = query.Where("EXISTS (?)", tagged)
+		}
+	}
+
+	return applyChangedSince(query, filter.ChangedSince)
+}
+
 // GetByUserID busca contatos por ID do usuário com filtros
 func (r *contactRepository) GetByUserID(userID uint, filter *models.ContactListFilter) ([]models.Contact, error) {
 	var contacts []models.Contact
-	query := r.db.Where("user_id = ?", userID)
+	query := r.applyFilters(r.db.Where("user_id = ?", userID), filter)
+
+	// Ordenar por nome (padrão) ou pela coluna solicitada
+	orderClause := "name ASC"
 
-	// Aplicar filtros
 	if filter != nil {
-		if filter.Type != "" {
-			query = query.Where("type = ?", filter.Type)
+		if filter.ChangedSince != nil {
+			orderClause = syncOrder
 		}
-		if filter.Search != "" {
-			searchTerm := "%" + filter.Search + "%"
-			query = query.Where("name ILIKE ? OR email ILIKE ? OR company ILIKE ?",
-				searchTerm, searchTerm, searchTerm)
+		clause, err := OrderClause(filter.Sort, filter.Order, ContactSortColumns, orderClause)
+		if err != nil {
+			return nil, err
 		}
+		orderClause = clause
 
 		// Paginação
 		if filter.Limit > 0 {
@@ -113,31 +180,59 @@ func (r *contactRepository) GetByUserID(userID uint, filter *models.ContactListF
 		}
 	}
 
-	// Ordenar por nome
-	query = query.Order("name ASC")
+	query = query.Order(orderClause)
 
-	if err := query.Preload("User").Find(&contacts).Error; err != nil {
+	if err := query.Preload("User").Preload("Tags").Find(&contacts).Error; err != nil {
 		return nil, err
 	}
 
 	return contacts, nil
 }
 
-// GetByEmail busca um contato pelo email
+// CountByUserIDWithFilter conta os contatos do usuário que atendem aos filtros (ignorando paginação)
+func (r *contactRepository) CountByUserIDWithFilter(userID uint, filter *models.ContactListFilter) (int64, error) {
+	var count int64
+	query := r.applyFilters(r.db.Model(&models.Contact{}).Where("user_id = ?", userID), filter)
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// StreamByUserID percorre os contatos do usuário em lotes (ordenados por ID), aplicando os filtros (sem paginação)
+func (r *contactRepository) StreamByUserID(userID uint, filter *models.ContactListFilter, batchSize int, fn func(batch []models.Contact) error) error {
+	var batch []models.Contact
+	query := r.applyFilters(r.db.Where("user_id = ?", userID), filter)
+
+	result := query.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	})
+	return result.Error
+}
+
+// GetByEmail busca um contato pelo email (sem diferenciar maiúsculas)
 func (r *contactRepository) GetByEmail(email string) (*models.Contact, error) {
 	var contact models.Contact
-	if err := r.db.Where("email = ?", email).First(&contact).Error; err != nil {
+	if err := r.db.Where("LOWER(email) = LOWER(?)", email).First(&contact).Error; err != nil {
 		return nil, err
 	}
 	return &contact, nil
 }
 
-// Update atualiza um contato existente
-func (r *contactRepository) Update(contact *models.Contact) error {
-	if err := r.db.Save(contact).Error; err != nil {
-		return err
+// GetByEmailAndUserID busca um contato pelo email (sem diferenciar maiúsculas) entre os contatos do usuário
+func (r *contactRepository) GetByEmailAndUserID(email string, userID uint) (*models.Contact, error) {
+	var contact models.Contact
+	if err := r.db.Where("LOWER(email) = LOWER(?) AND user_id = ?", email, userID).First(&contact).Error; err != nil {
+		return nil, err
 	}
-	return nil
+	return &contact, nil
+}
+
+// Update atualiza um contato existente com controle de concorrência otimista.
+// Retorna ErrVersionConflict se a versão em contact.Version não for mais a atual.
+func (r *contactRepository) Update(contact *models.Contact) error {
+	return updateVersioned(r.db, contact, &contact.Version)
 }
 
 // Delete remove um contato do banco de dados (soft delete)
@@ -148,26 +243,267 @@ func (r *contactRepository) Delete(id uint) error {
 	return nil
 }
 
-// CountByUserID conta o número total de contatos de um usuário
-func (r *contactRepository) CountByUserID(userID uint) (int64, error) {
+// GetDeletedByID busca um contato excluído (soft delete) pelo ID
+func (r *contactRepository) GetDeletedByID(id uint) (*models.Contact, error) {
+	var contact models.Contact
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&contact, id).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// GetDeletedByUserID busca os contatos excluídos (soft delete) do usuário, dos mais recentes aos mais antigos
+func (r *contactRepository) GetDeletedByUserID(userID uint, filter *models.ContactListFilter) ([]models.Contact, error) {
+	var contacts []models.Contact
+	query := r.applyFilters(r.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID), filter)
+
+	if filter != nil {
+		// Paginação
+		if filter.Limit > 0 {
+			query = query.Limit(filter.Limit)
+		}
+		if filter.Offset > 0 {
+			query = query.Offset(filter.Offset)
+		}
+	}
+
+	if err := query.Order("deleted_at DESC").Preload("User").Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+// CountDeletedByUserID conta os contatos excluídos do usuário que atendem aos filtros (ignorando paginação)
+func (r *contactRepository) CountDeletedByUserID(userID uint, filter *models.ContactListFilter) (int64, error) {
 	var count int64
-	if err := r.db.Model(&models.Contact{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+	query := r.applyFilters(r.db.Unscoped().Model(&models.Contact{}).Where("user_id = ? AND deleted_at IS NOT NULL", userID), filter)
+
+	if err := query.Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
+// Restore restaura um contato excluído e, opcionalmente, suas interações excluídas
+func (r *contactRepository) Restore(id uint, restoreInteractions bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Contact{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		if restoreInteractions {
+			if err := tx.Unscoped().Model(&models.Interaction{}).
+				Where("contact_id = ? AND deleted_at IS NOT NULL", id).
+				Update("deleted_at", nil).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Purge remove definitivamente um contato, junto com suas interações e tarefas (incluindo as excluídas),
+// os comentários e notificações dessas tarefas, as associações com etiquetas e as datas importantes.
+// Interações de outros contatos que tinham essas tarefas como follow-up perdem a referência.
+func (r *contactRepository) Purge(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		interactionIDs := tx.Unscoped().Model(&models.Interaction{}).Select("id").Where("contact_id = ?", id)
+		if err := tx.Unscoped().Where("interaction_id IN (?)", interactionIDs).Delete(&models.Attachment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("contact_id = ?", id).Delete(&models.Interaction{}).Error; err != nil {
+			return err
+		}
+		taskIDs := tx.Unscoped().Model(&models.Task{}).Select("id").Where("contact_id = ?", id)
+		if err := tx.Where("task_id IN (?)", taskIDs).Delete(&models.TaskComment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id IN (?)", taskIDs).Delete(&models.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.Interaction{}).
+			Where("follow_up_task_id IN (?)", taskIDs).
+			UpdateColumn("follow_up_task_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("contact_id = ?", id).Delete(&models.Task{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM contact_tags WHERE contact_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("contact_id = ?", id).Delete(&models.ImportantDate{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&models.Contact{}, id).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// CountByUserID conta o número total de contatos de um usuário
+func (r *contactRepository) CountByUserID(userID uint) (int64, error) {
+	return r.CountByUserIDInRange(userID, nil)
+}
+
 // CountByType conta o número de contatos por tipo de um usuário
 func (r *contactRepository) CountByType(userID uint, contactType models.ContactType) (int64, error) {
+	return r.CountByTypeInRange(userID, contactType, nil)
+}
+
+// CountByUserIDInRange conta os contatos do usuário criados no intervalo informado
+func (r *contactRepository) CountByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error) {
 	var count int64
-	if err := r.db.Model(&models.Contact{}).
-		Where("user_id = ? AND type = ?", userID, contactType).
-		Count(&count).Error; err != nil {
+	query := applyDateRange(r.db.Model(&models.Contact{}).Where("user_id = ?", userID), "created_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
+// GetByUserIDInRange busca os contatos do usuário criados no intervalo informado, dos mais recentes aos mais antigos
+func (r *contactRepository) GetByUserIDInRange(userID uint, dateRange *models.DateRange, limit int) ([]models.Contact, error) {
+	var contacts []models.Contact
+	query := applyDateRange(r.db.Where("user_id = ?", userID), "created_at", dateRange).
+		Order("created_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// CountByTypeInRange conta os contatos do usuário, com o tipo atual informado, criados no intervalo
+func (r *contactRepository) CountByTypeInRange(userID uint, contactType models.ContactType, dateRange *models.DateRange) (int64, error) {
+	var count int64
+	query := applyDateRange(r.db.Model(&models.Contact{}).
+		Where("user_id = ? AND type = ?", userID, contactType), "created_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountConvertedInRange conta os clientes do usuário convertidos a partir de leads no intervalo informado
+func (r *contactRepository) CountConvertedInRange(userID uint, dateRange *models.DateRange) (int64, error) {
+	var count int64
+	query := applyDateRange(r.db.Model(&models.Contact{}).
+		Where("user_id = ? AND type = ? AND converted_at IS NOT NULL", userID, models.ContactTypeClient), "converted_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// TouchLastContacted registra o momento do último contato, sem retroceder um valor mais recente.
+// Atualiza apenas a coluna last_contacted_at, sem alterar updated_at nem a versão.
+func (r *contactRepository) TouchLastContacted(id uint, at time.Time) error {
+	return r.db.Model(&models.Contact{}).
+		Where("id = ? AND (last_contacted_at IS NULL OR last_contacted_at < ?)", id, at).
+		UpdateColumn("last_contacted_at", at).Error
+}
+
+// GetStaleClients busca os clientes do usuário sem contato desde o momento informado (ou nunca contatados),
+// dos contatos mais antigos aos mais recentes
+func (r *contactRepository) GetStaleClients(userID uint, before time.Time, limit int) ([]models.Contact, error) {
+	var contacts []models.Contact
+	query := r.db.Where("user_id = ? AND type = ?", userID, models.ContactTypeClient).
+		Where("last_contacted_at < ? OR last_contacted_at IS NULL", before).
+		Order("last_contacted_at ASC NULLS FIRST").
+		Order("name ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// GetStaleLeads busca os leads do usuário cadastrados antes de before e sem interação de before até
+// until, dos que estão há mais tempo sem atividade (última interação até until ou, sem interações, o
+// cadastro) aos mais recentes
+func (r *contactRepository) GetStaleLeads(userID uint, before, until time.Time, limit int) ([]models.Contact, error) {
+	lastInteractions := r.db.Model(&models.Interaction{}).
+		Select("interactions.contact_id, MAX(interactions.date) AS last_date").
+		Joins("JOIN contacts ON interactions.contact_id = contacts.id").
+		Where("contacts.user_id = ? AND interactions.date <= ?", userID, until).
+		Group("interactions.contact_id")
+
+	var contacts []models.Contact
+	query := r.db.Select("contacts.*").
+		Joins("LEFT JOIN (?) AS last_interactions ON last_interactions.contact_id = contacts.id", lastInteractions).
+		Where("contacts.user_id = ? AND contacts.type = ? AND contacts.created_at < ?", userID, models.ContactTypeLead, before).
+		Where("last_interactions.last_date IS NULL OR last_interactions.last_date < ?", before).
+		Order("COALESCE(last_interactions.last_date, contacts.created_at) ASC").
+		Order("contacts.id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// GetWithDuplicateEmails busca os contatos do usuário cujo e-mail (sem diferenciar maiúsculas) é
+// compartilhado com outro contato do usuário, ordenados por e-mail. Apenas ID e e-mail são carregados.
+func (r *contactRepository) GetWithDuplicateEmails(userID uint) ([]models.Contact, error) {
+	duplicated := r.db.Model(&models.Contact{}).
+		Select("LOWER(email)").
+		Where("user_id = ? AND email <> ''", userID).
+		Group("LOWER(email)").
+		Having("COUNT(*) > 1")
+
+	var contacts []models.Contact
+	if err := r.db.Select("id", "email").
+		Where("user_id = ? AND LOWER(email) IN (?)", userID, duplicated).
+		Order("LOWER(email) ASC, id ASC").
+		Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// GetNamesByUserID busca ID, nome e empresa de todos os contatos do usuário, ordenados por ID
+func (r *contactRepository) GetNamesByUserID(userID uint) ([]models.Contact, error) {
+	var contacts []models.Contact
+	if err := r.db.Select("id", "name", "company").
+		Where("user_id = ?", userID).
+		Order("id ASC").
+		Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// GetByIDs busca os contatos do usuário com os IDs informados. IDs inexistentes, excluídos ou de
+// outros usuários são ignorados.
+func (r *contactRepository) GetByIDs(userID uint, ids []uint) ([]models.Contact, error) {
+	var contacts []models.Contact
+	if len(ids) == 0 {
+		return contacts, nil
+	}
+
+	if err := r.db.Where("user_id = ? AND id IN ?", userID, ids).
+		Order("id ASC").
+		Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
 // SearchByName busca contatos por nome (busca parcial)
 func (r *contactRepository) SearchByName(userID uint, name string) ([]models.Contact, error) {
 	var contacts []models.Contact
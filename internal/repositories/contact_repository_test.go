@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContactGetStaleLeadsQuery(t *testing.T) {
+	db, captured := newDryRunDB(t)
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	if _, err := NewContactRepository(db).GetStaleLeads(7, before, until, 20); err != nil {
+		t.Fatalf("GetStaleLeads: %v", err)
+	}
+	// A subconsulta é montada (sem ser executada) antes da consulta principal
+	if len(*captured) == 0 {
+		t.Fatal("nenhuma consulta gerada")
+	}
+	query := (*captured)[len(*captured)-1]
+	sql := query.SQL
+
+	// Filtro, ordenação e limite no banco, sem carregar todos os leads do usuário
+	for _, want := range []string{
+		`"interactions"."deleted_at" IS NULL`,
+		`"contacts"."deleted_at" IS NULL`,
+		"contacts.user_id = $",
+		"contacts.type = $",
+		"last_interactions.last_date IS NULL OR last_interactions.last_date < $",
+		"ORDER BY COALESCE(last_interactions.last_date, contacts.created_at) ASC,contacts.id ASC",
+		"LIMIT 20",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("SQL sem %q:\n%s", want, sql)
+		}
+	}
+
+	// A subconsulta das últimas interações também é restrita ao usuário e ao momento atual
+	vars := query.Vars
+	if len(vars) < 2 || vars[0] != uint(7) || vars[1] != until {
+		t.Errorf("vars da subconsulta = %v, want [7 %v ...]", vars, until)
+	}
+}
+
+func TestContactGetByEmailAndUserIDQuery(t *testing.T) {
+	db, captured := newDryRunDB(t)
+
+	if _, err := NewContactRepository(db).GetByEmailAndUserID("ana@example.com", 7); err != nil {
+		t.Fatalf("GetByEmailAndUserID: %v", err)
+	}
+
+	// A busca é restrita aos contatos do usuário, para não revelar contatos de outras contas
+	query := lastQuery(t, captured)
+	assertContainsAll(t, whereClause(query.SQL), "LOWER(email) = LOWER($1) AND user_id = $2")
+	if len(query.Vars) < 2 || query.Vars[0] != "ana@example.com" || query.Vars[1] != uint(7) {
+		t.Errorf("vars = %v, want [ana@example.com 7 ...]", query.Vars)
+	}
+}
+
+func TestContactListFilterCompanyAndProjects(t *testing.T) {
+	withProjects, withoutProjects := true, false
+	const projectsExist = `EXISTS (SELECT 1 FROM "projects" WHERE projects.client_id = contacts.id AND "projects"."deleted_at" IS NULL)`
+
+	tests := []struct {
+		name      string
+		filter    *models.ContactListFilter
+		wantWhere string
+		wantVars  string
+	}{
+		{
+			name:      "sem filtros",
+			filter:    &models.ContactListFilter{},
+			wantWhere: ` WHERE user_id = $1 AND "contacts"."deleted_at" IS NULL`,
+			wantVars:  "[7]",
+		},
+		{
+			name:      "empresa parcial",
+			filter:    &models.ContactListFilter{Company: "Acme"},
+			wantWhere: ` WHERE user_id = $1 AND company ILIKE $2 AND "contacts"."deleted_at" IS NULL`,
+			wantVars:  "[7 %Acme%]",
+		},
+		{
+			name:      "com projetos",
+			filter:    &models.ContactListFilter{HasProjects: &withProjects},
+			wantWhere: ` WHERE user_id = $1 AND (type = $2 AND ` + projectsExist + `) AND "contacts"."deleted_at" IS NULL`,
+			wantVars:  "[7 CLIENT]",
+		},
+		{
+			name:      "sem projetos",
+			filter:    &models.ContactListFilter{HasProjects: &withoutProjects},
+			wantWhere: ` WHERE user_id = $1 AND NOT ` + projectsExist + ` AND "contacts"."deleted_at" IS NULL`,
+			wantVars:  "[7]",
+		},
+		{
+			name:      "empresa e com projetos",
+			filter:    &models.ContactListFilter{Company: "Acme", HasProjects: &withProjects},
+			wantWhere: ` WHERE user_id = $1 AND company ILIKE $2 AND (type = $3 AND ` + projectsExist + `) AND "contacts"."deleted_at" IS NULL`,
+			wantVars:  "[7 %Acme% CLIENT]",
+		},
+		{
+			name:      "empresa e sem projetos",
+			filter:    &models.ContactListFilter{Company: "Acme", HasProjects: &withoutProjects},
+			wantWhere: ` WHERE user_id = $1 AND company ILIKE $2 AND NOT ` + projectsExist + ` AND "contacts"."deleted_at" IS NULL`,
+			wantVars:  "[7 %Acme%]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, captured := newDryRunDB(t)
+			if _, err := NewContactRepository(db).GetByUserID(7, tt.filter); err != nil {
+				t.Fatalf("GetByUserID: %v", err)
+			}
+
+			query := lastQuery(t, captured)
+			if got := whereClause(query.SQL); got != tt.wantWhere {
+				t.Errorf("WHERE = %q\nwant    %q", got, tt.wantWhere)
+			}
+			if got := fmt.Sprint(query.Vars); got != tt.wantVars {
+				t.Errorf("vars = %s, want %s", got, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestContactPurgeRemovesTaskDependents(t *testing.T) {
+	db, captured := newDryRunDB(t)
+
+	if err := NewContactRepository(db).Purge(3); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+
+	// Comentários, notificações e follow-ups das tarefas do contato são tratados antes da remoção das tarefas
+	contactTasks := `IN (SELECT "id" FROM "tasks" WHERE contact_id = $1)`
+	want := []string{
+		`DELETE FROM "task_comments" WHERE task_id ` + contactTasks,
+		`DELETE FROM "notifications" WHERE task_id ` + contactTasks,
+		`UPDATE "interactions" SET "follow_up_task_id"=$1 WHERE follow_up_task_id IN (SELECT "id" FROM "tasks" WHERE contact_id = $2)`,
+		`DELETE FROM "tasks" WHERE contact_id = $1`,
+	}
+	got := strings.Join(writes(captured), "\n")
+	last := -1
+	for _, statement := range want {
+		index := strings.Index(got, statement)
+		if index < 0 {
+			t.Fatalf("instrução ausente: %q\n%s", statement, got)
+		}
+		if index < last {
+			t.Errorf("instrução fora de ordem: %q\n%s", statement, got)
+		}
+		last = index
+	}
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// applyDateRange restringe a consulta ao intervalo informado na coluna indicada (sem intervalo, não altera a consulta)
+func applyDateRange(query *gorm.DB, column string, dateRange *models.DateRange) *gorm.DB {
+	if dateRange == nil {
+		return query
+	}
+	if dateRange.From != nil {
+		query = query.Where(column+" >= ?", *dateRange.From)
+	}
+	if dateRange.To != nil {
+		query = query.Where(column+" <= ?", *dateRange.To)
+	}
+	return query
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// capturedQuery é uma instrução gerada pelo GORM em modo DryRun
+type capturedQuery struct {
+	SQL  string
+	Vars []interface{}
+}
+
+// dryRunPool substitui a conexão com o banco: em modo DryRun nenhuma instrução é executada,
+// e as transações apenas delimitam as instruções capturadas
+type dryRunPool struct{}
+
+var errDryRunPool = errors.New("dryRunPool não executa instruções")
+
+func (*dryRunPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errDryRunPool
+}
+
+func (*dryRunPool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errDryRunPool
+}
+
+func (*dryRunPool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errDryRunPool
+}
+
+func (*dryRunPool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func (p *dryRunPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return p, nil
+}
+
+func (*dryRunPool) Commit() error   { return nil }
+func (*dryRunPool) Rollback() error { return nil }
+
+// newDryRunDB cria uma conexão que apenas monta as instruções (sem banco) e captura, em ordem,
+// as consultas, atualizações e exclusões, inclusive as feitas dentro de transações
+func newDryRunDB(t *testing.T) (*gorm.DB, *[]capturedQuery) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: &dryRunPool{}}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+
+	var captured []capturedQuery
+	capture := func(tx *gorm.DB) {
+		captured = append(captured, capturedQuery{SQL: tx.Statement.SQL.String(), Vars: tx.Statement.Vars})
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture", capture); err != nil {
+		t.Fatalf("registrar callback: %v", err)
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("test:capture", capture); err != nil {
+		t.Fatalf("registrar callback: %v", err)
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("test:capture", capture); err != nil {
+		t.Fatalf("registrar callback: %v", err)
+	}
+	return db, &captured
+}
+
+// whereClause extrai o trecho WHERE de uma instrução SELECT, sem ordenação e paginação
+func whereClause(sql string) string {
+	start := strings.Index(sql, " WHERE ")
+	if start < 0 {
+		return ""
+	}
+	where := sql[start:]
+	for _, suffix := range []string{" ORDER BY ", " LIMIT ", " OFFSET "} {
+		if end := strings.Index(where, suffix); end >= 0 {
+			where = where[:end]
+		}
+	}
+	return where
+}
+
+// writes retorna, em ordem, as atualizações e exclusões capturadas, ignorando as subconsultas
+func writes(captured *[]capturedQuery) []string {
+	var statements []string
+	for _, query := range *captured {
+		if !strings.HasPrefix(query.SQL, "SELECT ") {
+			statements = append(statements, query.SQL)
+		}
+	}
+	return statements
+}
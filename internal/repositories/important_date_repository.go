@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImportantDateRepository define a interface para operações de datas importantes no banco de dados
+type ImportantDateRepository interface {
+	Create(date *models.ImportantDate) error
+	GetByContactID(contactID uint) ([]models.ImportantDate, error)
+	GetUpcomingCandidates(userID uint, from, to time.Time) ([]models.ImportantDate, error)
+}
+
+// importantDateRepository implementa ImportantDateRepository
+type importantDateRepository struct {
+	db *gorm.DB
+}
+
+// NewImportantDateRepository cria uma nova instância do repositório de datas importantes
+func NewImportantDateRepository(db *gorm.DB) ImportantDateRepository {
+	return &importantDateRepository{db: db}
+}
+
+// Create cria uma nova data importante no banco de dados
+func (r *importantDateRepository) Create(date *models.ImportantDate) error {
+	if err := r.db.Create(date).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByContactID busca as datas importantes de um contato, ordenadas por mês e dia
+func (r *importantDateRepository) GetByContactID(contactID uint) ([]models.ImportantDate, error) {
+	var dates []models.ImportantDate
+	if err := r.db.Where("contact_id = ?", contactID).
+		Order("EXTRACT(MONTH FROM date) ASC").
+		Order("EXTRACT(DAY FROM date) ASC").
+		Order("id ASC").
+		Find(&dates).Error; err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
+
+// GetUpcomingCandidates busca as datas dos contatos ativos do usuário que podem ocorrer entre from e to:
+// todas as recorrentes (a próxima ocorrência é calculada pelo serviço) e as únicas dentro do intervalo
+func (r *importantDateRepository) GetUpcomingCandidates(userID uint, from, to time.Time) ([]models.ImportantDate, error) {
+	var dates []models.ImportantDate
+	if err := r.db.Joins("JOIN contacts ON important_dates.contact_id = contacts.id AND contacts.deleted_at IS NULL").
+		Where("contacts.user_id = ?", userID).
+		Where("important_dates.recurring = ? OR important_dates.date BETWEEN ? AND ?", true, from.Format(models.ImportantDateLayout), to.Format(models.ImportantDateLayout)).
+		Preload("Contact").
+		Find(&dates).Error; err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
@@ -2,6 +2,7 @@
 
 import (
 	"crm-backend/internal/models"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -9,14 +10,25 @@
 
 // InteractionRepository define a interface para operações de interação no banco de dados
 type InteractionRepository interface {
+	WithTx(tx *gorm.DB) InteractionRepository
 	Create(interaction *models.Interaction) error
+	CreateWithFollowUp(interaction *models.Interaction, followUp *models.Task) error
 	GetByID(id uint) (*models.Interaction, error)
 	GetByContactID(contactID uint, filter *models.InteractionListFilter) ([]models.Interaction, error)
 	Update(interaction *models.Interaction) error
 	Delete(id uint) error
 	GetByUserID(userID uint, filter *models.InteractionListFilter) ([]models.Interaction, error)
+	CountByUserIDWithFilter(userID uint, filter *models.InteractionListFilter) (int64, error)
 	CountByContactID(contactID uint) (int64, error)
-	GetRecentByUserID(userID uint, days int, limit int) ([]models.Interaction, error)
+	CountByContactIDWithFilter(contactID uint, filter *models.InteractionListFilter) (int64, error)
+	CountByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error)
+	GetByUserIDInRange(userID uint, dateRange *models.DateRange, limit int) ([]models.Interaction, error)
+	StreamByUserID(userID uint, batchSize int, fn func(batch []models.Interaction) error) error
+	CountByContactIDGroupedByType(contactID uint) (map[models.InteractionType]int64, error)
+	CountByContactIDGroupedByOutcome(contactID uint) (map[models.InteractionOutcome]int64, error)
+	GetRecentByUserID(userID uint, since time.Time, limit int) ([]models.Interaction, error)
+	GetLastDateByContact(userID uint, until time.Time) (map[uint]time.Time, error)
+	Search(userID uint, term string, limit int) ([]models.Interaction, error)
 }
 
 // interactionRepository implementa InteractionRepository
@@ -29,6 +41,11 @@ func NewInteractionRepository(db *gorm.DB) InteractionRepository {
 	return &interactionRepository{db: db}
 }
 
+// WithTx retorna uma cópia do repositório que executa as operações na transação informada
+func (r *interactionRepository) WithTx(tx *gorm.DB) InteractionRepository {
+	return &interactionRepository{db: tx}
+}
+
 // Create cria uma nova interação no banco de dados
 func (r *interactionRepository) Create(interaction *models.Interaction) error {
 	if err := r.db.Create(interaction).Error; err != nil {
@@ -37,6 +54,21 @@ func (r *interactionRepository) Create(interaction *models.Interaction) error {
 	return nil
 }
 
+// CreateWithFollowUp cria a tarefa de follow-up e a interação vinculada a ela na mesma transação
+func (r *interactionRepository) CreateWithFollowUp(interaction *models.Interaction, followUp *models.Task) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(followUp).Error; err != nil {
+			return err
+		}
+		interaction.FollowUpTaskID = &followUp.ID
+		if err := tx.Create(interaction).Error; err != nil {
+			interaction.FollowUpTaskID = nil
+			return err
+		}
+		return nil
+	})
+}
+
 // GetByID busca uma interação pelo ID
 func (r *interactionRepository) GetByID(id uint) (*models.Interaction, error) {
 	var interaction models.Interaction
@@ -49,20 +81,9 @@ func (r *interactionRepository) GetByID(id uint) (*models.Interaction, error) {
 // GetByContactID busca interações por ID do contato com filtros
 func (r *interactionRepository) GetByContactID(contactID uint, filter *models.InteractionListFilter) ([]models.Interaction, error) {
 	var interactions []models.Interaction
-	query := r.db.Where("contact_id = ?", contactID)
+	query := r.applyFilters(r.db.Where("interactions.contact_id = ?", contactID), filter)
 
-	// Aplicar filtros
 	if filter != nil {
-		if filter.Type != "" {
-			query = query.Where("type = ?", filter.Type)
-		}
-		if filter.DateFrom != nil {
-			query = query.Where("date >= ?", filter.DateFrom)
-		}
-		if filter.DateTo != nil {
-			query = query.Where("date <= ?", filter.DateTo)
-		}
-
 		// Paginação
 		if filter.Limit > 0 {
 			query = query.Limit(filter.Limit)
@@ -73,7 +94,7 @@ func (r *interactionRepository) GetByContactID(contactID uint, filter *models.In
 	}
 
 	// Ordenar por data (mais recente primeiro)
-	query = query.Order("date DESC")
+	query = query.Order("interactions.date DESC")
 
 	if err := query.Preload("Contact").Find(&interactions).Error; err != nil {
 		return nil, err
@@ -82,38 +103,64 @@ func (r *interactionRepository) GetByContactID(contactID uint, filter *models.In
 	return interactions, nil
 }
 
+// applyFilters aplica os filtros de listagem de interações à consulta (sem paginação). As colunas são
+// qualificadas para que a mesma lógica sirva às consultas que juntam a tabela de contatos.
+func (r *interactionRepository) applyFilters(query *gorm.DB, filter *models.InteractionListFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+
+	if filter.Type != "" {
+		query = query.Where("interactions.type = ?", filter.Type)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("interactions.outcome = ?", filter.Outcome)
+	}
+	if filter.DateFrom != nil {
+		query = query.Where("interactions.date >= ?", filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("interactions.date <= ?", filter.DateTo)
+	}
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		searchTerm := "%" + search + "%"
+		query = query.Where("interactions.subject ILIKE ? OR interactions.description ILIKE ?", searchTerm, searchTerm)
+	}
+
+	return query
+}
+
+// applyUserFilters restringe a consulta às interações dos contatos do usuário e aplica os filtros
+// da listagem global, que também aceita filtrar por contato (sem paginação)
+func (r *interactionRepository) applyUserFilters(query *gorm.DB, userID uint, filter *models.InteractionListFilter) *gorm.DB {
+	query = query.Joins("JOIN contacts ON interactions.contact_id = contacts.id").
+		Where("contacts.user_id = ?", userID)
+	if filter != nil && filter.ContactID > 0 {
+		query = query.Where("interactions.contact_id = ?", filter.ContactID)
+	}
+
+	return r.applyFilters(query, filter)
+}
+
 // GetByUserID busca interações por ID do usuário (através dos contatos)
 func (r *interactionRepository) GetByUserID(userID uint, filter *models.InteractionListFilter) ([]models.Interaction, error) {
 	var interactions []models.Interaction
-	query := r.db.Joins("JOIN contacts ON interactions.contact_id = contacts.id").
-		Where("contacts.user_id = ?", userID)
+	query := r.applyUserFilters(r.db, userID, filter)
 
-	// Aplicar filtros
+	// Paginação: com cursor, continua após o último item visto; caso contrário, usa offset
 	if filter != nil {
-		if filter.Type != "" {
-			query = query.Where("interactions.type = ?", filter.Type)
-		}
-		if filter.DateFrom != nil {
-			query = query.Where("interactions.date >= ?", filter.DateFrom)
-		}
-		if filter.DateTo != nil {
-			query = query.Where("interactions.date <= ?", filter.DateTo)
-		}
-		if filter.ContactID > 0 {
-			query = query.Where("interactions.contact_id = ?", filter.ContactID)
-		}
-
-		// Paginação
 		if filter.Limit > 0 {
 			query = query.Limit(filter.Limit)
 		}
-		if filter.Offset > 0 {
+		if filter.After != nil {
+			query = query.Where("(interactions.date, interactions.id) < (?, ?)", filter.After.Date, filter.After.ID)
+		} else if filter.Offset > 0 {
 			query = query.Offset(filter.Offset)
 		}
 	}
 
-	// Ordenar por data (mais recente primeiro)
-	query = query.Order("interactions.date DESC")
+	// Ordenar por data (mais recente primeiro), desempatando pelo ID para manter o cursor estável
+	query = query.Order("interactions.date DESC").Order("interactions.id DESC")
 
 	if err := query.Preload("Contact").Find(&interactions).Error; err != nil {
 		return nil, err
@@ -122,6 +169,17 @@ func (r *interactionRepository) GetByUserID(userID uint, filter *models.Interact
 	return interactions, nil
 }
 
+// CountByUserIDWithFilter conta as interações do usuário que atendem aos filtros (ignorando paginação)
+func (r *interactionRepository) CountByUserIDWithFilter(userID uint, filter *models.InteractionListFilter) (int64, error) {
+	var count int64
+	query := r.applyUserFilters(r.db.Model(&models.Interaction{}), userID, filter)
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // Update atualiza uma interação existente
 func (r *interactionRepository) Update(interaction *models.Interaction) error {
 	if err := r.db.Save(interaction).Error; err != nil {
@@ -147,15 +205,169 @@ func (r *interactionRepository) CountByContactID(contactID uint) (int64, error)
 	return count, nil
 }
 
+// CountByContactIDWithFilter conta as interações do contato que atendem aos filtros (ignorando paginação)
+func (r *interactionRepository) CountByContactIDWithFilter(contactID uint, filter *models.InteractionListFilter) (int64, error) {
+	var count int64
+	query := r.applyFilters(r.db.Model(&models.Interaction{}).Where("interactions.contact_id = ?", contactID), filter)
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountByUserIDInRange conta as interações dos contatos do usuário ocorridas no intervalo informado
+func (r *interactionRepository) CountByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error) {
+	var count int64
+	query := applyDateRange(r.db.Model(&models.Interaction{}).
+		Joins("JOIN contacts ON interactions.contact_id = contacts.id").
+		Where("contacts.user_id = ?", userID), "interactions.date", dateRange)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// StreamByUserID percorre todas as interações dos contatos do usuário em lotes (ordenadas por ID)
+func (r *interactionRepository) StreamByUserID(userID uint, batchSize int, fn func(batch []models.Interaction) error) error {
+	var batch []models.Interaction
+	contactIDs := r.db.Model(&models.Contact{}).Select("id").Where("user_id = ?", userID)
+	result := r.db.Where("contact_id IN (?)", contactIDs).FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	})
+	return result.Error
+}
+
+// GetByUserIDInRange busca as interações dos contatos do usuário ocorridas no intervalo informado, das mais recentes às mais antigas
+func (r *interactionRepository) GetByUserIDInRange(userID uint, dateRange *models.DateRange, limit int) ([]models.Interaction, error) {
+	var interactions []models.Interaction
+	query := applyDateRange(r.db.Joins("JOIN contacts ON interactions.contact_id = contacts.id").
+		Where("contacts.user_id = ?", userID), "interactions.date", dateRange).
+		Preload("Contact").
+		Order("interactions.date DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&interactions).Error; err != nil {
+		return nil, err
+	}
+	return interactions, nil
+}
+
+// CountByContactIDGroupedByType conta as interações de um contato agrupadas por tipo.
+// Todos os tipos conhecidos estão presentes no resultado, com 0 quando não há interações.
+func (r *interactionRepository) CountByContactIDGroupedByType(contactID uint) (map[models.InteractionType]int64, error) {
+	var rows []struct {
+		Type  models.InteractionType
+		Count int64
+	}
+
+	if err := r.db.Model(&models.Interaction{}).
+		Select("type, COUNT(*) AS count").
+		Where("contact_id = ?", contactID).
+		Group("type").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counted := make(map[models.InteractionType]int64, len(rows))
+	for _, row := range rows {
+		counted[row.Type] = row.Count
+	}
+
+	return zeroFilledCounts(models.InteractionTypes, counted), nil
+}
+
+// CountByContactIDGroupedByOutcome conta as interações de um contato agrupadas por resultado.
+// Todos os resultados conhecidos estão presentes no resultado, com 0 quando não há interações.
+func (r *interactionRepository) CountByContactIDGroupedByOutcome(contactID uint) (map[models.InteractionOutcome]int64, error) {
+	var rows []struct {
+		Outcome models.InteractionOutcome
+		Count   int64
+	}
+
+	if err := r.db.Model(&models.Interaction{}).
+		Select("outcome, COUNT(*) AS count").
+		Where("contact_id = ?", contactID).
+		Group("outcome").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counted := make(map[models.InteractionOutcome]int64, len(rows))
+	for _, row := range rows {
+		counted[row.Outcome] = row.Count
+	}
+
+	return zeroFilledCounts(models.InteractionOutcomes, counted), nil
+}
+
+// zeroFilledCounts devolve as contagens com todas as chaves conhecidas presentes, usando 0 para as ausentes
+func zeroFilledCounts[K comparable](known []K, counted map[K]int64) map[K]int64 {
+	counts := make(map[K]int64, len(known))
+	for _, key := range known {
+		counts[key] = 0
+	}
+	for key, count := range counted {
+		counts[key] = count
+	}
+	return counts
+}
+
 // GetRecentByUserID busca interações recentes do usuário nos últimos X dias
-func (r *interactionRepository) GetRecentByUserID(userID uint, days int, limit int) ([]models.Interaction, error) {
+func (r *interactionRepository) GetRecentByUserID(userID uint, since time.Time, limit int) ([]models.Interaction, error) {
 	var interactions []models.Interaction
 
-	// Calcular data de início (X dias atrás)
-	startDate := time.Now().AddDate(0, 0, -days)
+	query := r.db.Joins("JOIN contacts ON interactions.contact_id = contacts.id").
+		Where("contacts.user_id = ? AND interactions.date >= ?", userID, since).
+		Order("interactions.date DESC").
+		Preload("Contact")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&interactions).Error; err != nil {
+		return nil, err
+	}
+
+	return interactions, nil
+}
+
+// GetLastDateByContact retorna, por contato do usuário, a data da interação mais recente até until. Interações agendadas para depois de until não contam; contatos sem interação ficam de fora.
+func (r *interactionRepository) GetLastDateByContact(userID uint, until time.Time) (map[uint]time.Time, error) {
+	var rows []struct {
+		ContactID uint
+		LastDate  time.Time
+	}
+
+	if err := r.db.Model(&models.Interaction{}).
+		Select("interactions.contact_id, MAX(interactions.date) AS last_date").
+		Joins("JOIN contacts ON interactions.contact_id = contacts.id").
+		Where("contacts.user_id = ? AND interactions.date <= ?", userID, until).
+		Group("interactions.contact_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	dates := make(map[uint]time.Time, len(rows))
+	for _, row := range rows {
+		dates[row.ContactID] = row.LastDate.UTC()
+	}
+
+	return dates, nil
+}
+
+// Search busca interações do usuário por assunto ou descrição (busca parcial)
+func (r *interactionRepository) Search(userID uint, term string, limit int) ([]models.Interaction, error) {
+	var interactions []models.Interaction
+	searchTerm := "%" + term + "%"
 
 	query := r.db.Joins("JOIN contacts ON interactions.contact_id = contacts.id").
-		Where("contacts.user_id = ? AND interactions.date >= ?", userID, startDate).
+		Where("contacts.user_id = ? AND (interactions.subject ILIKE ? OR interactions.description ILIKE ?)",
+			userID, searchTerm, searchTerm).
 		Order("interactions.date DESC").
 		Preload("Contact")
 
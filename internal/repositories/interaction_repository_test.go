@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInteractionListAndCountShareFilters(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	filter := &models.InteractionListFilter{
+		Type:      models.InteractionType("CALL"),
+		Outcome:   models.InteractionOutcome("POSITIVE"),
+		DateFrom:  &from,
+		DateTo:    &to,
+		ContactID: 3,
+		Search:    " proposta ",
+		Limit:     10,
+		Offset:    20,
+	}
+
+	tests := []struct {
+		name  string
+		list  func(r InteractionRepository) error
+		count func(r InteractionRepository) error
+	}{
+		{
+			name: "listagem global",
+			list: func(r InteractionRepository) error {
+				_, err := r.GetByUserID(7, filter)
+				return err
+			},
+			count: func(r InteractionRepository) error {
+				_, err := r.CountByUserIDWithFilter(7, filter)
+				return err
+			},
+		},
+		{
+			name: "listagem do contato",
+			list: func(r InteractionRepository) error {
+				_, err := r.GetByContactID(3, filter)
+				return err
+			},
+			count: func(r InteractionRepository) error {
+				_, err := r.CountByContactIDWithFilter(3, filter)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, captured := newDryRunDB(t)
+			repo := NewInteractionRepository(db)
+
+			if err := tt.list(repo); err != nil {
+				t.Fatalf("listagem: %v", err)
+			}
+			if err := tt.count(repo); err != nil {
+				t.Fatalf("contagem: %v", err)
+			}
+			if len(*captured) != 2 {
+				t.Fatalf("instruções = %d, want 2", len(*captured))
+			}
+
+			list, count := (*captured)[0], (*captured)[1]
+			listWhere, countWhere := whereClause(list.SQL), whereClause(count.SQL)
+			if listWhere == "" || listWhere != countWhere {
+				t.Fatalf("filtros diferentes:\nlistagem: %s\ncontagem: %s", listWhere, countWhere)
+			}
+			// A listagem acrescenta apenas os parâmetros de paginação
+			if listVars := list.Vars[:len(count.Vars)]; !reflect.DeepEqual(listVars, count.Vars) {
+				t.Fatalf("parâmetros diferentes:\nlistagem: %v\ncontagem: %v", list.Vars, count.Vars)
+			}
+
+			for _, column := range []string{"interactions.type", "interactions.outcome", "interactions.date >=", "interactions.date <=", "interactions.contact_id"} {
+				if !strings.Contains(countWhere, column) {
+					t.Errorf("filtro %q ausente em %s", column, countWhere)
+				}
+			}
+			// A busca usa OR e precisa estar entre parênteses para não anular os demais filtros
+			if !strings.Contains(countWhere, "(interactions.subject ILIKE $") {
+				t.Errorf("busca sem parênteses em %s", countWhere)
+			}
+			if !containsVar(count.Vars, "%proposta%") {
+				t.Errorf("termo de busca %q ausente em %v", "%proposta%", count.Vars)
+			}
+		})
+	}
+}
+
+// containsVar verifica se o valor está entre os parâmetros da instrução
+func containsVar(vars []interface{}, want interface{}) bool {
+	for _, v := range vars {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestZeroFilledCountsByType(t *testing.T) {
+	tests := []struct {
+		name    string
+		counted map[models.InteractionType]int64
+		want    map[models.InteractionType]int64
+	}{
+		{
+			name:    "sem interações",
+			counted: map[models.InteractionType]int64{},
+			want:    map[models.InteractionType]int64{},
+		},
+		{
+			name: "tipos misturados",
+			counted: map[models.InteractionType]int64{
+				models.InteractionTypeEmail:   3,
+				models.InteractionTypeCall:    1,
+				models.InteractionTypeMeeting: 2,
+			},
+			want: map[models.InteractionType]int64{
+				models.InteractionTypeEmail:   3,
+				models.InteractionTypeCall:    1,
+				models.InteractionTypeMeeting: 2,
+			},
+		},
+		{
+			name:    "apenas um tipo",
+			counted: map[models.InteractionType]int64{models.InteractionTypeCall: 4},
+			want:    map[models.InteractionType]int64{models.InteractionTypeCall: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := zeroFilledCounts(models.InteractionTypes, tt.counted)
+			if len(got) != len(models.InteractionTypes) {
+				t.Fatalf("tipos = %d, want %d", len(got), len(models.InteractionTypes))
+			}
+			for _, interactionType := range models.InteractionTypes {
+				count, ok := got[interactionType]
+				if !ok {
+					t.Errorf("tipo %s ausente do resultado", interactionType)
+					continue
+				}
+				if count != tt.want[interactionType] {
+					t.Errorf("counts[%s] = %d, want %d", interactionType, count, tt.want[interactionType])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationRepository define a interface para operações de notificação no banco de dados
+type NotificationRepository interface {
+	Create(notification *models.Notification) error
+	GetByID(id uint) (*models.Notification, error)
+	GetByUserID(userID uint, filter *models.NotificationListFilter) ([]models.Notification, error)
+	MarkAsRead(id uint) error
+	ExistsForTask(taskID uint, notificationType models.NotificationType, dueDate, since time.Time) (bool, error)
+}
+
+// notificationRepository implementa NotificationRepository
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository cria uma nova instância do repositório de notificações
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Create cria uma nova notificação no banco de dados
+func (r *notificationRepository) Create(notification *models.Notification) error {
+	if err := r.db.Create(notification).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByID busca uma notificação pelo ID
+func (r *notificationRepository) GetByID(id uint) (*models.Notification, error) {
+	var notification models.Notification
+	if err := r.db.First(&notification, id).Error; err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// GetByUserID busca as notificações de um usuário, das mais recentes às mais antigas
+func (r *notificationRepository) GetByUserID(userID uint, filter *models.NotificationListFilter) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := r.db.Where("user_id = ?", userID)
+
+	if filter != nil {
+		if filter.Unread {
+			query = query.Where("read = ?", false)
+		}
+
+		// Paginação
+		if filter.Limit > 0 {
+			query = query.Limit(filter.Limit)
+		}
+		if filter.Offset > 0 {
+			query = query.Offset(filter.Offset)
+		}
+	}
+
+	if err := query.Order("created_at DESC").Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// MarkAsRead marca uma notificação como lida
+func (r *notificationRepository) MarkAsRead(id uint) error {
+	if err := r.db.Model(&models.Notification{}).Where("id = ?", id).Update("read", true).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ExistsForTask verifica se já existe uma notificação do tipo informado, lida ou não, para o vencimento
+// dueDate da tarefa, criada a partir de since
+func (r *notificationRepository) ExistsForTask(taskID uint, notificationType models.NotificationType, dueDate, since time.Time) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Notification{}).
+		Where("task_id = ? AND type = ? AND due_date = ? AND created_at >= ?", taskID, notificationType, dueDate, since).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNotificationExistsForTaskQuery(t *testing.T) {
+	db, captured := newDryRunDB(t)
+	due := time.Date(2024, 3, 15, 18, 0, 0, 0, time.UTC)
+	since := time.Date(2024, 3, 14, 9, 0, 0, 0, time.UTC)
+
+	if _, err := NewNotificationRepository(db).ExistsForTask(3, models.NotificationTypeTaskDueSoon, due, since); err != nil {
+		t.Fatalf("ExistsForTask: %v", err)
+	}
+
+	// O vencimento faz parte da chave e o estado de leitura não: lidas também evitam repetição
+	query := lastQuery(t, captured)
+	if want := ` WHERE task_id = $1 AND type = $2 AND due_date = $3 AND created_at >= $4`; whereClause(query.SQL) != want {
+		t.Errorf("WHERE = %q\nwant    %q", whereClause(query.SQL), want)
+	}
+	if got, want := fmt.Sprint(query.Vars), fmt.Sprint([]interface{}{uint(3), models.NotificationTypeTaskDueSoon, due, since}); got != want {
+		t.Errorf("vars = %s, want %s", got, want)
+	}
+}
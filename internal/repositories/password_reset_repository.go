@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PasswordResetRepository define a interface para operações de redefinição de senha no banco de dados
+type PasswordResetRepository interface {
+	Create(reset *models.PasswordReset) error
+	GetByTokenHash(tokenHash string) (*models.PasswordReset, error)
+	Consume(reset *models.PasswordReset, passwordHash string) error
+}
+
+// passwordResetRepository implementa PasswordResetRepository
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository cria uma nova instância do repositório de redefinição de senha
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+// Create cria um novo token de redefinição de senha no banco de dados
+func (r *passwordResetRepository) Create(reset *models.PasswordReset) error {
+	if err := r.db.Create(reset).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByTokenHash busca um token de redefinição pelo hash
+func (r *passwordResetRepository) GetByTokenHash(tokenHash string) (*models.PasswordReset, error) {
+	var reset models.PasswordReset
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&reset).Error; err != nil {
+		return nil, err
+	}
+	return &reset, nil
+}
+
+// Consume marca o token como utilizado e atualiza a senha do usuário na mesma transação.
+// Os demais tokens pendentes do usuário também são invalidados.
+func (r *passwordResetRepository) Consume(reset *models.PasswordReset, passwordHash string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Marcar como utilizado apenas se ainda não foi, evitando reutilização concorrente
+		result := tx.Model(&models.PasswordReset{}).
+			Where("id = ? AND used_at IS NULL", reset.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Model(&models.User{}).
+			Where("id = ?", reset.UserID).
+			Update("password", passwordHash).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.PasswordReset{}).
+			Where("user_id = ? AND used_at IS NULL", reset.UserID).
+			Update("used_at", now).Error
+	})
+}
@@ -8,15 +8,29 @@
 
 // ProjectRepository define a interface para operações de projeto no banco de dados
 type ProjectRepository interface {
+	WithTx(tx *gorm.DB) ProjectRepository
 	Create(project *models.Project) error
 	GetByID(id uint) (*models.Project, error)
 	GetByUserID(userID uint, filter *models.ProjectListFilter) ([]models.Project, error)
+	CountByUserIDWithFilter(userID uint, filter *models.ProjectListFilter) (int64, error)
 	Update(project *models.Project) error
 	Delete(id uint) error
+	GetDeletedByID(id uint) (*models.Project, error)
+	Restore(id uint) error
 	GetByClientID(clientID uint) ([]models.Project, error)
+	CountByClientID(clientID uint) (int64, error)
+	CountAllByClientID(clientID uint) (int64, error)
+	GetSharedWithUser(userID uint) ([]models.Project, error)
+	GetBoardByUserID(userID uint) ([]models.Project, error)
 	CountByUserID(userID uint) (int64, error)
 	CountByStatus(userID uint, status models.ProjectStatus) (int64, error)
+	CountByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error)
+	CountByStatusInRange(userID uint, status models.ProjectStatus, dateRange *models.DateRange) (int64, error)
+	CountUpdatedInRange(userID uint, dateRange *models.DateRange) (int64, error)
+	StreamByUserID(userID uint, batchSize int, fn func(batch []models.Project) error) error
+	GetUpdatedInRange(userID uint, dateRange *models.DateRange, limit int) ([]models.Project, error)
 	GetWithTasks(id uint) (*models.Project, error)
+	Search(userID uint, term string, limit int) ([]models.Project, error)
 }
 
 // projectRepository implementa ProjectRepository
@@ -29,6 +43,11 @@ func NewProjectRepository(db *gorm.DB) ProjectRepository {
 	return &projectRepository{db: db}
 }
 
+// WithTx retorna uma cópia do repositório que executa as operações na transação informada
+func (r *projectRepository) WithTx(tx *gorm.DB) ProjectRepository {
+	return &projectRepository{db: tx}
+}
+
 // Create cria um novo projeto no banco de dados
 func (r *projectRepository) Create(project *models.Project) error {
 	if err := r.db.Create(project).Error; err != nil {
@@ -46,21 +65,29 @@ func (r *projectRepository) GetByID(id uint) (*models.Project, error) {
 	return &project, nil
 }
 
+// applyFilters aplica os filtros de listagem de projetos à consulta (sem paginação)
+func (r *projectRepository) applyFilters(query *gorm.DB, filter *models.ProjectListFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ClientID != nil {
+		query = query.Where("client_id = ?", *filter.ClientID)
+	}
+
+	return applyChangedSince(query, filter.ChangedSince)
+}
+
 // GetByUserID busca projetos por ID do usuário com filtros
 func (r *projectRepository) GetByUserID(userID uint, filter *models.ProjectListFilter) ([]models.Project, error) {
 	var projects []models.Project
-	query := r.db.Where("user_id = ?", userID)
+	query := r.applyFilters(r.db.Where("user_id = ?", userID), filter)
 
-	// Aplicar filtros
+	// Paginação
 	if filter != nil {
-		if filter.Status != "" {
-			query = query.Where("status = ?", filter.Status)
-		}
-		if filter.ClientID != nil {
-			query = query.Where("client_id = ?", *filter.ClientID)
-		}
-
-		// Paginação
 		if filter.Limit > 0 {
 			query = query.Limit(filter.Limit)
 		}
@@ -69,8 +96,12 @@ func (r *projectRepository) GetByUserID(userID uint, filter *models.ProjectListF
 		}
 	}
 
-	// Ordenar por data de criação (mais recente primeiro)
-	query = query.Order("created_at DESC")
+	// Ordenar por data de criação, mais recente primeiro (sincronização: pela data de alteração)
+	if filter != nil && filter.ChangedSince != nil {
+		query = query.Order(syncOrder)
+	} else {
+		query = query.Order("created_at DESC")
+	}
 
 	if err := query.Preload("Client").Preload("User").Find(&projects).Error; err != nil {
 		return nil, err
@@ -79,6 +110,17 @@ func (r *projectRepository) GetByUserID(userID uint, filter *models.ProjectListF
 	return projects, nil
 }
 
+// CountByUserIDWithFilter conta os projetos do usuário que atendem aos filtros (ignorando paginação)
+func (r *projectRepository) CountByUserIDWithFilter(userID uint, filter *models.ProjectListFilter) (int64, error) {
+	var count int64
+	query := r.applyFilters(r.db.Model(&models.Project{}).Where("user_id = ?", userID), filter)
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // GetByClientID busca projetos por ID do cliente
 func (r *projectRepository) GetByClientID(clientID uint) ([]models.Project, error) {
 	var projects []models.Project
@@ -92,12 +134,56 @@ func (r *projectRepository) GetByClientID(clientID uint) ([]models.Project, erro
 	return projects, nil
 }
 
-// Update atualiza um projeto existente
-func (r *projectRepository) Update(project *models.Project) error {
-	if err := r.db.Save(project).Error; err != nil {
-		return err
+// GetBoardByUserID busca todos os projetos do usuário ordenados por status e atualização mais recente
+func (r *projectRepository) GetBoardByUserID(userID uint) ([]models.Project, error) {
+	var projects []models.Project
+	if err := r.db.Where("user_id = ?", userID).
+		Preload("Client").
+		Order("status ASC").
+		Order("updated_at DESC").
+		Find(&projects).Error; err != nil {
+		return nil, err
 	}
-	return nil
+	return projects, nil
+}
+
+// CountByClientID conta os projetos (não excluídos) de um cliente
+func (r *projectRepository) CountByClientID(clientID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Project{}).Where("client_id = ?", clientID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountAllByClientID conta os projetos de um cliente, incluindo os excluídos (soft delete)
+func (r *projectRepository) CountAllByClientID(clientID uint) (int64, error) {
+	var count int64
+	if err := r.db.Unscoped().Model(&models.Project{}).Where("client_id = ?", clientID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetSharedWithUser busca os projetos de outros usuários compartilhados com o usuário
+func (r *projectRepository) GetSharedWithUser(userID uint) ([]models.Project, error) {
+	var projects []models.Project
+	if err := r.db.
+		Joins("JOIN shared_resources ON shared_resources.resource_id = projects.id AND shared_resources.resource_type = ?", models.SharedResourceProject).
+		Where("shared_resources.shared_with_id = ?", userID).
+		Preload("Client").
+		Preload("User").
+		Order("projects.created_at DESC").
+		Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// Update atualiza um projeto existente com controle de concorrência otimista.
+// Retorna ErrVersionConflict se a versão em project.Version não for mais a atual.
+func (r *projectRepository) Update(project *models.Project) error {
+	return updateVersioned(r.db, project, &project.Version)
 }
 
 // Delete remove um projeto do banco de dados (soft delete)
@@ -108,26 +194,92 @@ func (r *projectRepository) Delete(id uint) error {
 	return nil
 }
 
+// GetDeletedByID busca um projeto excluído (soft delete) pelo ID
+func (r *projectRepository) GetDeletedByID(id uint) (*models.Project, error) {
+	var project models.Project
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&project, id).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// Restore restaura um projeto excluído (soft delete)
+func (r *projectRepository) Restore(id uint) error {
+	if err := r.db.Unscoped().Model(&models.Project{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 // CountByUserID conta o número total de projetos de um usuário
 func (r *projectRepository) CountByUserID(userID uint) (int64, error) {
+	return r.CountByUserIDInRange(userID, nil)
+}
+
+// CountByStatus conta o número de projetos por status de um usuário
+func (r *projectRepository) CountByStatus(userID uint, status models.ProjectStatus) (int64, error) {
+	return r.CountByStatusInRange(userID, status, nil)
+}
+
+// CountByUserIDInRange conta os projetos do usuário criados no intervalo informado
+func (r *projectRepository) CountByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error) {
 	var count int64
-	if err := r.db.Model(&models.Project{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+	query := applyDateRange(r.db.Model(&models.Project{}).Where("user_id = ?", userID), "created_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
-// CountByStatus conta o número de projetos por status de um usuário
-func (r *projectRepository) CountByStatus(userID uint, status models.ProjectStatus) (int64, error) {
+// CountByStatusInRange conta os projetos do usuário, com o status atual informado, criados no intervalo
+func (r *projectRepository) CountByStatusInRange(userID uint, status models.ProjectStatus, dateRange *models.DateRange) (int64, error) {
+	var count int64
+	query := applyDateRange(r.db.Model(&models.Project{}).
+		Where("user_id = ? AND status = ?", userID, status), "created_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// StreamByUserID percorre todos os projetos do usuário em lotes (ordenados por ID)
+func (r *projectRepository) StreamByUserID(userID uint, batchSize int, fn func(batch []models.Project) error) error {
+	var batch []models.Project
+	result := r.db.Where("user_id = ?", userID).FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	})
+	return result.Error
+}
+
+// CountUpdatedInRange conta os projetos do usuário alterados (ou criados) no intervalo informado
+func (r *projectRepository) CountUpdatedInRange(userID uint, dateRange *models.DateRange) (int64, error) {
 	var count int64
-	if err := r.db.Model(&models.Project{}).
-		Where("user_id = ? AND status = ?", userID, status).
-		Count(&count).Error; err != nil {
+	query := applyDateRange(r.db.Model(&models.Project{}).Where("user_id = ?", userID), "updated_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
+// GetUpdatedInRange busca os projetos do usuário alterados (ou criados) no intervalo informado, dos mais recentes aos mais antigos
+func (r *projectRepository) GetUpdatedInRange(userID uint, dateRange *models.DateRange, limit int) ([]models.Project, error) {
+	var projects []models.Project
+	query := applyDateRange(r.db.Where("user_id = ?", userID), "updated_at", dateRange).
+		Preload("Client").
+		Order("updated_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
 // GetWithTasks obtém um projeto com suas tarefas associadas
 func (r *projectRepository) GetWithTasks(id uint) (*models.Project, error) {
 	var project models.Project
@@ -136,3 +288,23 @@ func (r *projectRepository) GetWithTasks(id uint) (*models.Project, error) {
 	}
 	return &project, nil
 }
+
+// Search busca projetos do usuário por nome ou descrição (busca parcial)
+func (r *projectRepository) Search(userID uint, term string, limit int) ([]models.Project, error) {
+	var projects []models.Project
+	searchTerm := "%" + term + "%"
+
+	query := r.db.Where("user_id = ? AND (name ILIKE ? OR description ILIKE ?)", userID, searchTerm, searchTerm).
+		Order("updated_at DESC").
+		Preload("Client")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
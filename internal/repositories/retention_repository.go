@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RetentionRepository define as operações de remoção definitiva de registros excluídos (soft delete)
+// há mais tempo que o período de retenção. Os métodos devem ser chamados na ordem declarada,
+// dos registros dependentes para os registros dos quais eles dependem.
+type RetentionRepository interface {
+	PurgeAttachments(cutoff time.Time) (int64, []string, error)
+	PurgeInteractions(cutoff time.Time) (int64, error)
+	PurgeTasks(cutoff time.Time) (int64, error)
+	PurgeProjects(cutoff time.Time) (int64, error)
+	PurgeContacts(cutoff time.Time) (int64, error)
+}
+
+// retentionRepository implementa RetentionRepository
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionRepository cria uma nova instância do repositório de retenção
+func NewRetentionRepository(db *gorm.DB) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+// PurgeAttachments remove definitivamente os anexos excluídos antes de cutoff e os anexos das
+// interações que serão removidas. Retorna os caminhos dos arquivos para que sejam apagados do disco.
+func (r *retentionRepository) PurgeAttachments(cutoff time.Time) (int64, []string, error) {
+	var purged int64
+	var paths []string
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expiredInteractions := tx.Unscoped().Model(&models.Interaction{}).Select("id").Where("deleted_at < ?", cutoff)
+		expired := func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Where("deleted_at < ? OR interaction_id IN (?)", cutoff, expiredInteractions)
+		}
+
+		if err := tx.Scopes(expired).Model(&models.Attachment{}).Pluck("storage_path", &paths).Error; err != nil {
+			return err
+		}
+
+		result := tx.Scopes(expired).Delete(&models.Attachment{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return purged, paths, nil
+}
+
+// PurgeInteractions remove definitivamente as interações excluídas antes de cutoff
+func (r *retentionRepository) PurgeInteractions(cutoff time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("deleted_at < ?", cutoff).Delete(&models.Interaction{})
+	return result.RowsAffected, result.Error
+}
+
+// PurgeTasks remove definitivamente as tarefas excluídas antes de cutoff, junto com seus comentários
+// e notificações. As interações que as tinham como follow-up perdem a referência.
+func (r *retentionRepository) PurgeTasks(cutoff time.Time) (int64, error) {
+	var purged int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expiredTasks := tx.Unscoped().Model(&models.Task{}).Select("id").Where("deleted_at < ?", cutoff)
+		if err := tx.Where("task_id IN (?)", expiredTasks).Delete(&models.TaskComment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id IN (?)", expiredTasks).Delete(&models.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.Interaction{}).
+			Where("follow_up_task_id IN (?)", expiredTasks).
+			UpdateColumn("follow_up_task_id", nil).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at < ?", cutoff).Delete(&models.Task{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// PurgeProjects remove definitivamente os projetos excluídos antes de cutoff.
+// Projetos ainda referenciados por tarefas (excluídas ou não) são mantidos.
+func (r *retentionRepository) PurgeProjects(cutoff time.Time) (int64, error) {
+	referenced := r.db.Unscoped().Model(&models.Task{}).Select("1").Where("tasks.project_id = projects.id")
+
+	result := r.db.Unscoped().
+		Where("deleted_at < ?", cutoff).
+		Where("NOT EXISTS (?)", referenced).
+		Delete(&models.Project{})
+	return result.RowsAffected, result.Error
+}
+
+// PurgeContacts remove definitivamente os contatos excluídos antes de cutoff, junto com suas
+// associações com etiquetas e datas importantes. Contatos ainda referenciados por interações,
+// tarefas ou projetos (excluídos ou não) são mantidos.
+func (r *retentionRepository) PurgeContacts(cutoff time.Time) (int64, error) {
+	var purged int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		err := tx.Unscoped().Model(&models.Contact{}).
+			Where("deleted_at < ?", cutoff).
+			Where("NOT EXISTS (?)", tx.Unscoped().Model(&models.Interaction{}).Select("1").Where("interactions.contact_id = contacts.id")).
+			Where("NOT EXISTS (?)", tx.Unscoped().Model(&models.Task{}).Select("1").Where("tasks.contact_id = contacts.id")).
+			Where("NOT EXISTS (?)", tx.Unscoped().Model(&models.Project{}).Select("1").Where("projects.client_id = contacts.id")).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Exec("DELETE FROM contact_tags WHERE contact_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("contact_id IN ?", ids).Delete(&models.ImportantDate{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&models.Contact{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
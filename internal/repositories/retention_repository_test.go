@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetentionPurgeTasksRemovesDependents(t *testing.T) {
+	db, captured := newDryRunDB(t)
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := NewRetentionRepository(db).PurgeTasks(cutoff); err != nil {
+		t.Fatalf("PurgeTasks: %v", err)
+	}
+
+	// Comentários, notificações e follow-ups das tarefas expiradas são tratados antes da remoção,
+	// na mesma transação
+	expired := `IN (SELECT "id" FROM "tasks" WHERE deleted_at < $1)`
+	want := []string{
+		`DELETE FROM "task_comments" WHERE task_id ` + expired,
+		`DELETE FROM "notifications" WHERE task_id ` + expired,
+		`UPDATE "interactions" SET "follow_up_task_id"=$1 WHERE follow_up_task_id IN (SELECT "id" FROM "tasks" WHERE deleted_at < $2)`,
+		`DELETE FROM "tasks" WHERE deleted_at < $1`,
+	}
+	got := writes(captured)
+	if len(got) != len(want) {
+		t.Fatalf("%d instruções, want %d: %q", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("instrução %d = %q\nwant %q", i, got[i], want[i])
+		}
+	}
+}
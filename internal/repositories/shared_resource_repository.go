@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SharedResourceRepository define a interface para operações de compartilhamento no banco de dados
+type SharedResourceRepository interface {
+	Create(share *models.SharedResource) error
+	GetByID(id uint) (*models.SharedResource, error)
+	GetByResource(resourceType models.SharedResourceType, resourceID uint) ([]models.SharedResource, error)
+	HasPermission(resourceType models.SharedResourceType, resourceID, userID uint, permission models.SharePermission) (bool, error)
+	Exists(resourceType models.SharedResourceType, resourceID, userID uint) (bool, error)
+	Delete(id uint) error
+}
+
+// sharedResourceRepository implementa SharedResourceRepository
+type sharedResourceRepository struct {
+	db *gorm.DB
+}
+
+// NewSharedResourceRepository cria uma nova instância do repositório de compartilhamentos
+func NewSharedResourceRepository(db *gorm.DB) SharedResourceRepository {
+	return &sharedResourceRepository{db: db}
+}
+
+// Create cria um novo compartilhamento no banco de dados
+func (r *sharedResourceRepository) Create(share *models.SharedResource) error {
+	if err := r.db.Create(share).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByID busca um compartilhamento pelo ID
+func (r *sharedResourceRepository) GetByID(id uint) (*models.SharedResource, error) {
+	var share models.SharedResource
+	if err := r.db.Preload("SharedWith").First(&share, id).Error; err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// GetByResource busca os compartilhamentos de um recurso
+func (r *sharedResourceRepository) GetByResource(resourceType models.SharedResourceType, resourceID uint) ([]models.SharedResource, error) {
+	var shares []models.SharedResource
+	if err := r.db.Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Preload("SharedWith").
+		Order("created_at ASC").
+		Find(&shares).Error; err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// HasPermission verifica se o recurso foi compartilhado com o usuário com a permissão informada
+func (r *sharedResourceRepository) HasPermission(resourceType models.SharedResourceType, resourceID, userID uint, permission models.SharePermission) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.SharedResource{}).
+		Where("resource_type = ? AND resource_id = ? AND shared_with_id = ? AND permission = ?",
+			resourceType, resourceID, userID, permission).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Exists verifica se o recurso já foi compartilhado com o usuário (com qualquer permissão)
+func (r *sharedResourceRepository) Exists(resourceType models.SharedResourceType, resourceID, userID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.SharedResource{}).
+		Where("resource_type = ? AND resource_id = ? AND shared_with_id = ?", resourceType, resourceID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Delete remove um compartilhamento
+func (r *sharedResourceRepository) Delete(id uint) error {
+	if err := r.db.Delete(&models.SharedResource{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
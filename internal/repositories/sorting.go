@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Colunas permitidas para ordenação de cada listagem
+var (
+	ContactSortColumns = []string{"name", "email", "company", "created_at", "updated_at"}
+)
+
+// OrderClause valida os parâmetros de ordenação contra a lista de colunas permitidas
+// e retorna a cláusula ORDER BY correspondente. Quando sort não é informado, retorna defaultClause.
+func OrderClause(sort, order string, allowed []string, defaultClause string) (string, error) {
+	if sort == "" {
+		if order != "" {
+			return "", fmt.Errorf("parâmetro order requer o parâmetro sort")
+		}
+		return defaultClause, nil
+	}
+
+	column := ""
+	for _, candidate := range allowed {
+		if strings.EqualFold(sort, candidate) {
+			column = candidate
+			break
+		}
+	}
+	if column == "" {
+		return "", fmt.Errorf("campo de ordenação inválido: %s. Use: %s", sort, strings.Join(allowed, ", "))
+	}
+
+	direction := "ASC"
+	switch strings.ToLower(order) {
+	case "", "asc":
+	case "desc":
+		direction = "DESC"
+	default:
+		return "", fmt.Errorf("direção de ordenação inválida: %s. Use: asc ou desc", order)
+	}
+
+	return column + " " + direction, nil
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// syncOrder é a ordenação das listagens de sincronização: estável entre páginas e das alterações
+// mais antigas para as mais recentes
+const syncOrder = "updated_at ASC, id ASC"
+
+// applyChangedSince restringe a consulta aos registros alterados ou excluídos a partir de since,
+// incluindo os excluídos via soft delete (que não atualizam updated_at). Sem since, não altera a consulta.
+func applyChangedSince(query *gorm.DB, since *time.Time) *gorm.DB {
+	if since == nil {
+		return query
+	}
+	return query.Unscoped().Where("updated_at >= ? OR deleted_at >= ?", *since, *since)
+}
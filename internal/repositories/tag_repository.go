@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository define a interface para operações de etiquetas no banco de dados
+type TagRepository interface {
+	WithTx(tx *gorm.DB) TagRepository
+	FindOrCreateByNames(userID uint, names []string) ([]models.Tag, error)
+	GetByName(userID uint, name string) (*models.Tag, error)
+	AddToContact(contactID uint, tags []models.Tag) error
+	RemoveFromContact(contactID uint, tag *models.Tag) error
+	GetByContactID(contactID uint) ([]models.Tag, error)
+}
+
+// tagRepository implementa TagRepository
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository cria uma nova instância do repositório de etiquetas
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// WithTx retorna uma cópia do repositório que executa as operações na transação informada
+func (r *tagRepository) WithTx(tx *gorm.DB) TagRepository {
+	return &tagRepository{db: tx}
+}
+
+// FindOrCreateByNames retorna as etiquetas do usuário com os nomes informados (já normalizados),
+// criando as que ainda não existem
+func (r *tagRepository) FindOrCreateByNames(userID uint, names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	newTags := make([]models.Tag, len(names))
+	for i, name := range names {
+		newTags[i] = models.Tag{Name: name, UserID: userID}
+	}
+
+	var tags []models.Tag
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&newTags).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND name IN ?", userID, names).Order("name ASC").Find(&tags).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetByName busca uma etiqueta do usuário pelo nome (já normalizado)
+func (r *tagRepository) GetByName(userID uint, name string) (*models.Tag, error) {
+	var tag models.Tag
+	if err := r.db.Where("user_id = ? AND name = ?", userID, name).First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// AddToContact associa as etiquetas ao contato (associações já existentes são mantidas)
+func (r *tagRepository) AddToContact(contactID uint, tags []models.Tag) error {
+	return r.db.Model(&models.Contact{ID: contactID}).Association("Tags").Append(tags)
+}
+
+// RemoveFromContact remove a associação da etiqueta com o contato (a etiqueta em si é mantida)
+func (r *tagRepository) RemoveFromContact(contactID uint, tag *models.Tag) error {
+	return r.db.Model(&models.Contact{ID: contactID}).Association("Tags").Delete(tag)
+}
+
+// GetByContactID busca as etiquetas associadas ao contato, em ordem alfabética
+func (r *tagRepository) GetByContactID(contactID uint) ([]models.Tag, error) {
+	var tags []models.Tag
+	if err := r.db.Model(&models.Contact{ID: contactID}).Order("tags.name ASC").Association("Tags").Find(&tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// TaskCommentRepository define a interface para operações de comentários de tarefas no banco de dados
+type TaskCommentRepository interface {
+	Create(comment *models.TaskComment) error
+	GetByID(id uint) (*models.TaskComment, error)
+	GetByTaskID(taskID uint) ([]models.TaskComment, error)
+}
+
+// taskCommentRepository implementa TaskCommentRepository
+type taskCommentRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskCommentRepository cria uma nova instância do repositório de comentários de tarefas
+func NewTaskCommentRepository(db *gorm.DB) TaskCommentRepository {
+	return &taskCommentRepository{db: db}
+}
+
+// Create cria um novo comentário no banco de dados
+func (r *taskCommentRepository) Create(comment *models.TaskComment) error {
+	if err := r.db.Create(comment).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByID busca um comentário pelo ID, com o autor
+func (r *taskCommentRepository) GetByID(id uint) (*models.TaskComment, error) {
+	var comment models.TaskComment
+	if err := r.db.Preload("User").First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetByTaskID busca os comentários de uma tarefa, com os autores, dos mais recentes aos mais antigos
+func (r *taskCommentRepository) GetByTaskID(taskID uint) ([]models.TaskComment, error) {
+	var comments []models.TaskComment
+	if err := r.db.Where("task_id = ?", taskID).
+		Preload("User").
+		Order("created_at DESC, id DESC").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
@@ -5,21 +5,45 @@
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TaskRepository define a interface para operações de tarefa no banco de dados
 type TaskRepository interface {
+	WithTx(tx *gorm.DB) TaskRepository
 	Create(task *models.Task) error
+	CreateBatch(tasks []models.Task) error
 	GetByID(id uint) (*models.Task, error)
 	GetByUserID(userID uint, filter *models.TaskListFilter) ([]models.Task, error)
+	CountByUserIDWithFilter(userID uint, filter *models.TaskListFilter) (int64, error)
 	Update(task *models.Task) error
 	Delete(id uint) error
+	DeleteCompletedByUserID(userID uint, before *time.Time) ([]models.Task, error)
+	GetDeletedByID(id uint) (*models.Task, error)
+	Restore(id uint) error
 	GetByContactID(contactID uint) ([]models.Task, error)
+	GetAllByContactID(contactID uint) ([]models.Task, error)
+	StreamByUserID(userID uint, batchSize int, fn func(batch []models.Task) error) error
 	GetByProjectID(projectID uint) ([]models.Task, error)
+	ReassignContact(fromContactID, toContactID uint) (int64, error)
 	CountByUserID(userID uint) (int64, error)
 	CountPendingByUserID(userID uint) (int64, error)
 	CountOverdueByUserID(userID uint) (int64, error)
+	CountGroupedByStatusAndPriority(userID uint) (map[models.TaskStatus]int64, map[models.Priority]int64, error)
+	CountByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error)
+	CountPendingByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error)
+	CountByStatusInRange(userID uint, status models.TaskStatus, dateRange *models.DateRange) (int64, error)
+	CountOverdueByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error)
+	CountCompletedInRange(userID uint, dateRange *models.DateRange) (int64, error)
+	GetCompletedInRange(userID uint, dateRange *models.DateRange, limit int) ([]models.Task, error)
 	GetOverdueTasks(userID uint) ([]models.Task, error)
+	GetOverdueByContactID(contactID uint) ([]models.Task, error)
+	GetOverdueByProjectID(projectID uint) ([]models.Task, error)
+	GetUpcomingTasks(userID uint, from, to time.Time) ([]models.Task, error)
+	GetDueInRange(userID uint, from, to time.Time) ([]models.Task, error)
+	GetPendingDueBetween(from, to time.Time) ([]models.Task, error)
+	CountOpenDueByContact(userID uint, until time.Time) (map[uint]int64, error)
+	Search(userID uint, term string, limit int) ([]models.Task, error)
 }
 
 // taskRepository implementa TaskRepository
@@ -32,6 +56,11 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 	return &taskRepository{db: db}
 }
 
+// WithTx retorna uma cópia do repositório que executa as operações na transação informada
+func (r *taskRepository) WithTx(tx *gorm.DB) TaskRepository {
+	return &taskRepository{db: tx}
+}
+
 // Create cria uma nova tarefa no banco de dados
 func (r *taskRepository) Create(task *models.Task) error {
 	if err := r.db.Create(task).Error; err != nil {
@@ -40,6 +69,14 @@ func (r *taskRepository) Create(task *models.Task) error {
 	return nil
 }
 
+// CreateBatch cria várias tarefas em uma única inserção, preenchendo os IDs gerados
+func (r *taskRepository) CreateBatch(tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	return r.db.Omit(clause.Associations).Create(&tasks).Error
+}
+
 // GetByID busca uma tarefa pelo ID
 func (r *taskRepository) GetByID(id uint) (*models.Task, error) {
 	var task models.Task
@@ -49,33 +86,58 @@ func (r *taskRepository) GetByID(id uint) (*models.Task, error) {
 	return &task, nil
 }
 
+// applyFilters aplica os filtros de listagem de tarefas à consulta (sem paginação)
+func (r *taskRepository) applyFilters(query *gorm.DB, filter *models.TaskListFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+
+	if statuses := filter.Statuses(); len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if priorities := filter.Priorities(); len(priorities) > 0 {
+		query = query.Where("priority IN ?", priorities)
+	}
+	if filter.ContactID != nil {
+		query = query.Where("contact_id = ?", *filter.ContactID)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("project_id = ?", *filter.ProjectID)
+	}
+	if filter.DueBefore != nil {
+		query = query.Where("due_date <= ?", filter.DueBefore)
+	}
+	if filter.DueAfter != nil {
+		query = query.Where("due_date >= ?", filter.DueAfter)
+	}
+	if filter.HasDueDate != nil {
+		if *filter.HasDueDate {
+			query = query.Where("due_date IS NOT NULL")
+		} else {
+			query = query.Where("due_date IS NULL")
+		}
+	}
+
+	// A sincronização precisa de todas as alterações, inclusive das tarefas adiadas
+	if !filter.IncludeSnoozed && filter.ChangedSince == nil {
+		query = query.Scopes(notSnoozed)
+	}
+
+	return applyChangedSince(query, filter.ChangedSince)
+}
+
+// notSnoozed exclui as tarefas adiadas cujo adiamento ainda não terminou
+func notSnoozed(db *gorm.DB) *gorm.DB {
+	return db.Where("snoozed_until IS NULL OR snoozed_until <= ?", time.Now())
+}
+
 // GetByUserID busca tarefas por ID do usuário com filtros
 func (r *taskRepository) GetByUserID(userID uint, filter *models.TaskListFilter) ([]models.Task, error) {
 	var tasks []models.Task
-	query := r.db.Where("user_id = ?", userID)
+	query := r.applyFilters(r.db.Where("user_id = ?", userID), filter)
 
-	// Aplicar filtros
+	// Paginação
 	if filter != nil {
-		if filter.Status != "" {
-			query = query.Where("status = ?", filter.Status)
-		}
-		if filter.Priority != "" {
-			query = query.Where("priority = ?", filter.Priority)
-		}
-		if filter.ContactID != nil {
-			query = query.Where("contact_id = ?", *filter.ContactID)
-		}
-		if filter.ProjectID != nil {
-			query = query.Where("project_id = ?", *filter.ProjectID)
-		}
-		if filter.DueBefore != nil {
-			query = query.Where("due_date <= ?", filter.DueBefore)
-		}
-		if filter.DueAfter != nil {
-			query = query.Where("due_date >= ?", filter.DueAfter)
-		}
-
-		// Paginação
 		if filter.Limit > 0 {
 			query = query.Limit(filter.Limit)
 		}
@@ -84,8 +146,12 @@ func (r *taskRepository) GetByUserID(userID uint, filter *models.TaskListFilter)
 		}
 	}
 
-	// Ordenar por prioridade e data de vencimento
-	query = query.Order("CASE WHEN priority = 'HIGH' THEN 1 WHEN priority = 'MEDIUM' THEN 2 ELSE 3 END, due_date ASC")
+	// Ordenar por prioridade e data de vencimento (sincronização: pela data de alteração)
+	if filter != nil && filter.ChangedSince != nil {
+		query = query.Order(syncOrder)
+	} else {
+		query = query.Order("CASE WHEN priority = 'HIGH' THEN 1 WHEN priority = 'MEDIUM' THEN 2 ELSE 3 END, due_date ASC")
+	}
 
 	if err := query.Preload("Contact").Preload("Project").Find(&tasks).Error; err != nil {
 		return nil, err
@@ -94,6 +160,54 @@ func (r *taskRepository) GetByUserID(userID uint, filter *models.TaskListFilter)
 	return tasks, nil
 }
 
+// CountByUserIDWithFilter conta as tarefas do usuário que atendem aos filtros (ignorando paginação)
+func (r *taskRepository) CountByUserIDWithFilter(userID uint, filter *models.TaskListFilter) (int64, error) {
+	var count int64
+	query := r.applyFilters(r.db.Model(&models.Task{}).Where("user_id = ?", userID), filter)
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ReassignContact move as tarefas (não excluídas) de um contato para outro, incrementando suas versões.
+// Retorna a quantidade de tarefas movidas.
+func (r *taskRepository) ReassignContact(fromContactID, toContactID uint) (int64, error) {
+	result := r.db.Model(&models.Task{}).
+		Where("contact_id = ?", fromContactID).
+		Updates(map[string]interface{}{
+			"contact_id": toContactID,
+			"version":    gorm.Expr("version + 1"),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// GetAllByContactID busca as tarefas ligadas ao contato diretamente (contact_id) ou por meio de
+// projetos em que ele é o cliente. Tarefas que atendem às duas condições aparecem uma única vez.
+func (r *taskRepository) GetAllByContactID(contactID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	clientProjects := r.db.Model(&models.Project{}).Select("id").Where("client_id = ?", contactID)
+
+	if err := r.db.Where("contact_id = ? OR project_id IN (?)", contactID, clientProjects).
+		Preload("Contact").
+		Preload("Project").
+		Order("due_date ASC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// StreamByUserID percorre todas as tarefas do usuário em lotes (ordenadas por ID)
+func (r *taskRepository) StreamByUserID(userID uint, batchSize int, fn func(batch []models.Task) error) error {
+	var batch []models.Task
+	result := r.db.Where("user_id = ?", userID).FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	})
+	return result.Error
+}
+
 // GetByContactID busca tarefas por ID do contato
 func (r *taskRepository) GetByContactID(contactID uint) ([]models.Task, error) {
 	var tasks []models.Task
@@ -120,12 +234,10 @@ func (r *taskRepository) GetByProjectID(projectID uint) ([]models.Task, error) {
 	return tasks, nil
 }
 
-// Update atualiza uma tarefa existente
+// Update atualiza uma tarefa existente com controle de concorrência otimista.
+// Retorna ErrVersionConflict se a versão em task.Version não for mais a atual.
 func (r *taskRepository) Update(task *models.Task) error {
-	if err := r.db.Save(task).Error; err != nil {
-		return err
-	}
-	return nil
+	return updateVersioned(r.db, task, &task.Version)
 }
 
 // Delete remove uma tarefa do banco de dados (soft delete)
@@ -136,45 +248,210 @@ func (r *taskRepository) Delete(id uint) error {
 	return nil
 }
 
+// DeleteCompletedByUserID remove (soft delete) as tarefas concluídas do usuário, opcionalmente apenas
+// as concluídas antes de before, e retorna as tarefas removidas. As tarefas são bloqueadas ao serem
+// lidas para que nenhuma seja reaberta entre a leitura e a exclusão.
+func (r *taskRepository) DeleteCompletedByUserID(userID uint, before *time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// O filtro de status é fixo para nunca remover tarefas pendentes ou em andamento
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND status = ?", userID, models.TaskStatusCompleted)
+		if before != nil {
+			query = query.Where("completed_at < ?", *before)
+		}
+		if err := query.Order("id ASC").Find(&tasks).Error; err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(tasks))
+		for i, task := range tasks {
+			ids[i] = task.ID
+		}
+		return tx.Where("id IN ?", ids).Delete(&models.Task{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// GetDeletedByID busca uma tarefa excluída (soft delete) pelo ID
+func (r *taskRepository) GetDeletedByID(id uint) (*models.Task, error) {
+	var task models.Task
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&task, id).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// Restore restaura uma tarefa excluída (soft delete)
+func (r *taskRepository) Restore(id uint) error {
+	if err := r.db.Unscoped().Model(&models.Task{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 // CountByUserID conta o número total de tarefas de um usuário
 func (r *taskRepository) CountByUserID(userID uint) (int64, error) {
+	return r.CountByUserIDInRange(userID, nil)
+}
+
+// CountGroupedByStatusAndPriority conta as tarefas de um usuário agrupadas por status e por prioridade.
+// Todos os status e prioridades conhecidos estão presentes no resultado, com 0 quando não há tarefas.
+func (r *taskRepository) CountGroupedByStatusAndPriority(userID uint) (map[models.TaskStatus]int64, map[models.Priority]int64, error) {
+	var statusRows []struct {
+		Status models.TaskStatus
+		Count  int64
+	}
+	if err := r.db.Model(&models.Task{}).
+		Select("status, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("status").
+		Scan(&statusRows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var priorityRows []struct {
+		Priority models.Priority
+		Count    int64
+	}
+	if err := r.db.Model(&models.Task{}).
+		Select("priority, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("priority").
+		Scan(&priorityRows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	byStatus := make(map[models.TaskStatus]int64, len(models.TaskStatuses))
+	for _, status := range models.TaskStatuses {
+		byStatus[status] = 0
+	}
+	for _, row := range statusRows {
+		byStatus[row.Status] = row.Count
+	}
+
+	byPriority := make(map[models.Priority]int64, len(models.Priorities))
+	for _, priority := range models.Priorities {
+		byPriority[priority] = 0
+	}
+	for _, row := range priorityRows {
+		byPriority[row.Priority] = row.Count
+	}
+
+	return byStatus, byPriority, nil
+}
+
+// CountPendingByUserID conta o número de tarefas pendentes de um usuário
+func (r *taskRepository) CountPendingByUserID(userID uint) (int64, error) {
+	return r.CountPendingByUserIDInRange(userID, nil)
+}
+
+// CountOverdueByUserID conta o número de tarefas em atraso de um usuário
+func (r *taskRepository) CountOverdueByUserID(userID uint) (int64, error) {
+	return r.CountOverdueByUserIDInRange(userID, nil)
+}
+
+// CountByUserIDInRange conta as tarefas do usuário criadas no intervalo informado
+func (r *taskRepository) CountByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error) {
 	var count int64
-	if err := r.db.Model(&models.Task{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+	query := applyDateRange(r.db.Model(&models.Task{}).Where("user_id = ?", userID), "created_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
-// CountPendingByUserID conta o número de tarefas pendentes de um usuário
-func (r *taskRepository) CountPendingByUserID(userID uint) (int64, error) {
+// CountPendingByUserIDInRange conta as tarefas atualmente pendentes (não iniciadas) do usuário criadas no intervalo
+func (r *taskRepository) CountPendingByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error) {
+	return r.CountByStatusInRange(userID, models.TaskStatusPending, dateRange)
+}
+
+// CountByStatusInRange conta as tarefas do usuário, com o status atual informado, criadas no intervalo
+func (r *taskRepository) CountByStatusInRange(userID uint, status models.TaskStatus, dateRange *models.DateRange) (int64, error) {
 	var count int64
-	if err := r.db.Model(&models.Task{}).
-		Where("user_id = ? AND status = ?", userID, models.TaskStatusPending).
-		Count(&count).Error; err != nil {
+	query := applyDateRange(r.db.Model(&models.Task{}).
+		Where("user_id = ? AND status = ?", userID, status), "created_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
-// CountOverdueByUserID conta o número de tarefas em atraso de um usuário
-func (r *taskRepository) CountOverdueByUserID(userID uint) (int64, error) {
+// CountCompletedInRange conta as tarefas do usuário concluídas no intervalo informado
+func (r *taskRepository) CountCompletedInRange(userID uint, dateRange *models.DateRange) (int64, error) {
+	var count int64
+	query := applyDateRange(r.db.Model(&models.Task{}).
+		Where("user_id = ? AND status = ?", userID, models.TaskStatusCompleted), "completed_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetCompletedInRange busca as tarefas do usuário concluídas no intervalo informado, das mais recentes às mais antigas
+func (r *taskRepository) GetCompletedInRange(userID uint, dateRange *models.DateRange, limit int) ([]models.Task, error) {
+	var tasks []models.Task
+	query := applyDateRange(r.db.Where("user_id = ? AND status = ?", userID, models.TaskStatusCompleted), "completed_at", dateRange).
+		Preload("Contact").
+		Preload("Project").
+		Order("completed_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CountOverdueByUserIDInRange conta as tarefas não concluídas, vencidas e não adiadas do usuário criadas no intervalo
+func (r *taskRepository) CountOverdueByUserIDInRange(userID uint, dateRange *models.DateRange) (int64, error) {
 	var count int64
 	now := time.Now()
-	if err := r.db.Model(&models.Task{}).
-		Where("user_id = ? AND status = ? AND due_date < ?", userID, models.TaskStatusPending, now).
-		Count(&count).Error; err != nil {
+	query := applyDateRange(r.db.Model(&models.Task{}).
+		Where("user_id = ? AND status <> ? AND due_date < ?", userID, models.TaskStatusCompleted, now).
+		Scopes(notSnoozed), "created_at", dateRange)
+	if err := query.Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
-// GetOverdueTasks busca tarefas em atraso de um usuário
+// GetOverdueTasks busca tarefas em atraso (não concluídas e vencidas) de um usuário
 func (r *taskRepository) GetOverdueTasks(userID uint) ([]models.Task, error) {
+	return r.findOverdue("user_id = ?", userID)
+}
+
+// GetOverdueByContactID busca as tarefas em atraso associadas ao contato
+func (r *taskRepository) GetOverdueByContactID(contactID uint) ([]models.Task, error) {
+	return r.findOverdue("contact_id = ?", contactID)
+}
+
+// GetOverdueByProjectID busca as tarefas em atraso associadas ao projeto
+func (r *taskRepository) GetOverdueByProjectID(projectID uint) ([]models.Task, error) {
+	return r.findOverdue("project_id = ?", projectID)
+}
+
+// findOverdue busca tarefas não concluídas e não adiadas com vencimento já passado, restritas pelo escopo informado
+func (r *taskRepository) findOverdue(scope string, value uint) ([]models.Task, error) {
 	var tasks []models.Task
 	now := time.Now()
 
-	if err := r.db.Where("user_id = ? AND status = ? AND due_date < ?",
-		userID, models.TaskStatusPending, now).
+	if err := r.db.Where(scope, value).
+		Where("status <> ? AND due_date < ?", models.TaskStatusCompleted, now).
+		Scopes(notSnoozed).
 		Preload("Contact").
 		Preload("Project").
 		Order("due_date ASC").
@@ -184,3 +461,99 @@ func (r *taskRepository) GetOverdueTasks(userID uint) ([]models.Task, error) {
 
 	return tasks, nil
 }
+
+// GetUpcomingTasks busca tarefas não concluídas e não adiadas com vencimento entre agora e os próximos X dias
+func (r *taskRepository) GetUpcomingTasks(userID uint, from, to time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+
+	if err := r.db.Where("user_id = ? AND status <> ? AND due_date IS NOT NULL AND due_date BETWEEN ? AND ?",
+		userID, models.TaskStatusCompleted, from, to).
+		Scopes(notSnoozed).
+		Preload("Contact").
+		Preload("Project").
+		Order("due_date ASC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// GetDueInRange busca as tarefas do usuário (de qualquer status) com vencimento a partir de from e
+// antes de to, em ordem de vencimento
+func (r *taskRepository) GetDueInRange(userID uint, from, to time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+
+	if err := r.db.Where("user_id = ? AND due_date >= ? AND due_date < ?", userID, from, to).
+		Preload("Contact").
+		Preload("Project").
+		Order("due_date ASC, id ASC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// CountOpenDueByContact conta, por contato, as tarefas não concluídas e não adiadas do usuário com
+// vencimento até until (inclusive as atrasadas). Tarefas sem contato são ignoradas.
+func (r *taskRepository) CountOpenDueByContact(userID uint, until time.Time) (map[uint]int64, error) {
+	var rows []struct {
+		ContactID uint
+		Count     int64
+	}
+
+	if err := r.db.Model(&models.Task{}).
+		Select("contact_id, COUNT(*) AS count").
+		Where("user_id = ? AND contact_id IS NOT NULL AND status <> ? AND due_date <= ?",
+			userID, models.TaskStatusCompleted, until).
+		Scopes(notSnoozed).
+		Group("contact_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ContactID] = row.Count
+	}
+
+	return counts, nil
+}
+
+// GetPendingDueBetween busca tarefas não concluídas (pendentes ou em andamento) e não adiadas de todos
+// os usuários com vencimento no intervalo informado
+func (r *taskRepository) GetPendingDueBetween(from, to time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+
+	if err := r.db.Where("status <> ? AND due_date IS NOT NULL AND due_date BETWEEN ? AND ?",
+		models.TaskStatusCompleted, from, to).
+		Scopes(notSnoozed).
+		Order("due_date ASC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Search busca tarefas do usuário por título ou descrição (busca parcial)
+func (r *taskRepository) Search(userID uint, term string, limit int) ([]models.Task, error) {
+	var tasks []models.Task
+	searchTerm := "%" + term + "%"
+
+	query := r.db.Where("user_id = ? AND (title ILIKE ? OR description ILIKE ?)", userID, searchTerm, searchTerm).
+		Order("updated_at DESC").
+		Preload("Contact").
+		Preload("Project")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
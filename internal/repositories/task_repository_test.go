@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lastQuery retorna a última instrução capturada, falhando se nenhuma foi gerada
+func lastQuery(t *testing.T, captured *[]capturedQuery) capturedQuery {
+	t.Helper()
+	if len(*captured) == 0 {
+		t.Fatal("nenhuma consulta gerada")
+	}
+	return (*captured)[len(*captured)-1]
+}
+
+// assertContainsAll verifica se a instrução contém todos os trechos informados
+func assertContainsAll(t *testing.T, sql string, parts ...string) {
+	t.Helper()
+	for _, part := range parts {
+		if !strings.Contains(sql, part) {
+			t.Errorf("SQL sem %q:\n%s", part, sql)
+		}
+	}
+}
+
+func TestTaskGetUpcomingTasksQuery(t *testing.T) {
+	db, captured := newDryRunDB(t)
+	from := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 8, 23, 59, 59, 0, time.UTC)
+
+	if _, err := NewTaskRepository(db).GetUpcomingTasks(7, from, to); err != nil {
+		t.Fatalf("GetUpcomingTasks: %v", err)
+	}
+	query := lastQuery(t, captured)
+
+	assertContainsAll(t, query.SQL,
+		"user_id = $1 AND status <> $2 AND due_date IS NOT NULL AND due_date BETWEEN $3 AND $4",
+		`"tasks"."deleted_at" IS NULL`,
+		"ORDER BY due_date ASC",
+	)
+	if len(query.Vars) < 4 || query.Vars[0] != uint(7) || query.Vars[1] != models.TaskStatusCompleted ||
+		query.Vars[2] != from || query.Vars[3] != to {
+		t.Errorf("vars = %v, want [7 COMPLETED %v %v ...]", query.Vars, from, to)
+	}
+}
+
+func TestTaskOverdueQueryScopes(t *testing.T) {
+	tests := []struct {
+		name      string
+		overdue   func(TaskRepository) ([]models.Task, error)
+		wantScope string
+	}{
+		{name: "usuário", overdue: func(r TaskRepository) ([]models.Task, error) { return r.GetOverdueTasks(7) }, wantScope: " WHERE user_id = $1 AND "},
+		{name: "contato", overdue: func(r TaskRepository) ([]models.Task, error) { return r.GetOverdueByContactID(7) }, wantScope: " WHERE contact_id = $1 AND "},
+		{name: "projeto", overdue: func(r TaskRepository) ([]models.Task, error) { return r.GetOverdueByProjectID(7) }, wantScope: " WHERE project_id = $1 AND "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, captured := newDryRunDB(t)
+			if _, err := tt.overdue(NewTaskRepository(db)); err != nil {
+				t.Fatalf("consulta de atrasadas: %v", err)
+			}
+
+			// O escopo vem antes do mesmo predicado de atraso usado em todas as variantes
+			query := lastQuery(t, captured)
+			where := whereClause(query.SQL)
+			if !strings.HasPrefix(where, tt.wantScope) {
+				t.Errorf("WHERE = %q, want prefixo %q", where, tt.wantScope)
+			}
+			assertContainsAll(t, query.SQL,
+				"status <> $2 AND due_date < $3",
+				`"tasks"."deleted_at" IS NULL`,
+				"ORDER BY due_date ASC",
+			)
+			if len(query.Vars) < 2 || query.Vars[0] != uint(7) || query.Vars[1] != models.TaskStatusCompleted {
+				t.Errorf("vars = %v, want [7 COMPLETED ...]", query.Vars)
+			}
+		})
+	}
+}
+
+func TestTaskListFilterStatusesAndPriorities(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    *models.TaskListFilter
+		wantWhere string
+		wantVars  string
+	}{
+		{
+			name:      "um status",
+			filter:    &models.TaskListFilter{Status: models.TaskStatusPending, IncludeSnoozed: true},
+			wantWhere: ` WHERE user_id = $1 AND status IN ($2) AND "tasks"."deleted_at" IS NULL`,
+			wantVars:  "[7 PENDING]",
+		},
+		{
+			name:      "vários status",
+			filter:    &models.TaskListFilter{Status: "PENDING,IN_PROGRESS", IncludeSnoozed: true},
+			wantWhere: ` WHERE user_id = $1 AND status IN ($2,$3) AND "tasks"."deleted_at" IS NULL`,
+			wantVars:  "[7 PENDING IN_PROGRESS]",
+		},
+		{
+			name:      "status e prioridades",
+			filter:    &models.TaskListFilter{Status: "COMPLETED", Priority: "HIGH, MEDIUM", IncludeSnoozed: true},
+			wantWhere: ` WHERE user_id = $1 AND status IN ($2) AND priority IN ($3,$4) AND "tasks"."deleted_at" IS NULL`,
+			wantVars:  "[7 COMPLETED HIGH MEDIUM]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, captured := newDryRunDB(t)
+			if _, err := NewTaskRepository(db).GetByUserID(7, tt.filter); err != nil {
+				t.Fatalf("GetByUserID: %v", err)
+			}
+
+			query := lastQuery(t, captured)
+			if got := whereClause(query.SQL); got != tt.wantWhere {
+				t.Errorf("WHERE = %q\nwant    %q", got, tt.wantWhere)
+			}
+			if got := fmt.Sprint(query.Vars); got != tt.wantVars {
+				t.Errorf("vars = %s, want %s", got, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestTaskListFilterHasDueDate(t *testing.T) {
+	withDueDate, withoutDueDate := true, false
+
+	tests := []struct {
+		name       string
+		hasDueDate *bool
+		wantWhere  string
+	}{
+		{name: "sem filtro", wantWhere: ` WHERE user_id = $1 AND "tasks"."deleted_at" IS NULL`},
+		{name: "com vencimento", hasDueDate: &withDueDate, wantWhere: ` WHERE user_id = $1 AND due_date IS NOT NULL AND "tasks"."deleted_at" IS NULL`},
+		{name: "sem vencimento", hasDueDate: &withoutDueDate, wantWhere: ` WHERE user_id = $1 AND due_date IS NULL AND "tasks"."deleted_at" IS NULL`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, captured := newDryRunDB(t)
+			filter := &models.TaskListFilter{HasDueDate: tt.hasDueDate, IncludeSnoozed: true}
+			if _, err := NewTaskRepository(db).GetByUserID(7, filter); err != nil {
+				t.Fatalf("GetByUserID: %v", err)
+			}
+
+			if got := whereClause(lastQuery(t, captured).SQL); got != tt.wantWhere {
+				t.Errorf("WHERE = %q\nwant    %q", got, tt.wantWhere)
+			}
+		})
+	}
+}
+
+func TestTaskQueriesHideSnoozedUntilItEnds(t *testing.T) {
+	snoozed := regexp.MustCompile(`\(snoozed_until IS NULL OR snoozed_until <= \$(\d+)\)`)
+	changedSince := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		query       func(TaskRepository) error
+		wantSnoozed bool
+	}{
+		{name: "próximas tarefas", wantSnoozed: true, query: func(r TaskRepository) error {
+			_, err := r.GetUpcomingTasks(7, time.Now(), time.Now().Add(24*time.Hour))
+			return err
+		}},
+		{name: "tarefas atrasadas", wantSnoozed: true, query: func(r TaskRepository) error {
+			_, err := r.GetOverdueTasks(7)
+			return err
+		}},
+		{name: "listagem padrão", wantSnoozed: true, query: func(r TaskRepository) error {
+			_, err := r.GetByUserID(7, &models.TaskListFilter{})
+			return err
+		}},
+		{name: "listagem com include_snoozed", query: func(r TaskRepository) error {
+			_, err := r.GetByUserID(7, &models.TaskListFilter{IncludeSnoozed: true})
+			return err
+		}},
+		{name: "sincronização", query: func(r TaskRepository) error {
+			_, err := r.GetByUserID(7, &models.TaskListFilter{ChangedSince: &changedSince})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, captured := newDryRunDB(t)
+			start := time.Now()
+			if err := tt.query(NewTaskRepository(db)); err != nil {
+				t.Fatalf("consulta: %v", err)
+			}
+			end := time.Now()
+
+			query := lastQuery(t, captured)
+			match := snoozed.FindStringSubmatch(query.SQL)
+			if !tt.wantSnoozed {
+				if match != nil {
+					t.Errorf("tarefas adiadas não deveriam ser excluídas:\n%s", query.SQL)
+				}
+				return
+			}
+			if match == nil {
+				t.Fatalf("SQL sem o filtro de adiamento:\n%s", query.SQL)
+			}
+
+			// O corte é o momento da consulta: a tarefa volta a aparecer assim que o adiamento termina
+			index, _ := strconv.Atoi(match[1])
+			cutoff, ok := query.Vars[index-1].(time.Time)
+			if !ok || cutoff.Before(start) || cutoff.After(end) {
+				t.Errorf("corte do adiamento = %v, want momento da consulta", query.Vars[index-1])
+			}
+		})
+	}
+}
+
+func TestTaskDeleteCompletedSelectsOnlyCompletedInWindow(t *testing.T) {
+	before := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		before    *time.Time
+		wantWhere string
+		wantVars  string
+	}{
+		{
+			name:      "todas as concluídas",
+			wantWhere: ` WHERE (user_id = $1 AND status = $2) AND "tasks"."deleted_at" IS NULL`,
+			wantVars:  "[7 COMPLETED]",
+		},
+		{
+			name:      "concluídas antes da data",
+			before:    &before,
+			wantWhere: ` WHERE (user_id = $1 AND status = $2) AND completed_at < $3 AND "tasks"."deleted_at" IS NULL`,
+			wantVars:  fmt.Sprint([]interface{}{uint(7), models.TaskStatusCompleted, before}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, captured := newDryRunDB(t)
+			if _, err := NewTaskRepository(db).DeleteCompletedByUserID(7, tt.before); err != nil {
+				t.Fatalf("DeleteCompletedByUserID: %v", err)
+			}
+
+			// As tarefas removidas são lidas e bloqueadas com o status fixo em COMPLETED
+			query := lastQuery(t, captured)
+			if got := whereClause(query.SQL); got != tt.wantWhere {
+				t.Errorf("WHERE = %q\nwant    %q", got, tt.wantWhere)
+			}
+			assertContainsAll(t, query.SQL, "ORDER BY id ASC FOR UPDATE")
+			if got := fmt.Sprint(query.Vars); got != tt.wantVars {
+				t.Errorf("vars = %s, want %s", got, tt.wantVars)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// Transactor executa operações de vários repositórios em uma única transação.
+// Dentro da função, os repositórios devem ser vinculados à transação com WithTx(tx).
+type Transactor interface {
+	Transaction(fn func(tx *gorm.DB) error) error
+}
+
+// transactor implementa Transactor
+type transactor struct {
+	db *gorm.DB
+}
+
+// NewTransactor cria um Transactor sobre a conexão informada
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactor{db: db}
+}
+
+// Transaction executa fn em uma transação: confirma se fn retornar nil e desfaz caso contrário
+func (t *transactor) Transaction(fn func(tx *gorm.DB) error) error {
+	return t.db.Transaction(fn)
+}
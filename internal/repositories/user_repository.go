@@ -43,10 +43,10 @@ func (r *userRepository) GetByID(id uint) (*models.User, error) {
 	return &user, nil
 }
 
-// GetByEmail busca um usuário pelo email
+// GetByEmail busca um usuário pelo email (sem diferenciar maiúsculas)
 func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 	var user models.User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.db.Where("LOWER(email) = LOWER(?)", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -68,12 +68,11 @@ func (r *userRepository) Delete(id uint) error {
 	return nil
 }
 
-// EmailExists verifica se um email já está em uso
+// EmailExists verifica se um email já está em uso (sem diferenciar maiúsculas)
 func (r *userRepository) EmailExists(email string) (bool, error) {
 	var count int64
-	if err := r.db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	if err := r.db.Model(&models.User{}).Where("LOWER(email) = LOWER(?)", email).Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
-
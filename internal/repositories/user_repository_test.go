@@ -0,0 +1,35 @@
+package repositories
+
+import "testing"
+
+func TestUserEmailLookupsIgnoreCase(t *testing.T) {
+	tests := []struct {
+		name   string
+		lookup func(UserRepository) error
+	}{
+		{name: "GetByEmail", lookup: func(r UserRepository) error {
+			_, err := r.GetByEmail("A@B.com")
+			return err
+		}},
+		{name: "EmailExists", lookup: func(r UserRepository) error {
+			_, err := r.EmailExists("A@B.com")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, captured := newDryRunDB(t)
+			if err := tt.lookup(NewUserRepository(db)); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+
+			// A@B.com e a@b.com colidem: a comparação é feita em minúsculas no banco
+			query := lastQuery(t, captured)
+			assertContainsAll(t, whereClause(query.SQL), "LOWER(email) = LOWER($1)")
+			if len(query.Vars) == 0 || query.Vars[0] != "A@B.com" {
+				t.Errorf("vars = %v, want [A@B.com ...]", query.Vars)
+			}
+		})
+	}
+}
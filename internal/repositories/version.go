@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrVersionConflict indica que o registro foi alterado por outra operação desde que foi lido
+var ErrVersionConflict = errors.New("registro alterado por outra operação")
+
+// updateVersioned persiste todos os campos do registro apenas se a versão no banco ainda for a lida,
+// incrementando-a. Sem linhas afetadas, outra operação atualizou o registro antes e retorna ErrVersionConflict.
+func updateVersioned(db *gorm.DB, value interface{}, version *uint) error {
+	expected := *version
+	*version = expected + 1
+
+	result := db.Model(value).
+		Select("*").
+		Omit(clause.Associations).
+		Where("version = ?", expected).
+		Updates(value)
+	if result.Error != nil {
+		*version = expected
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		*version = expected
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"crm-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// WebhookRepository define a interface para operações de webhook no banco de dados
+type WebhookRepository interface {
+	Create(webhook *models.Webhook) error
+	GetByID(id uint) (*models.Webhook, error)
+	GetByUserID(userID uint) ([]models.Webhook, error)
+	GetActiveByUserID(userID uint) ([]models.Webhook, error)
+	Update(webhook *models.Webhook) error
+	Delete(id uint) error
+}
+
+// webhookRepository implementa WebhookRepository
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository cria uma nova instância do repositório de webhooks
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// Create cria um novo webhook no banco de dados
+func (r *webhookRepository) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+// GetByID busca um webhook pelo ID
+func (r *webhookRepository) GetByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := r.db.First(&webhook, id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetByUserID busca os webhooks de um usuário, dos mais antigos aos mais recentes
+func (r *webhookRepository) GetByUserID(userID uint) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	if err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// GetActiveByUserID busca os webhooks ativos de um usuário
+func (r *webhookRepository) GetActiveByUserID(userID uint) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	if err := r.db.Where("user_id = ? AND active = ?", userID, true).Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Update atualiza um webhook existente
+func (r *webhookRepository) Update(webhook *models.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+// Delete remove um webhook
+func (r *webhookRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Webhook{}, id).Error
+}
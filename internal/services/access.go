@@ -0,0 +1,96 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// AccessPolicy define como os serviços respondem a registros que existem mas pertencem a outro usuário
+type AccessPolicy struct {
+	// HideOwnership responde NotFound (404), que não permite descobrir quais IDs existem,
+	// em vez de ErrForbidden (403, padrão)
+	HideOwnership bool
+}
+
+// ownershipError retorna o erro para um recurso existente que o usuário não pode acessar
+func (p AccessPolicy) ownershipError(resource string) error {
+	if p.HideOwnership {
+		return errors.NewNotFoundError(resource)
+	}
+	return errors.ErrForbidden
+}
+
+// authorizeAccess verifica se o usuário pode acessar o recurso: o dono sempre pode;
+// os demais apenas se o recurso tiver sido compartilhado com eles com a permissão exigida
+func authorizeAccess(
+	access AccessPolicy,
+	shareRepo repositories.SharedResourceRepository,
+	resourceType models.SharedResourceType,
+	resourceID, ownerID, userID uint,
+	permission models.SharePermission,
+	resource string,
+) error {
+	if ownerID == userID {
+		return nil
+	}
+	if shareRepo == nil {
+		return access.ownershipError(resource)
+	}
+
+	allowed, err := shareRepo.HasPermission(resourceType, resourceID, userID, permission)
+	if err != nil {
+		return errors.ErrInternalServer
+	}
+	if !allowed {
+		return access.ownershipError(resource)
+	}
+
+	return nil
+}
+
+// Ownable é implementado pelas entidades que pertencem diretamente a um usuário
+type Ownable interface {
+	GetOwnerID() uint
+}
+
+// fetchOwned busca uma entidade pelo ID e verifica se ela pertence ao usuário.
+// Retorna NotFound (com o nome do recurso) se não existir, ownershipError se pertencer a outro usuário
+// e ErrInternalServer em falhas do banco
+func fetchOwned[T Ownable](access AccessPolicy, get func(id uint) (T, error), id, userID uint, resource string) (T, error) {
+	var zero T
+
+	entity, err := get(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return zero, errors.NewNotFoundError(resource)
+		}
+		return zero, errors.ErrInternalServer
+	}
+
+	if entity.GetOwnerID() != userID {
+		return zero, access.ownershipError(resource)
+	}
+
+	return entity, nil
+}
+
+// fetchOwnedInteraction é a variante de fetchOwned para interações, cuja posse é indireta:
+// a interação pertence ao usuário dono do contato associado
+func fetchOwnedInteraction(access AccessPolicy, repo repositories.InteractionRepository, id, userID uint) (*models.Interaction, error) {
+	interaction, err := repo.GetByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("Interação")
+		}
+		return nil, errors.ErrInternalServer
+	}
+
+	if interaction.Contact.UserID != userID {
+		return nil, access.ownershipError("Interação")
+	}
+
+	return interaction, nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// fakeShareRepo concede as permissões cadastradas em grants (por ID do recurso e usuário)
+type fakeShareRepo struct {
+	repositories.SharedResourceRepository
+	grants  map[[2]uint]models.SharePermission
+	err     error
+	created []models.SharedResource
+}
+
+func (r *fakeShareRepo) HasPermission(resourceType models.SharedResourceType, resourceID, userID uint, permission models.SharePermission) (bool, error) {
+	if r.err != nil {
+		return false, r.err
+	}
+	granted, ok := r.grants[[2]uint{resourceID, userID}]
+	return ok && granted == permission, nil
+}
+
+func TestFetchOwned(t *testing.T) {
+	contacts := map[uint]*models.Contact{1: {ID: 1, UserID: 7}}
+	get := func(id uint) (*models.Contact, error) {
+		if id == 99 {
+			return nil, stderrors.New("connection refused")
+		}
+		contact, ok := contacts[id]
+		if !ok {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return contact, nil
+	}
+
+	tests := []struct {
+		name       string
+		hide       bool
+		id, userID uint
+		wantStatus int // 0: acesso permitido
+	}{
+		{name: "dono", id: 1, userID: 7},
+		{name: "dono com hide", hide: true, id: 1, userID: 7},
+		{name: "outro usuário", id: 1, userID: 8, wantStatus: http.StatusForbidden},
+		{name: "outro usuário com hide", hide: true, id: 1, userID: 8, wantStatus: http.StatusNotFound},
+		{name: "inexistente", id: 2, userID: 7, wantStatus: http.StatusNotFound},
+		{name: "inexistente com hide", hide: true, id: 2, userID: 7, wantStatus: http.StatusNotFound},
+		{name: "falha do banco", id: 99, userID: 7, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact, err := fetchOwned(AccessPolicy{HideOwnership: tt.hide}, get, tt.id, tt.userID, "Contato")
+			if tt.wantStatus == 0 {
+				if err != nil || contact == nil || contact.ID != tt.id {
+					t.Fatalf("fetchOwned = %v, %v, want contato %d", contact, err, tt.id)
+				}
+				return
+			}
+			assertAppError(t, err, tt.wantStatus)
+			if contact != nil {
+				t.Errorf("contact = %+v, want nil", contact)
+			}
+		})
+	}
+}
+
+func TestAuthorizeAccess(t *testing.T) {
+	shareRepo := &fakeShareRepo{grants: map[[2]uint]models.SharePermission{
+		{5, 8}: models.SharePermissionRead,
+	}}
+
+	tests := []struct {
+		name       string
+		hide       bool
+		shareRepo  repositories.SharedResourceRepository
+		userID     uint
+		permission models.SharePermission
+		wantStatus int // 0: acesso permitido
+	}{
+		{name: "dono", shareRepo: shareRepo, userID: 7, permission: models.SharePermissionRead},
+		{name: "compartilhado", shareRepo: shareRepo, userID: 8, permission: models.SharePermissionRead},
+		{name: "compartilhado com hide", hide: true, shareRepo: shareRepo, userID: 8, permission: models.SharePermissionRead},
+		{name: "sem compartilhamento", shareRepo: shareRepo, userID: 9, permission: models.SharePermissionRead, wantStatus: http.StatusForbidden},
+		{name: "sem compartilhamento com hide", hide: true, shareRepo: shareRepo, userID: 9, permission: models.SharePermissionRead, wantStatus: http.StatusNotFound},
+		{name: "sem repositório de compartilhamento", userID: 8, permission: models.SharePermissionRead, wantStatus: http.StatusForbidden},
+		{name: "sem repositório de compartilhamento com hide", hide: true, userID: 8, permission: models.SharePermissionRead, wantStatus: http.StatusNotFound},
+		{name: "falha do banco", shareRepo: &fakeShareRepo{err: stderrors.New("timeout")}, userID: 8, permission: models.SharePermissionRead, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorizeAccess(AccessPolicy{HideOwnership: tt.hide}, tt.shareRepo, models.SharedResourceProject, 5, 7, tt.userID, tt.permission, "Projeto")
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("authorizeAccess = %v, want nil", err)
+				}
+				return
+			}
+			assertAppError(t, err, tt.wantStatus)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/pkg/errors"
+	"encoding/json"
+	"io"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InteractionExport representa uma interação no formato de exportação (sem relacionamentos)
+type InteractionExport struct {
+	ID             uint                      `json:"id"`
+	ContactID      uint                      `json:"contact_id"`
+	Type           models.InteractionType    `json:"type"`
+	Date           time.Time                 `json:"date"`
+	Subject        string                    `json:"subject"`
+	Description    string                    `json:"description"`
+	Outcome        models.InteractionOutcome `json:"outcome"`
+	Planned        bool                      `json:"planned"`
+	FollowUpTaskID *uint                     `json:"follow_up_task_id,omitempty"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+// TaskExport representa uma tarefa no formato de exportação (sem relacionamentos)
+type TaskExport struct {
+	ID             uint                  `json:"id"`
+	Title          string                `json:"title"`
+	Description    string                `json:"description"`
+	DueDate        *time.Time            `json:"due_date,omitempty"`
+	Priority       models.Priority       `json:"priority"`
+	Status         models.TaskStatus     `json:"status"`
+	ContactID      *uint                 `json:"contact_id,omitempty"`
+	ProjectID      *uint                 `json:"project_id,omitempty"`
+	CompletedAt    *time.Time            `json:"completed_at,omitempty"`
+	RecurrenceRule models.RecurrenceRule `json:"recurrence_rule"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// ProjectExport representa um projeto no formato de exportação (sem relacionamentos)
+type ProjectExport struct {
+	ID               uint                 `json:"id"`
+	Name             string               `json:"name"`
+	Description      string               `json:"description"`
+	Status           models.ProjectStatus `json:"status"`
+	ClientID         uint                 `json:"client_id"`
+	StartDate        *time.Time           `json:"start_date,omitempty"`
+	EndDate          *time.Time           `json:"end_date,omitempty"`
+	Budget           int64                `json:"budget"`
+	SpentAmount      int64                `json:"spent_amount"`
+	ProgressOverride *float64             `json:"progress_override,omitempty"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+}
+
+// ExportAllData escreve em w um único documento JSON com o perfil e todos os contatos, interações,
+// tarefas e projetos do usuário. Os dados são lidos e escritos em lotes, sem carregar tudo em memória.
+// Senhas e campos internos (versão, exclusão lógica, relacionamentos) não são incluídos.
+func (s *userService) ExportAllData(userID uint, w io.Writer) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewNotFoundError("Usuário")
+		}
+		return errors.ErrInternalServer
+	}
+
+	header, err := json.Marshal(struct {
+		ExportedAt time.Time           `json:"exported_at"`
+		Profile    models.UserResponse `json:"profile"`
+	}{
+		ExportedAt: time.Now().UTC(),
+		Profile:    user.ToResponse(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Abrir o objeto com os campos do cabeçalho, deixando-o aberto para as listas
+	if _, err := w.Write(header[:len(header)-1]); err != nil {
+		return err
+	}
+
+	err = writeExportSection(w, "contacts", func(fn func([]models.Contact) error) error {
+		return s.contactRepo.StreamByUserID(userID, nil, exportBatchSize, fn)
+	}, newContactExport)
+	if err != nil {
+		return err
+	}
+
+	err = writeExportSection(w, "interactions", func(fn func([]models.Interaction) error) error {
+		return s.interactionRepo.StreamByUserID(userID, exportBatchSize, fn)
+	}, newInteractionExport)
+	if err != nil {
+		return err
+	}
+
+	err = writeExportSection(w, "tasks", func(fn func([]models.Task) error) error {
+		return s.taskRepo.StreamByUserID(userID, exportBatchSize, fn)
+	}, newTaskExport)
+	if err != nil {
+		return err
+	}
+
+	err = writeExportSection(w, "projects", func(fn func([]models.Project) error) error {
+		return s.projectRepo.StreamByUserID(userID, exportBatchSize, fn)
+	}, newProjectExport)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "}")
+	return err
+}
+
+// writeExportSection escreve `,"<name>":[...]` convertendo e serializando os itens lote a lote
+func writeExportSection[T any, E any](w io.Writer, name string, stream func(fn func([]T) error) error, convert func(T) E) error {
+	if _, err := io.WriteString(w, `,"`+name+`":[`); err != nil {
+		return err
+	}
+
+	first := true
+	err := stream(func(batch []T) error {
+		for _, item := range batch {
+			data, err := json.Marshal(convert(item))
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]")
+	return err
+}
+
+// newInteractionExport converte uma interação para o formato de exportação
+func newInteractionExport(interaction models.Interaction) InteractionExport {
+	return InteractionExport{
+		ID:             interaction.ID,
+		ContactID:      interaction.ContactID,
+		Type:           interaction.Type,
+		Date:           interaction.Date,
+		Subject:        interaction.Subject,
+		Description:    interaction.Description,
+		Outcome:        interaction.Outcome,
+		Planned:        interaction.Planned,
+		FollowUpTaskID: interaction.FollowUpTaskID,
+		CreatedAt:      interaction.CreatedAt,
+		UpdatedAt:      interaction.UpdatedAt,
+	}
+}
+
+// newTaskExport converte uma tarefa para o formato de exportação
+func newTaskExport(task models.Task) TaskExport {
+	return TaskExport{
+		ID:             task.ID,
+		Title:          task.Title,
+		Description:    task.Description,
+		DueDate:        task.DueDate,
+		Priority:       task.Priority,
+		Status:         task.Status,
+		ContactID:      task.ContactID,
+		ProjectID:      task.ProjectID,
+		CompletedAt:    task.CompletedAt,
+		RecurrenceRule: task.RecurrenceRule,
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+	}
+}
+
+// newProjectExport converte um projeto para o formato de exportação
+func newProjectExport(project models.Project) ProjectExport {
+	return ProjectExport{
+		ID:               project.ID,
+		Name:             project.Name,
+		Description:      project.Description,
+		Status:           project.Status,
+		ClientID:         project.ClientID,
+		StartDate:        project.StartDate,
+		EndDate:          project.EndDate,
+		Budget:           project.Budget,
+		SpentAmount:      project.SpentAmount,
+		ProgressOverride: project.ProgressOverride,
+		CreatedAt:        project.CreatedAt,
+		UpdatedAt:        project.UpdatedAt,
+	}
+}
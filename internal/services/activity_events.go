@@ -0,0 +1,35 @@
+package services
+
+import (
+	"crm-backend/internal/events"
+	"crm-backend/internal/models"
+	"time"
+)
+
+// publishActivity publica a atividade no barramento de eventos (quando configurado),
+// usando o momento atual como data da atividade
+func publishActivity(publisher events.Publisher, userID uint, activity models.UserActivity, action models.ActivityAction) {
+	if publisher == nil {
+		return
+	}
+
+	now := time.Now()
+	activity.Action = action
+	activity.CreatedAt = now
+	activity.UpdatedAt = now
+
+	publisher.Publish(userID, activity)
+}
+
+// projectStatusAction retorna a ação de atividade correspondente ao status do projeto
+func projectStatusAction(status models.ProjectStatus) models.ActivityAction {
+	switch status {
+	case models.ProjectStatusInProgress:
+		return models.ActionStarted
+	case models.ProjectStatusCompleted:
+		return models.ActionCompleted
+	case models.ProjectStatusCancelled:
+		return models.ActionCancelled
+	}
+	return models.ActionUpdated
+}
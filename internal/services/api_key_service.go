@@ -0,0 +1,151 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"crm-backend/pkg/logger"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// apiKeyPrefix identifica as chaves de API geradas pelo CRM
+	apiKeyPrefix = "crm_"
+	// apiKeyBytes define a quantidade de bytes aleatórios de cada chave
+	apiKeyBytes = 32
+	// apiKeyLastUsedInterval evita uma escrita no banco a cada requisição autenticada por chave
+	apiKeyLastUsedInterval = time.Minute
+)
+
+// errInvalidAPIKey indica uma chave de API desconhecida, revogada ou de um usuário excluído
+var errInvalidAPIKey = errors.NewUnauthorizedError("Chave de API inválida")
+
+// APIKeyService define a interface para operações de chaves de API
+type APIKeyService interface {
+	Create(userID uint, req *models.APIKeyCreateRequest) (*models.APIKeyCreateResponse, error)
+	GetByUserID(userID uint) ([]models.APIKey, error)
+	Delete(userID, keyID uint) error
+	Authenticate(key string) (uint, error)
+}
+
+// apiKeyService implementa APIKeyService
+type apiKeyService struct {
+	apiKeyRepo repositories.APIKeyRepository
+	userRepo   repositories.UserRepository
+	access     AccessPolicy
+}
+
+// NewAPIKeyService cria uma nova instância do serviço de chaves de API
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, userRepo repositories.UserRepository, access AccessPolicy) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		access:     access,
+	}
+}
+
+// Create gera uma nova chave de API para o usuário. A chave em texto puro é retornada apenas
+// nesta resposta; somente o hash é armazenado.
+func (s *apiKeyService) Create(userID uint, req *models.APIKeyCreateRequest) (*models.APIKeyCreateResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewBadRequestError("Nome da chave é obrigatório")
+	}
+	if len([]rune(name)) > models.MaxAPIKeyNameLength {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("O nome deve ter no máximo %d caracteres", models.MaxAPIKeyNameLength))
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	apiKey := &models.APIKey{
+		UserID:  userID,
+		KeyHash: hashAPIKey(key),
+		Name:    name,
+	}
+
+	if err := s.apiKeyRepo.Create(apiKey); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return &models.APIKeyCreateResponse{APIKey: *apiKey, Key: key}, nil
+}
+
+// GetByUserID lista as chaves de API do usuário (sem os valores das chaves)
+func (s *apiKeyService) GetByUserID(userID uint) ([]models.APIKey, error) {
+	keys, err := s.apiKeyRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	return keys, nil
+}
+
+// Delete revoga uma chave de API do usuário
+func (s *apiKeyService) Delete(userID, keyID uint) error {
+	if _, err := fetchOwned(s.access, s.apiKeyRepo.GetByID, keyID, userID, "Chave de API"); err != nil {
+		return err
+	}
+
+	if err := s.apiKeyRepo.Delete(keyID); err != nil {
+		return errors.ErrInternalServer
+	}
+	return nil
+}
+
+// Authenticate identifica o usuário dono da chave de API e registra o uso da chave
+func (s *apiKeyService) Authenticate(key string) (uint, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return 0, errInvalidAPIKey
+	}
+
+	apiKey, err := s.apiKeyRepo.GetByKeyHash(hashAPIKey(key))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, errInvalidAPIKey
+		}
+		return 0, errors.ErrInternalServer
+	}
+
+	// Chaves de contas excluídas deixam de valer
+	if _, err := s.userRepo.GetByID(apiKey.UserID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, errInvalidAPIKey
+		}
+		return 0, errors.ErrInternalServer
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedInterval {
+		if err := s.apiKeyRepo.UpdateLastUsed(apiKey.ID, now); err != nil {
+			logger.LogError(err, "Falha ao registrar uso da chave de API", map[string]interface{}{
+				"api_key_id": apiKey.ID,
+			})
+		}
+	}
+
+	return apiKey.UserID, nil
+}
+
+// generateAPIKey gera uma chave de API aleatória com o prefixo do CRM
+func generateAPIKey() (string, error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashAPIKey calcula o hash persistido de uma chave (a chave em si nunca é armazenada)
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
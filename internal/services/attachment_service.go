@@ -0,0 +1,184 @@
+package services
+
+import (
+	"bytes"
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// AllowedAttachmentContentTypes define os tipos de arquivo aceitos como anexo
+var AllowedAttachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"text/plain":      true,
+}
+
+// AttachmentService define a interface para operações de anexos de interações
+type AttachmentService interface {
+	Upload(userID, interactionID uint, filename string, size int64, content io.Reader) (*models.Attachment, error)
+	GetByInteractionID(userID, interactionID uint) ([]models.Attachment, error)
+	MaxUploadSize() int64
+}
+
+// attachmentService implementa AttachmentService
+type attachmentService struct {
+	attachmentRepo  repositories.AttachmentRepository
+	interactionRepo repositories.InteractionRepository
+	uploadDir       string
+	maxUploadSize   int64
+	access          AccessPolicy
+}
+
+// NewAttachmentService cria uma nova instância do serviço de anexos
+func NewAttachmentService(
+	attachmentRepo repositories.AttachmentRepository,
+	interactionRepo repositories.InteractionRepository,
+	uploadDir string,
+	maxUploadSize int64,
+	access AccessPolicy,
+) AttachmentService {
+	return &attachmentService{
+		attachmentRepo:  attachmentRepo,
+		interactionRepo: interactionRepo,
+		uploadDir:       uploadDir,
+		maxUploadSize:   maxUploadSize,
+		access:          access,
+	}
+}
+
+// MaxUploadSize retorna o tamanho máximo permitido para um anexo, em bytes
+func (s *attachmentService) MaxUploadSize() int64 {
+	return s.maxUploadSize
+}
+
+// Upload armazena um arquivo em disco e registra seus metadados como anexo da interação
+func (s *attachmentService) Upload(userID, interactionID uint, filename string, size int64, content io.Reader) (*models.Attachment, error) {
+	if err := s.checkInteractionOwnership(userID, interactionID); err != nil {
+		return nil, err
+	}
+
+	if size > s.maxUploadSize {
+		return nil, errors.NewAppError(http.StatusRequestEntityTooLarge, "Arquivo muito grande",
+			fmt.Sprintf("O tamanho máximo permitido é de %d bytes", s.maxUploadSize))
+	}
+
+	// Detectar o tipo do arquivo a partir do conteúdo (não confiar no cabeçalho do cliente)
+	header := make([]byte, 512)
+	n, err := io.ReadFull(content, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, errors.NewBadRequestError("Não foi possível ler o arquivo enviado")
+	}
+	header = header[:n]
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(header))
+	if err != nil || !AllowedAttachmentContentTypes[contentType] {
+		return nil, errors.NewBadRequestError("Tipo de arquivo não permitido")
+	}
+
+	storedName, err := generateStorageName(filename)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	if err := os.MkdirAll(s.uploadDir, 0o755); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	storagePath := filepath.Join(s.uploadDir, storedName)
+
+	written, err := s.writeFile(storagePath, io.MultiReader(bytes.NewReader(header), content))
+	if err != nil {
+		os.Remove(storagePath)
+		return nil, err
+	}
+
+	attachment := &models.Attachment{
+		InteractionID: interactionID,
+		Filename:      filepath.Base(filename),
+		ContentType:   contentType,
+		Size:          written,
+		StoragePath:   storagePath,
+	}
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		os.Remove(storagePath)
+		return nil, errors.ErrInternalServer
+	}
+
+	return attachment, nil
+}
+
+// GetByInteractionID lista os anexos de uma interação do usuário
+func (s *attachmentService) GetByInteractionID(userID, interactionID uint) ([]models.Attachment, error) {
+	if err := s.checkInteractionOwnership(userID, interactionID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetByInteractionID(interactionID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return attachments, nil
+}
+
+// checkInteractionOwnership verifica se a interação existe e pertence a um contato do usuário
+func (s *attachmentService) checkInteractionOwnership(userID, interactionID uint) error {
+	interaction, err := s.interactionRepo.GetByID(interactionID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewNotFoundError("Interação")
+		}
+		return errors.ErrInternalServer
+	}
+
+	if interaction.Contact.UserID != userID {
+		return s.access.ownershipError("Interação")
+	}
+
+	return nil
+}
+
+// writeFile grava o conteúdo em disco, respeitando o tamanho máximo de upload
+func (s *attachmentService) writeFile(path string, content io.Reader) (int64, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return 0, errors.ErrInternalServer
+	}
+	defer file.Close()
+
+	// Ler um byte além do limite para detectar arquivos maiores que o permitido
+	written, err := io.Copy(file, io.LimitReader(content, s.maxUploadSize+1))
+	if err != nil {
+		return 0, errors.ErrInternalServer
+	}
+	if written > s.maxUploadSize {
+		return 0, errors.NewAppError(http.StatusRequestEntityTooLarge, "Arquivo muito grande",
+			fmt.Sprintf("O tamanho máximo permitido é de %d bytes", s.maxUploadSize))
+	}
+
+	return written, nil
+}
+
+// generateStorageName gera um nome aleatório para o arquivo em disco, preservando a extensão original
+func generateStorageName(filename string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf) + strings.ToLower(filepath.Ext(filepath.Base(filename))), nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"crm-backend/pkg/logger"
+	"encoding/json"
+	"reflect"
+)
+
+// auditIgnoredFields são campos que mudam a cada gravação e não interessam ao log de auditoria
+var auditIgnoredFields = map[string]bool{
+	"id":         true,
+	"user_id":    true,
+	"version":    true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// AuditRecorder registra alterações de entidades no log de auditoria
+type AuditRecorder interface {
+	Record(userID uint, entityType models.AuditEntityType, entityID uint, action models.AuditAction, before, after interface{})
+}
+
+// AuditService define a interface para operações do log de auditoria
+type AuditService interface {
+	AuditRecorder
+	GetByUserID(userID uint, filter *models.AuditLogFilter) ([]models.AuditLog, error)
+}
+
+// auditService implementa AuditService
+type auditService struct {
+	auditLogRepo repositories.AuditLogRepository
+}
+
+// NewAuditService cria uma nova instância do serviço de auditoria
+func NewAuditService(auditLogRepo repositories.AuditLogRepository) AuditService {
+	return &auditService{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// recordAudit registra a alteração no log de auditoria (quando configurado)
+func recordAudit(recorder AuditRecorder, userID uint, entityType models.AuditEntityType, entityID uint, action models.AuditAction, before, after interface{}) {
+	if recorder == nil {
+		return
+	}
+	recorder.Record(userID, entityType, entityID, action, before, after)
+}
+
+// Record grava a alteração com os campos afetados: o estado criado ou restaurado, o estado excluído
+// ou removido definitivamente ou, em atualizações, apenas os campos alterados no formato
+// {"campo": {"old": ..., "new": ...}}.
+// Falhas são registradas no log sem interromper a operação de origem.
+func (s *auditService) Record(userID uint, entityType models.AuditEntityType, entityID uint, action models.AuditAction, before, after interface{}) {
+	var changes interface{}
+	switch action {
+	case models.AuditActionCreate, models.AuditActionRestore:
+		changes = auditFields(after)
+	case models.AuditActionDelete, models.AuditActionPurge:
+		changes = auditFields(before)
+	default:
+		changes = auditDiff(auditFields(before), auditFields(after))
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		logger.LogError(err, "Audit Log Serialization", map[string]interface{}{
+			"entity_type": entityType,
+			"entity_id":   entityID,
+		})
+		return
+	}
+
+	auditLog := &models.AuditLog{
+		UserID:      userID,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Action:      action,
+		ChangesJSON: string(changesJSON),
+	}
+	if err := s.auditLogRepo.Create(auditLog); err != nil {
+		logger.LogError(err, "Audit Log Write", map[string]interface{}{
+			"user_id":     userID,
+			"entity_type": entityType,
+			"entity_id":   entityID,
+			"action":      action,
+		})
+	}
+}
+
+// GetByUserID obtém os registros de auditoria das entidades do usuário
+func (s *auditService) GetByUserID(userID uint, filter *models.AuditLogFilter) ([]models.AuditLog, error) {
+	if filter == nil {
+		filter = &models.AuditLogFilter{}
+	}
+	filter.Limit = models.NormalizeLimit(filter.Limit)
+	if err := models.CheckOffset(filter.Offset); err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
+	}
+
+	auditLogs, err := s.auditLogRepo.GetByUserID(userID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return auditLogs, nil
+}
+
+// auditFields converte a entidade nos seus campos simples (como serializados em JSON),
+// ignorando relacionamentos e campos de controle
+func auditFields(entity interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if entity == nil {
+		return fields
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return fields
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fields
+	}
+
+	for key, value := range raw {
+		if auditIgnoredFields[key] {
+			continue
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			continue // Relacionamentos carregados
+		}
+		fields[key] = value
+	}
+	return fields
+}
+
+// auditDiff retorna apenas os campos com valores diferentes entre os dois estados
+func auditDiff(before, after map[string]interface{}) map[string]interface{} {
+	diff := map[string]interface{}{}
+	for key, newValue := range after {
+		if oldValue := before[key]; !reflect.DeepEqual(oldValue, newValue) {
+			diff[key] = map[string]interface{}{"old": oldValue, "new": newValue}
+		}
+	}
+	for key, oldValue := range before {
+		if _, ok := after[key]; !ok {
+			diff[key] = map[string]interface{}{"old": oldValue, "new": nil}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAuditServiceRecordChanges(t *testing.T) {
+	before := &models.Task{ID: 3, Title: "Antes", Priority: models.PriorityLow, Status: models.TaskStatusPending, Version: 1}
+	after := &models.Task{ID: 3, Title: "Depois", Priority: models.PriorityLow, Status: models.TaskStatusPending, Version: 2}
+
+	tests := []struct {
+		name   string
+		action models.AuditAction
+		before interface{}
+		after  interface{}
+		check  func(t *testing.T, changes map[string]interface{})
+	}{
+		{
+			name: "criação registra o estado criado", action: models.AuditActionCreate, after: after,
+			check: func(t *testing.T, changes map[string]interface{}) { wantField(t, changes, "title", "Depois") },
+		},
+		{
+			name: "restauração registra o estado restaurado", action: models.AuditActionRestore, after: after,
+			check: func(t *testing.T, changes map[string]interface{}) { wantField(t, changes, "title", "Depois") },
+		},
+		{
+			name: "exclusão registra o estado excluído", action: models.AuditActionDelete, before: before,
+			check: func(t *testing.T, changes map[string]interface{}) { wantField(t, changes, "title", "Antes") },
+		},
+		{
+			name: "remoção definitiva registra o estado removido", action: models.AuditActionPurge, before: before,
+			check: func(t *testing.T, changes map[string]interface{}) { wantField(t, changes, "title", "Antes") },
+		},
+		{
+			name: "atualização registra apenas os campos alterados", action: models.AuditActionUpdate, before: before, after: after,
+			check: func(t *testing.T, changes map[string]interface{}) {
+				want := map[string]interface{}{"title": map[string]interface{}{"old": "Antes", "new": "Depois"}}
+				if !reflect.DeepEqual(changes, want) {
+					t.Fatalf("changes = %v, want %v", changes, want)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAuditLogRepo{}
+			NewAuditService(repo).Record(7, models.AuditEntityTask, 3, tt.action, tt.before, tt.after)
+
+			if len(repo.logs) != 1 {
+				t.Fatalf("registros = %d, want 1", len(repo.logs))
+			}
+			if repo.logs[0].Action != tt.action {
+				t.Fatalf("action = %s, want %s", repo.logs[0].Action, tt.action)
+			}
+
+			var changes map[string]interface{}
+			if err := json.Unmarshal([]byte(repo.logs[0].ChangesJSON), &changes); err != nil {
+				t.Fatalf("changes_json inválido: %v", err)
+			}
+			if _, ok := changes["version"]; ok {
+				t.Error("campos de controle não deveriam ser registrados")
+			}
+			tt.check(t, changes)
+		})
+	}
+}
+
+// wantField verifica o valor de um campo registrado
+func wantField(t *testing.T, changes map[string]interface{}, field string, want interface{}) {
+	t.Helper()
+	if changes[field] != want {
+		t.Fatalf("%s = %v, want %v", field, changes[field], want)
+	}
+}
+
+func TestAuditServiceGetByUserIDRejectsLargeOffset(t *testing.T) {
+	// Com o máximo padrão (10000)
+	_, err := NewAuditService(&fakeAuditLogRepo{}).GetByUserID(7, &models.AuditLogFilter{Offset: 10001})
+	assertAppError(t, err, http.StatusBadRequest)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/pkg/errors"
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+)
+
+// Formatos suportados para o relatório de um contato
+const (
+	ReportFormatHTML = "html"
+	ReportFormatJSON = "json"
+)
+
+// reportDateLayout é o formato das datas no relatório HTML
+const reportDateLayout = "02/01/2006 15:04"
+
+//go:embed templates/contact_report.html
+var contactReportHTML string
+
+// contactReportTemplate é o modelo do relatório HTML de um contato. Cada relatório usa uma cópia,
+// com as funções no fuso horário do usuário (ver reportFuncs).
+var contactReportTemplate = template.Must(template.New("contact_report").
+	Funcs(reportFuncs(time.UTC)).
+	Parse(contactReportHTML))
+
+// ContactReport representa o relatório de um contato: perfil e estatísticas (no resumo) e as
+// interações mais recentes em ordem cronológica
+type ContactReport struct {
+	Summary      *ContactSummary      `json:"summary"`
+	Interactions []models.Interaction `json:"interactions"`
+	GeneratedAt  time.Time            `json:"generated_at"`
+}
+
+// ExportContactReport escreve em w o relatório de um contato do usuário no formato informado.
+// No HTML, as datas são exibidas no fuso horário do usuário.
+func (s *contactService) ExportContactReport(userID, contactID uint, format string, w io.Writer) error {
+	if format != ReportFormatHTML && format != ReportFormatJSON {
+		return errors.NewBadRequestError("Formato de relatório inválido. Use: html ou json")
+	}
+
+	details, err := s.GetWithDetails(userID, contactID)
+	if err != nil {
+		return err
+	}
+	summary, err := s.GetContactSummary(userID, contactID)
+	if err != nil {
+		return err
+	}
+
+	// As interações vêm da mais recente para a mais antiga
+	interactions := details.Interactions
+	sort.SliceStable(interactions, func(i, j int) bool {
+		return interactions[i].Date.Before(interactions[j].Date)
+	})
+
+	report := &ContactReport{
+		Summary:      summary,
+		Interactions: interactions,
+		GeneratedAt:  time.Now().UTC(),
+	}
+
+	if format == ReportFormatJSON {
+		return json.NewEncoder(w).Encode(report)
+	}
+
+	tmpl, err := contactReportTemplate.Clone()
+	if err != nil {
+		return errors.ErrInternalServer
+	}
+	return tmpl.Funcs(reportFuncs(userLocation(s.userRepo, userID))).Execute(w, report)
+}
+
+// reportFuncs retorna as funções do modelo do relatório: datetime formata uma data (time.Time ou
+// *time.Time) no fuso horário informado, com texto vazio para datas ausentes
+func reportFuncs(loc *time.Location) template.FuncMap {
+	return template.FuncMap{
+		"datetime": func(value interface{}) string {
+			switch t := value.(type) {
+			case time.Time:
+				return t.In(loc).Format(reportDateLayout)
+			case *time.Time:
+				if t != nil {
+					return t.In(loc).Format(reportDateLayout)
+				}
+			}
+			return ""
+		},
+	}
+}
@@ -1,24 +1,89 @@
 package services
 
 import (
+	"crm-backend/internal/events"
 	"crm-backend/internal/models"
 	"crm-backend/internal/repositories"
 	"crm-backend/pkg/errors"
+	"crm-backend/pkg/phone"
+	"crm-backend/pkg/validation"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"gorm.io/gorm"
 )
 
+// Formatos suportados para exportação de contatos
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+// exportBatchSize define quantos contatos são lidos do banco por vez durante a exportação
+const exportBatchSize = 500
+
+// ContactExport representa um contato no formato de exportação (sem relacionamentos)
+type ContactExport struct {
+	ID        uint               `json:"id"`
+	Name      string             `json:"name"`
+	Email     string             `json:"email"`
+	Phone     string             `json:"phone"`
+	Company   string             `json:"company"`
+	Position  string             `json:"position"`
+	Type      models.ContactType `json:"type"`
+	Notes     string             `json:"notes"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// newContactExport converte um contato para o formato de exportação
+func newContactExport(contact models.Contact) ContactExport {
+	return ContactExport{
+		ID:        contact.ID,
+		Name:      contact.Name,
+		Email:     contact.Email,
+		Phone:     contact.Phone,
+		Company:   contact.Company,
+		Position:  contact.Position,
+		Type:      contact.Type,
+		Notes:     contact.Notes,
+		CreatedAt: contact.CreatedAt,
+		UpdatedAt: contact.UpdatedAt,
+	}
+}
+
 // ContactService define a interface para operações de contato
 type ContactService interface {
 	Create(userID uint, req *models.ContactCreateRequest) (*models.Contact, error)
 	GetByID(userID, contactID uint) (*models.Contact, error)
 	GetWithDetails(userID, contactID uint) (*ContactDetails, error)
 	GetByUserID(userID uint, filter *models.ContactListFilter) ([]models.Contact, error)
+	GetPaginatedByUserID(userID uint, filter *models.ContactListFilter) (*models.PaginatedResponse[models.Contact], error)
+	GetChangedByUserID(userID uint, filter *models.ContactListFilter) (*models.PaginatedResponse[models.ChangedContact], error)
 	Update(userID, contactID uint, req *models.ContactUpdateRequest) (*models.Contact, error)
-	Delete(userID, contactID uint) error
+	Delete(userID, contactID uint, reassignTo *uint) error
+	Restore(userID, contactID uint, restoreInteractions bool) (*models.Contact, error)
+	PurgeContact(userID, contactID uint) error
+	GetDeletedByUserID(userID uint, filter *models.ContactListFilter) (*models.PaginatedResponse[models.DeletedContact], error)
 	SearchByName(userID uint, name string) ([]models.Contact, error)
 	GetContactSummary(userID, contactID uint) (*ContactSummary, error)
-	ConvertLeadToClient(userID, contactID uint) (*models.Contact, error)
+	ConvertLeadToClient(userID, contactID uint) (*models.Contact, bool, error)
+	BatchUpdateType(userID uint, req *models.ContactBatchTypeRequest) (*models.BatchResult, error)
+	ExportContacts(userID uint, filter *models.ContactListFilter, format string, w io.Writer) error
+	ExportContactReport(userID, contactID uint, format string, w io.Writer) error
+	AddTags(userID, contactID uint, req *models.ContactTagsRequest) ([]models.Tag, error)
+	RemoveTag(userID, contactID uint, tagName string) ([]models.Tag, error)
+	Touch(userID, contactID uint) (*models.Contact, error)
+	GetStaleClients(userID uint, days, limit int) ([]models.Contact, error)
+	FindDuplicates(userID uint) ([]models.ContactDuplicateGroup, error)
+	GetNeedingFollowUp(userID uint, days, limit int) ([]models.ContactFollowUp, error)
 }
 
 // ContactDetails representa detalhes completos de um contato
@@ -27,19 +92,23 @@ type ContactDetails struct {
 	Interactions []models.Interaction `json:"interactions"`
 	Tasks        []models.Task        `json:"tasks"`
 	Projects     []models.Project     `json:"projects"`
+	NextAction   *DashboardTask       `json:"next_action"` // Tarefa não concluída com o vencimento futuro mais próximo
 }
 
 // ContactSummary representa um resumo do contato
 type ContactSummary struct {
-	Contact             *models.Contact `json:"contact"`
-	TotalInteractions   int64           `json:"total_interactions"`
-	TotalTasks          int64           `json:"total_tasks"`
-	CompletedTasks      int64           `json:"completed_tasks"`
-	PendingTasks        int64           `json:"pending_tasks"`
-	TotalProjects       int64           `json:"total_projects"`
-	ActiveProjects      int64           `json:"active_projects"`
-	CompletedProjects   int64           `json:"completed_projects"`
-	LastInteractionDate *string         `json:"last_interaction_date"`
+	Contact               *models.Contact                     `json:"contact"`
+	TotalInteractions     int64                               `json:"total_interactions"`
+	InteractionsByType    map[models.InteractionType]int64    `json:"interactions_by_type"`
+	InteractionsByOutcome map[models.InteractionOutcome]int64 `json:"interactions_by_outcome"`
+	TotalTasks            int64                               `json:"total_tasks"`
+	CompletedTasks        int64                               `json:"completed_tasks"`
+	PendingTasks          int64                               `json:"pending_tasks"`
+	InProgressTasks       int64                               `json:"in_progress_tasks"`
+	TotalProjects         int64                               `json:"total_projects"`
+	ActiveProjects        int64                               `json:"active_projects"`
+	CompletedProjects     int64                               `json:"completed_projects"`
+	LastInteractionDate   *time.Time                          `json:"last_interaction_date"`
 }
 
 // contactService implementa ContactService
@@ -48,36 +117,78 @@ type contactService struct {
 	interactionRepo repositories.InteractionRepository
 	taskRepo        repositories.TaskRepository
 	projectRepo     repositories.ProjectRepository
+	tagRepo         repositories.TagRepository
+	userRepo        repositories.UserRepository
+	transactor      repositories.Transactor
+	publisher       events.Publisher
+	phoneRegion     string
+	maxContacts     int
+	auditor         AuditRecorder
+	access          AccessPolicy
 }
 
-// NewContactService cria uma nova instância do serviço de contatos
+// NewContactService cria uma nova instância do serviço de contatos.
+// phoneRegion é a região assumida para telefones informados sem código do país (ex.: BR) e
+// maxContacts o limite de contatos por usuário (0: sem limite).
 func NewContactService(
 	contactRepo repositories.ContactRepository,
 	interactionRepo repositories.InteractionRepository,
 	taskRepo repositories.TaskRepository,
 	projectRepo repositories.ProjectRepository,
+	tagRepo repositories.TagRepository,
+	userRepo repositories.UserRepository,
+	transactor repositories.Transactor,
+	publisher events.Publisher,
+	phoneRegion string,
+	maxContacts int,
+	auditor AuditRecorder,
+	access AccessPolicy,
 ) ContactService {
 	return &contactService{
 		contactRepo:     contactRepo,
 		interactionRepo: interactionRepo,
 		taskRepo:        taskRepo,
 		projectRepo:     projectRepo,
+		tagRepo:         tagRepo,
+		userRepo:        userRepo,
+		transactor:      transactor,
+		publisher:       publisher,
+		phoneRegion:     phoneRegion,
+		maxContacts:     maxContacts,
+		auditor:         auditor,
+		access:          access,
 	}
 }
 
 // Create cria um novo contato
 func (s *contactService) Create(userID uint, req *models.ContactCreateRequest) (*models.Contact, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	// Verificar o limite de contatos do usuário
+	if err := checkQuota(s.contactRepo.CountByUserID, userID, s.maxContacts, "contatos"); err != nil {
+		return nil, err
+	}
+
+	email := models.NormalizeEmail(req.Email)
+
 	// Verificar se já existe um contato com o mesmo email para este usuário
-	existingContact, err := s.contactRepo.GetByEmail(req.Email)
-	if err == nil && existingContact.UserID == userID {
+	_, err := s.contactRepo.GetByEmailAndUserID(email, userID)
+	if err == nil {
 		return nil, errors.NewConflictError("Já existe um contato com este email")
 	}
 
+	phoneNumber, err := s.normalizePhone(req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
 	// Criar contato
 	contact := &models.Contact{
 		Name:     req.Name,
-		Email:    req.Email,
-		Phone:    req.Phone,
+		Email:    email,
+		Phone:    phoneNumber,
 		Company:  req.Company,
 		Position: req.Position,
 		Type:     req.Type,
@@ -95,22 +206,26 @@ func (s *contactService) Create(userID uint, req *models.ContactCreateRequest) (
 		return nil, errors.ErrInternalServer
 	}
 
+	publishActivity(s.publisher, userID, createActivityFromContact(*createdContact), models.ActionCreated)
+	recordAudit(s.auditor, userID, models.AuditEntityContact, createdContact.ID, models.AuditActionCreate, nil, createdContact)
+
 	return createdContact, nil
 }
 
-// GetByID obtém um contato específico
-func (s *contactService) GetByID(userID, contactID uint) (*models.Contact, error) {
-	contact, err := s.contactRepo.GetByID(contactID)
+// normalizePhone padroniza o telefone informado (formato E.164 quando o país pode ser inferido)
+func (s *contactService) normalizePhone(raw string) (string, error) {
+	normalized, err := phone.Normalize(raw, s.phoneRegion)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Contato")
-		}
-		return nil, errors.ErrInternalServer
+		return "", errors.NewBadRequestError("Telefone inválido: informe apenas dígitos, espaços, hífens ou parênteses, com DDD ou código do país")
 	}
+	return normalized, nil
+}
 
-	// Verificar se o contato pertence ao usuário
-	if contact.UserID != userID {
-		return nil, errors.ErrForbidden
+// GetByID obtém um contato específico
+func (s *contactService) GetByID(userID, contactID uint) (*models.Contact, error) {
+	contact, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato")
+	if err != nil {
+		return nil, err
 	}
 
 	return contact, nil
@@ -146,6 +261,7 @@ func (s *contactService) GetWithDetails(userID, contactID uint) (*ContactDetails
 			return nil, errors.ErrInternalServer
 		}
 		details.Tasks = tasks
+		details.NextAction = nextAction(tasks, time.Now())
 	}
 
 	// Buscar projetos
@@ -160,14 +276,52 @@ func (s *contactService) GetWithDetails(userID, contactID uint) (*ContactDetails
 	return details, nil
 }
 
+// nextAction retorna a tarefa não concluída com o vencimento futuro mais próximo,
+// ou nil se nenhuma tarefa tiver vencimento após o momento informado
+func nextAction(tasks []models.Task, now time.Time) *DashboardTask {
+	var next *models.Task
+	for i := range tasks {
+		task := &tasks[i]
+		if task.Status == models.TaskStatusCompleted || task.DueDate == nil || !task.DueDate.After(now) {
+			continue
+		}
+		if next == nil || task.DueDate.Before(*next.DueDate) {
+			next = task
+		}
+	}
+	if next == nil {
+		return nil
+	}
+
+	action := &DashboardTask{
+		ID:       next.ID,
+		Title:    next.Title,
+		Priority: next.Priority,
+		DueDate:  next.DueDate,
+	}
+	if next.Contact != nil {
+		action.ContactName = next.Contact.Name
+	}
+	if next.Project != nil {
+		action.ProjectName = next.Project.Name
+	}
+	return action
+}
+
 // GetByUserID obtém todos os contatos do usuário
 func (s *contactService) GetByUserID(userID uint, filter *models.ContactListFilter) ([]models.Contact, error) {
 	// Aplicar valores padrão ao filtro se necessário
 	if filter == nil {
 		filter = &models.ContactListFilter{}
 	}
-	if filter.Limit == 0 {
-		filter.Limit = 50 // Limite padrão
+	filter.Limit = models.NormalizeLimit(filter.Limit)
+	if err := models.CheckOffset(filter.Offset); err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
+	}
+
+	// Validar ordenação antes de consultar (apenas colunas permitidas)
+	if _, err := repositories.OrderClause(filter.Sort, filter.Order, repositories.ContactSortColumns, ""); err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
 	}
 
 	contacts, err := s.contactRepo.GetByUserID(userID, filter)
@@ -178,26 +332,93 @@ func (s *contactService) GetByUserID(userID uint, filter *models.ContactListFilt
 	return contacts, nil
 }
 
+// GetPaginatedByUserID obtém os contatos do usuário com metadados de paginação
+func (s *contactService) GetPaginatedByUserID(userID uint, filter *models.ContactListFilter) (*models.PaginatedResponse[models.Contact], error) {
+	if filter == nil {
+		filter = &models.ContactListFilter{}
+	}
+
+	items, err := s.GetByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.contactRepo.CountByUserIDWithFilter(userID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return models.NewPaginatedResponse(items, total, filter.Limit, filter.Offset), nil
+}
+
+// GetChangedByUserID obtém os contatos do usuário alterados ou excluídos desde filter.ChangedSince,
+// indicando os excluídos, para sincronização incremental
+func (s *contactService) GetChangedByUserID(userID uint, filter *models.ContactListFilter) (*models.PaginatedResponse[models.ChangedContact], error) {
+	page, err := s.GetPaginatedByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.ChangedContact, len(page.Data))
+	for i, contact := range page.Data {
+		items[i] = models.ChangedContact{Contact: contact, ChangeState: models.NewChangeState(contact.DeletedAt)}
+	}
+
+	return models.NewPaginatedResponse(items, page.Total, page.Limit, page.Offset), nil
+}
+
+// GetDeletedByUserID obtém os contatos excluídos do usuário (lixeira) com metadados de paginação
+func (s *contactService) GetDeletedByUserID(userID uint, filter *models.ContactListFilter) (*models.PaginatedResponse[models.DeletedContact], error) {
+	if filter == nil {
+		filter = &models.ContactListFilter{}
+	}
+	filter.Limit = models.NormalizeLimit(filter.Limit)
+	if err := models.CheckOffset(filter.Offset); err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
+	}
+
+	contacts, err := s.contactRepo.GetDeletedByUserID(userID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	total, err := s.contactRepo.CountDeletedByUserID(userID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	items := make([]models.DeletedContact, len(contacts))
+	for i, contact := range contacts {
+		items[i] = models.DeletedContact{Contact: contact, DeletedAt: contact.DeletedAt.Time}
+	}
+
+	return models.NewPaginatedResponse(items, total, filter.Limit, filter.Offset), nil
+}
+
 // Update atualiza um contato existente
 func (s *contactService) Update(userID, contactID uint, req *models.ContactUpdateRequest) (*models.Contact, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
 	// Buscar contato existente
-	contact, err := s.contactRepo.GetByID(contactID)
+	contact, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato")
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Contato")
-		}
-		return nil, errors.ErrInternalServer
+		return nil, err
 	}
 
-	// Verificar se o contato pertence ao usuário
-	if contact.UserID != userID {
-		return nil, errors.ErrForbidden
+	// Verificar se o contato não foi alterado desde a leitura do cliente
+	if err := checkExpectedVersion(req.Version, contact.Version); err != nil {
+		return nil, err
 	}
 
+	before := *contact
+
 	// Verificar se o email está sendo alterado e se já existe
-	if req.Email != "" && req.Email != contact.Email {
-		existingContact, err := s.contactRepo.GetByEmail(req.Email)
-		if err == nil && existingContact.UserID == userID && existingContact.ID != contactID {
+	email := models.NormalizeEmail(req.Email)
+	if email != "" && email != models.NormalizeEmail(contact.Email) {
+		existingContact, err := s.contactRepo.GetByEmailAndUserID(email, userID)
+		if err == nil && existingContact.ID != contactID {
 			return nil, errors.NewConflictError("Já existe um contato com este email")
 		}
 	}
@@ -206,28 +427,38 @@ func (s *contactService) Update(userID, contactID uint, req *models.ContactUpdat
 	if req.Name != "" {
 		contact.Name = req.Name
 	}
-	if req.Email != "" {
-		contact.Email = req.Email
+	if email != "" {
+		contact.Email = email
 	}
-	if req.Phone != "" {
-		contact.Phone = req.Phone
+	if req.Phone != nil {
+		contact.Phone = ""
+		if *req.Phone != "" {
+			phoneNumber, err := s.normalizePhone(*req.Phone)
+			if err != nil {
+				return nil, err
+			}
+			contact.Phone = phoneNumber
+		}
 	}
-	if req.Company != "" {
-		contact.Company = req.Company
+	if req.Company != nil {
+		contact.Company = *req.Company
 	}
-	if req.Position != "" {
-		contact.Position = req.Position
+	if req.Position != nil {
+		contact.Position = *req.Position
 	}
 	if req.Type != "" {
+		if err := s.checkTypeChange(contact, req.Type); err != nil {
+			return nil, err
+		}
 		contact.Type = req.Type
 	}
-	if req.Notes != "" {
-		contact.Notes = req.Notes
+	if req.Notes != nil {
+		contact.Notes = *req.Notes
 	}
 
 	// Salvar alterações
 	if err := s.contactRepo.Update(contact); err != nil {
-		return nil, errors.ErrInternalServer
+		return nil, updateError(err)
 	}
 
 	// Buscar contato atualizado com relacionamentos
@@ -236,13 +467,135 @@ func (s *contactService) Update(userID, contactID uint, req *models.ContactUpdat
 		return nil, errors.ErrInternalServer
 	}
 
+	publishActivity(s.publisher, userID, createActivityFromContact(*updatedContact), models.ActionUpdated)
+	recordAudit(s.auditor, userID, models.AuditEntityContact, updatedContact.ID, models.AuditActionUpdate, &before, updatedContact)
+
 	return updatedContact, nil
 }
 
-// Delete exclui um contato
-func (s *contactService) Delete(userID, contactID uint) error {
+// checkTypeChange impede que um cliente com projetos volte a ser LEAD, já que projetos
+// só podem pertencer a contatos do tipo CLIENT
+func (s *contactService) checkTypeChange(contact *models.Contact, newType models.ContactType) error {
+	if contact.Type != models.ContactTypeClient || newType != models.ContactTypeLead || s.projectRepo == nil {
+		return nil
+	}
+
+	projects, err := s.projectRepo.CountByClientID(contact.ID)
+	if err != nil {
+		return errors.ErrInternalServer
+	}
+	if projects > 0 {
+		return errors.NewBadRequestError("Não é possível alterar para LEAD um cliente com projetos associados. Exclua ou transfira os projetos primeiro.")
+	}
+	return nil
+}
+
+// Delete exclui um contato. Se reassignTo for informado, as tarefas do contato são antes
+// transferidas para esse outro contato do usuário, na mesma transação da exclusão.
+func (s *contactService) Delete(userID, contactID uint, reassignTo *uint) error {
 	// Buscar contato existente
+	contact, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato")
+	if err != nil {
+		return err
+	}
+
+	// Verificar o contato que receberá as tarefas
+	if reassignTo != nil {
+		if *reassignTo == contactID {
+			return errors.NewBadRequestError("O contato de destino deve ser diferente do contato excluído")
+		}
+		if _, err := fetchOwned(s.access, s.contactRepo.GetByID, *reassignTo, userID, "Contato de destino"); err != nil {
+			return err
+		}
+	}
+
+	// Verificar projetos associados, transferir tarefas e excluir o contato em uma única transação
+	err = s.transactor.Transaction(func(tx *gorm.DB) error {
+		// Verificar se há projetos associados (apenas para clientes)
+		if contact.Type == models.ContactTypeClient && s.projectRepo != nil {
+			projects, err := s.projectRepo.WithTx(tx).GetByClientID(contactID)
+			if err != nil {
+				return errors.ErrInternalServer
+			}
+			if len(projects) > 0 {
+				return errors.NewBadRequestError("Não é possível excluir cliente com projetos associados. Exclua os projetos primeiro.")
+			}
+		}
+
+		if reassignTo != nil {
+			if _, err := s.taskRepo.WithTx(tx).ReassignContact(contactID, *reassignTo); err != nil {
+				return errors.ErrInternalServer
+			}
+		}
+
+		// Excluir contato (soft delete - GORM cuidará das relações)
+		if err := s.contactRepo.WithTx(tx).Delete(contactID); err != nil {
+			return errors.ErrInternalServer
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	publishActivity(s.publisher, userID, createActivityFromContact(*contact), models.ActionDeleted)
+	recordAudit(s.auditor, userID, models.AuditEntityContact, contact.ID, models.AuditActionDelete, contact, nil)
+
+	return nil
+}
+
+// Restore restaura um contato excluído do usuário, opcionalmente restaurando também suas interações excluídas
+func (s *contactService) Restore(userID, contactID uint, restoreInteractions bool) (*models.Contact, error) {
+	// Verificar se já existe um registro ativo com este ID
+	if live, err := s.contactRepo.GetByID(contactID); err == nil {
+		if live.UserID != userID {
+			return nil, errors.NewNotFoundError("Contato excluído")
+		}
+		return nil, errors.NewConflictError("Contato não está excluído")
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, errors.ErrInternalServer
+	}
+
+	// Buscar registro excluído (soft delete)
+	deleted, err := s.contactRepo.GetDeletedByID(contactID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("Contato excluído")
+		}
+		return nil, errors.ErrInternalServer
+	}
+
+	// Apenas o dono pode restaurar
+	if deleted.UserID != userID {
+		return nil, errors.NewNotFoundError("Contato excluído")
+	}
+
+	// Contatos excluídos não contam no limite: restaurar equivale a criar um novo
+	if err := checkQuota(s.contactRepo.CountByUserID, userID, s.maxContacts, "contatos"); err != nil {
+		return nil, err
+	}
+
+	if err := s.contactRepo.Restore(contactID, restoreInteractions); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	restored, err := s.contactRepo.GetByID(contactID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	recordAudit(s.auditor, userID, models.AuditEntityContact, restored.ID, models.AuditActionRestore, nil, restored)
+
+	return restored, nil
+}
+
+// PurgeContact remove definitivamente um contato (ativo ou excluído) e seus dados dependentes
+func (s *contactService) PurgeContact(userID, contactID uint) error {
+	// Buscar contato ativo ou, se não houver, excluído
 	contact, err := s.contactRepo.GetByID(contactID)
+	if err == gorm.ErrRecordNotFound {
+		contact, err = s.contactRepo.GetDeletedByID(contactID)
+	}
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return errors.NewNotFoundError("Contato")
@@ -250,27 +603,28 @@ func (s *contactService) Delete(userID, contactID uint) error {
 		return errors.ErrInternalServer
 	}
 
-	// Verificar se o contato pertence ao usuário
+	// Apenas o dono pode remover definitivamente
 	if contact.UserID != userID {
-		return errors.ErrForbidden
+		return errors.NewNotFoundError("Contato")
 	}
 
-	// Verificar se há projetos associados (apenas para clientes)
-	if contact.Type == models.ContactTypeClient && s.projectRepo != nil {
-		projects, err := s.projectRepo.GetByClientID(contactID)
+	// Projetos (mesmo excluídos) continuam referenciando o cliente
+	if s.projectRepo != nil {
+		projects, err := s.projectRepo.CountAllByClientID(contactID)
 		if err != nil {
 			return errors.ErrInternalServer
 		}
-		if len(projects) > 0 {
-			return errors.NewBadRequestError("Não é possível excluir cliente com projetos associados. Exclua os projetos primeiro.")
+		if projects > 0 {
+			return errors.NewConflictError("Não é possível remover definitivamente cliente com projetos associados")
 		}
 	}
 
-	// Excluir contato (soft delete - GORM cuidará das relações)
-	if err := s.contactRepo.Delete(contactID); err != nil {
+	if err := s.contactRepo.Purge(contactID); err != nil {
 		return errors.ErrInternalServer
 	}
 
+	recordAudit(s.auditor, userID, models.AuditEntityContact, contact.ID, models.AuditActionPurge, contact, nil)
+
 	return nil
 }
 
@@ -308,12 +662,24 @@ func (s *contactService) GetContactSummary(userID, contactID uint) (*ContactSumm
 		}
 		summary.TotalInteractions = interactionCount
 
+		interactionsByType, err := s.interactionRepo.CountByContactIDGroupedByType(contactID)
+		if err != nil {
+			return nil, errors.ErrInternalServer
+		}
+		summary.InteractionsByType = interactionsByType
+
+		interactionsByOutcome, err := s.interactionRepo.CountByContactIDGroupedByOutcome(contactID)
+		if err != nil {
+			return nil, errors.ErrInternalServer
+		}
+		summary.InteractionsByOutcome = interactionsByOutcome
+
 		// Buscar última interação para obter a data
 		interactions, err := s.interactionRepo.GetByContactID(contactID, &models.InteractionListFilter{
 			Limit: 1,
 		})
 		if err == nil && len(interactions) > 0 {
-			lastDate := interactions[0].Date.Format("2006-01-02 15:04:05")
+			lastDate := interactions[0].Date.UTC()
 			summary.LastInteractionDate = &lastDate
 		}
 	}
@@ -327,9 +693,12 @@ func (s *contactService) GetContactSummary(userID, contactID uint) (*ContactSumm
 
 		summary.TotalTasks = int64(len(tasks))
 		for _, task := range tasks {
-			if task.Status == models.TaskStatusCompleted {
+			switch task.Status {
+			case models.TaskStatusCompleted:
 				summary.CompletedTasks++
-			} else {
+			case models.TaskStatusInProgress:
+				summary.InProgressTasks++
+			default:
 				summary.PendingTasks++
 			}
 		}
@@ -356,40 +725,511 @@ func (s *contactService) GetContactSummary(userID, contactID uint) (*ContactSumm
 	return summary, nil
 }
 
-// ConvertLeadToClient converte um lead em cliente
-func (s *contactService) ConvertLeadToClient(userID, contactID uint) (*models.Contact, error) {
+// ConvertLeadToClient converte um lead em cliente. A operação é idempotente: um contato que já é
+// cliente é retornado sem alterações, com true no segundo retorno (a data de conversão só é
+// registrada para leads).
+func (s *contactService) ConvertLeadToClient(userID, contactID uint) (*models.Contact, bool, error) {
 	// Buscar contato existente
-	contact, err := s.contactRepo.GetByID(contactID)
+	contact, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato")
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Contato")
-		}
-		return nil, errors.ErrInternalServer
+		return nil, false, err
 	}
 
-	// Verificar se o contato pertence ao usuário
-	if contact.UserID != userID {
-		return nil, errors.ErrForbidden
+	if contact.Type == models.ContactTypeClient {
+		return contact, true, nil
 	}
 
-	// Verificar se é um lead
-	if contact.Type != models.ContactTypeLead {
-		return nil, errors.NewBadRequestError("Apenas leads podem ser convertidos em clientes")
-	}
+	before := *contact
 
-	// Converter para cliente
+	// Converter para cliente, registrando o momento da conversão
+	now := time.Now()
 	contact.Type = models.ContactTypeClient
+	contact.ConvertedAt = &now
 
 	// Salvar alterações
 	if err := s.contactRepo.Update(contact); err != nil {
-		return nil, errors.ErrInternalServer
+		return nil, false, updateError(err)
 	}
 
 	// Buscar contato atualizado
 	updatedContact, err := s.contactRepo.GetByID(contact.ID)
 	if err != nil {
+		return nil, false, errors.ErrInternalServer
+	}
+
+	publishActivity(s.publisher, userID, createActivityFromContact(*updatedContact), models.ActionUpdated)
+	recordAudit(s.auditor, userID, models.AuditEntityContact, updatedContact.ID, models.AuditActionUpdate, &before, updatedContact)
+
+	return updatedContact, false, nil
+}
+
+// BatchUpdateType altera o tipo de vários contatos do usuário em uma única transação. IDs
+// inexistentes, de outros usuários, já do tipo informado ou de clientes com projetos (quando o
+// novo tipo é LEAD) são ignorados. Leads convertidos em clientes têm a data de conversão registrada.
+func (s *contactService) BatchUpdateType(userID uint, req *models.ContactBatchTypeRequest) (*models.BatchResult, error) {
+	if !req.Type.IsValid() {
+		return nil, errors.NewBadRequestError("Tipo inválido. Valores aceitos: CLIENT, LEAD")
+	}
+	if len(req.IDs) > models.MaxContactBatchSize {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("Informe no máximo %d contatos por vez", models.MaxContactBatchSize))
+	}
+
+	result := &models.BatchResult{Updated: []uint{}, Skipped: []uint{}}
+	var befores, updated []models.Contact
+
+	err := s.transactor.Transaction(func(tx *gorm.DB) error {
+		contactRepo := s.contactRepo.WithTx(tx)
+		seen := make(map[uint]bool, len(req.IDs))
+
+		for _, id := range req.IDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			contact, err := contactRepo.GetByID(id)
+			if err != nil {
+				if err == gorm.ErrRecordNotFound {
+					result.Skipped = append(result.Skipped, id)
+					continue
+				}
+				return errors.ErrInternalServer
+			}
+			if contact.UserID != userID || contact.Type == req.Type {
+				result.Skipped = append(result.Skipped, id)
+				continue
+			}
+
+			if req.Type == models.ContactTypeLead && s.projectRepo != nil {
+				projects, err := s.projectRepo.WithTx(tx).CountByClientID(id)
+				if err != nil {
+					return errors.ErrInternalServer
+				}
+				if projects > 0 {
+					result.Skipped = append(result.Skipped, id)
+					continue
+				}
+			}
+
+			before := *contact
+			if contact.Type == models.ContactTypeLead && req.Type == models.ContactTypeClient {
+				now := time.Now()
+				contact.ConvertedAt = &now
+			}
+			contact.Type = req.Type
+
+			if err := contactRepo.Update(contact); err != nil {
+				return updateError(err)
+			}
+
+			result.Updated = append(result.Updated, id)
+			befores = append(befores, before)
+			updated = append(updated, *contact)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range updated {
+		publishActivity(s.publisher, userID, createActivityFromContact(updated[i]), models.ActionUpdated)
+		recordAudit(s.auditor, userID, models.AuditEntityContact, updated[i].ID, models.AuditActionUpdate, &befores[i], &updated[i])
+	}
+
+	return result, nil
+}
+
+// Touch registra um contato rápido (sem interação), atualizando a data do último contato para agora
+func (s *contactService) Touch(userID, contactID uint) (*models.Contact, error) {
+	before, err := s.GetByID(userID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.contactRepo.TouchLastContacted(contactID, time.Now()); err != nil {
 		return nil, errors.ErrInternalServer
 	}
 
-	return updatedContact, nil
+	touched, err := s.GetByID(userID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(s.auditor, userID, models.AuditEntityContact, touched.ID, models.AuditActionUpdate, before, touched)
+
+	return touched, nil
+}
+
+// GetStaleClients obtém os clientes do usuário sem contato há pelo menos o número de dias informado
+func (s *contactService) GetStaleClients(userID uint, days, limit int) ([]models.Contact, error) {
+	if days <= 0 {
+		return nil, errors.NewBadRequestError("O número de dias deve ser maior que zero")
+	}
+
+	before := time.Now().AddDate(0, 0, -days)
+	contacts, err := s.contactRepo.GetStaleClients(userID, before, models.NormalizeLimit(limit))
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return contacts, nil
+}
+
+// GetNeedingFollowUp lista os contatos do usuário que precisam de acompanhamento: os que têm tarefas
+// não concluídas vencendo até o fim de hoje (no fuso horário do usuário) e os leads sem interação há
+// pelo menos days dias (leads cadastrados há menos tempo não contam). Os contatos com tarefas vencidas
+// vêm primeiro, com mais tarefas antes; em seguida, os leads há mais tempo sem interação.
+func (s *contactService) GetNeedingFollowUp(userID uint, days, limit int) ([]models.ContactFollowUp, error) {
+	if days <= 0 {
+		return nil, errors.NewBadRequestError("O número de dias deve ser maior que zero")
+	}
+
+	now := time.Now()
+	dueCounts, err := s.taskRepo.CountOpenDueByContact(userID, endOfDay(now.In(userLocation(s.userRepo, userID))))
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	// Leads parados vêm depois dos contatos com tarefas e na mesma ordem do repositório, então só
+	// os limit primeiros podem entrar no resultado
+	cutoff := now.AddDate(0, 0, -days)
+	limit = models.NormalizeLimit(limit)
+	leads, err := s.contactRepo.GetStaleLeads(userID, cutoff, now, limit)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	lastInteractions, err := s.interactionRepo.GetLastDateByContact(userID, now)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	byID := make(map[uint]*models.ContactFollowUp)
+	for _, lead := range leads {
+		followUp := &models.ContactFollowUp{Contact: lead}
+		if last, ok := lastInteractions[lead.ID]; ok {
+			followUp.LastInteractionAt = &last
+		}
+		followUp.Reasons = append(followUp.Reasons, models.FollowUpReasonStaleLead)
+		byID[lead.ID] = followUp
+	}
+
+	// Contatos com tarefas vencidas que ainda não foram carregados como leads
+	missing := make([]uint, 0, len(dueCounts))
+	for contactID := range dueCounts {
+		if _, ok := byID[contactID]; !ok {
+			missing = append(missing, contactID)
+		}
+	}
+	others, err := s.contactRepo.GetByIDs(userID, missing)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	for _, contact := range others {
+		followUp := &models.ContactFollowUp{Contact: contact}
+		if last, ok := lastInteractions[contact.ID]; ok {
+			followUp.LastInteractionAt = &last
+		}
+		// Um lead parado fora dos limit primeiros ainda entra por causa das tarefas
+		if contact.Type == models.ContactTypeLead && contact.CreatedAt.Before(cutoff) &&
+			(followUp.LastInteractionAt == nil || followUp.LastInteractionAt.Before(cutoff)) {
+			followUp.Reasons = append(followUp.Reasons, models.FollowUpReasonStaleLead)
+		}
+		byID[contact.ID] = followUp
+	}
+	for contactID, count := range dueCounts {
+		if followUp, ok := byID[contactID]; ok {
+			followUp.DueTasks = count
+			followUp.Reasons = append([]models.FollowUpReason{models.FollowUpReasonTaskDue}, followUp.Reasons...)
+		}
+	}
+
+	followUps := make([]models.ContactFollowUp, 0, len(byID))
+	for _, followUp := range byID {
+		followUps = append(followUps, *followUp)
+	}
+	sort.Slice(followUps, func(i, j int) bool {
+		a, b := followUps[i], followUps[j]
+		if a.DueTasks != b.DueTasks {
+			return a.DueTasks > b.DueTasks
+		}
+		if at, bt := lastFollowUpActivity(a), lastFollowUpActivity(b); !at.Equal(bt) {
+			return at.Before(bt)
+		}
+		return a.Contact.ID < b.Contact.ID
+	})
+
+	if len(followUps) > limit {
+		followUps = followUps[:limit]
+	}
+
+	return followUps, nil
+}
+
+// lastFollowUpActivity retorna o momento da última atividade conhecida do contato: a última
+// interação ou, se não houver, o cadastro
+func lastFollowUpActivity(followUp models.ContactFollowUp) time.Time {
+	if followUp.LastInteractionAt != nil {
+		return *followUp.LastInteractionAt
+	}
+	return followUp.Contact.CreatedAt
+}
+
+// FindDuplicates agrupa os contatos do usuário que provavelmente são a mesma pessoa: os que têm o
+// mesmo e-mail e os que têm o mesmo nome e a mesma empresa após normalização (ver duplicateKey).
+// Um contato pode aparecer em um grupo de cada critério.
+func (s *contactService) FindDuplicates(userID uint) ([]models.ContactDuplicateGroup, error) {
+	groups := make([]models.ContactDuplicateGroup, 0)
+
+	// Contatos chegam ordenados por e-mail, então cada grupo é uma sequência contígua
+	byEmail, err := s.contactRepo.GetWithDuplicateEmails(userID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	for _, contact := range byEmail {
+		email := strings.ToLower(contact.Email)
+		if last := len(groups) - 1; last >= 0 && groups[last].Key == email {
+			groups[last].ContactIDs = append(groups[last].ContactIDs, contact.ID)
+			continue
+		}
+		groups = append(groups, models.ContactDuplicateGroup{
+			Reason:     models.DuplicateReasonEmail,
+			Key:        email,
+			ContactIDs: []uint{contact.ID},
+		})
+	}
+
+	contacts, err := s.contactRepo.GetNamesByUserID(userID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	var keys []string
+	byName := make(map[string][]uint)
+	for _, contact := range contacts {
+		key := duplicateKey(contact.Name, contact.Company)
+		if key == "" {
+			continue
+		}
+		if _, seen := byName[key]; !seen {
+			keys = append(keys, key)
+		}
+		byName[key] = append(byName[key], contact.ID)
+	}
+	for _, key := range keys {
+		if len(byName[key]) > 1 {
+			groups = append(groups, models.ContactDuplicateGroup{
+				Reason:     models.DuplicateReasonName,
+				Key:        key,
+				ContactIDs: byName[key],
+			})
+		}
+	}
+
+	return groups, nil
+}
+
+// companySuffixes são sufixos societários ignorados na comparação de empresas
+var companySuffixes = map[string]bool{
+	"ltda": true, "sa": true, "me": true, "epp": true, "eireli": true,
+	"inc": true, "llc": true, "ltd": true, "corp": true, "co": true,
+}
+
+// duplicateKey retorna a chave de comparação de nome e empresa de um contato, vazia quando o
+// nome não tem letras ou dígitos. As palavras do nome são ordenadas ("Silva, Maria" equivale a
+// "Maria Silva") e os sufixos societários da empresa são descartados ("Acme Ltda." equivale a "ACME").
+func duplicateKey(name, company string) string {
+	nameWords := normalizeWords(name)
+	if len(nameWords) == 0 {
+		return ""
+	}
+	sort.Strings(nameWords)
+
+	var companyWords []string
+	for _, word := range normalizeWords(company) {
+		if !companySuffixes[word] {
+			companyWords = append(companyWords, word)
+		}
+	}
+
+	key := strings.Join(nameWords, " ")
+	if len(companyWords) > 0 {
+		key += " @ " + strings.Join(companyWords, " ")
+	}
+	return key
+}
+
+// accentFolding mapeia letras acentuadas para a letra sem acento
+var accentFolding = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// normalizeWords converte o texto para minúsculas sem acentos e o divide em palavras. Pontos e
+// barras são removidos ("S.A." e "S/A" viram "sa"); os demais caracteres que não são letras ou
+// dígitos separam palavras.
+func normalizeWords(value string) []string {
+	value = accentFolding.Replace(strings.ToLower(value))
+	value = strings.NewReplacer(".", "", "/", "").Replace(value)
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// AddTags adiciona etiquetas ao contato, criando as que o usuário ainda não possui.
+// Retorna as etiquetas do contato após a alteração.
+func (s *contactService) AddTags(userID, contactID uint, req *models.ContactTagsRequest) ([]models.Tag, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetByID(userID, contactID); err != nil {
+		return nil, err
+	}
+
+	names := models.NormalizeTagNames(req.Tags)
+	if len(names) == 0 {
+		return nil, errors.NewBadRequestError("Informe ao menos uma etiqueta válida")
+	}
+	for _, name := range names {
+		if len([]rune(name)) > models.MaxTagNameLength {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("Etiquetas devem ter no máximo %d caracteres", models.MaxTagNameLength))
+		}
+	}
+
+	tags, err := s.tagRepo.FindOrCreateByNames(userID, names)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	if err := s.tagRepo.AddToContact(contactID, tags); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return s.contactTags(contactID)
+}
+
+// RemoveTag remove a etiqueta do contato (a etiqueta continua disponível para outros contatos).
+// Retorna as etiquetas do contato após a alteração.
+func (s *contactService) RemoveTag(userID, contactID uint, tagName string) ([]models.Tag, error) {
+	if _, err := s.GetByID(userID, contactID); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.tagRepo.GetByName(userID, models.NormalizeTagName(tagName))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("Etiqueta")
+		}
+		return nil, errors.ErrInternalServer
+	}
+	if err := s.tagRepo.RemoveFromContact(contactID, tag); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return s.contactTags(contactID)
+}
+
+// contactTags busca as etiquetas atuais do contato
+func (s *contactService) contactTags(contactID uint) ([]models.Tag, error) {
+	tags, err := s.tagRepo.GetByContactID(contactID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	return tags, nil
+}
+
+// ExportContacts escreve todos os contatos do usuário (respeitando os filtros) no formato solicitado
+func (s *contactService) ExportContacts(userID uint, filter *models.ContactListFilter, format string, w io.Writer) error {
+	// Exportação ignora paginação
+	exportFilter := &models.ContactListFilter{}
+	if filter != nil {
+		exportFilter.Type = filter.Type
+		exportFilter.Search = filter.Search
+		exportFilter.Company = filter.Company
+		exportFilter.HasProjects = filter.HasProjects
+		exportFilter.Tags = filter.Tags
+	}
+
+	switch format {
+	case ExportFormatCSV:
+		return s.exportContactsCSV(userID, exportFilter, w)
+	case ExportFormatJSON:
+		return s.exportContactsJSON(userID, exportFilter, w)
+	default:
+		return errors.NewBadRequestError("Formato de exportação inválido. Use: csv ou json")
+	}
+}
+
+// exportContactsCSV escreve os contatos em CSV, lote a lote
+func (s *contactService) exportContactsCSV(userID uint, filter *models.ContactListFilter, w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"id", "name", "email", "phone", "company", "position", "type", "notes", "created_at", "updated_at"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	err := s.contactRepo.StreamByUserID(userID, filter, exportBatchSize, func(batch []models.Contact) error {
+		for _, contact := range batch {
+			record := []string{
+				strconv.FormatUint(uint64(contact.ID), 10),
+				contact.Name,
+				contact.Email,
+				contact.Phone,
+				contact.Company,
+				contact.Position,
+				string(contact.Type),
+				contact.Notes,
+				contact.CreatedAt.Format(time.RFC3339),
+				contact.UpdatedAt.Format(time.RFC3339),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// exportContactsJSON escreve os contatos como um array JSON, lote a lote
+func (s *contactService) exportContactsJSON(userID uint, filter *models.ContactListFilter, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	first := true
+	err := s.contactRepo.StreamByUserID(userID, filter, exportBatchSize, func(batch []models.Contact) error {
+		for _, contact := range batch {
+			data, err := json.Marshal(newContactExport(contact))
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]")
+	return err
 }
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContactServiceGetNeedingFollowUp(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	contactID := func(id uint) *uint { return &id }
+	yesterday := daysAgo(1)
+
+	contactRepo := newFakeContactRepo(
+		models.Contact{ID: 1, UserID: 7, Type: models.ContactTypeLead, CreatedAt: daysAgo(100)},
+		models.Contact{ID: 2, UserID: 7, Type: models.ContactTypeLead, CreatedAt: daysAgo(90)},
+		models.Contact{ID: 3, UserID: 7, Type: models.ContactTypeLead, CreatedAt: daysAgo(80)},
+		models.Contact{ID: 4, UserID: 7, Type: models.ContactTypeLead, CreatedAt: daysAgo(80)},
+		models.Contact{ID: 5, UserID: 7, Type: models.ContactTypeClient, CreatedAt: daysAgo(200)},
+		models.Contact{ID: 6, UserID: 7, Type: models.ContactTypeLead, CreatedAt: daysAgo(10)},
+		models.Contact{ID: 7, UserID: 7, Type: models.ContactTypeLead, CreatedAt: daysAgo(70)},
+		models.Contact{ID: 8, UserID: 8, Type: models.ContactTypeLead, CreatedAt: daysAgo(300)},
+	)
+	lastDates := map[uint]time.Time{2: daysAgo(60), 3: daysAgo(40), 4: daysAgo(5), 7: daysAgo(50)}
+	contactRepo.lastInteractions = lastDates
+	taskRepo := newFakeTaskRepo(
+		models.Task{ID: 1, UserID: 7, ContactID: contactID(5), Status: models.TaskStatusPending, DueDate: &yesterday},
+		models.Task{ID: 2, UserID: 7, ContactID: contactID(5), Status: models.TaskStatusInProgress, DueDate: &yesterday},
+		models.Task{ID: 3, UserID: 7, ContactID: contactID(3), Status: models.TaskStatusPending, DueDate: &yesterday},
+		models.Task{ID: 4, UserID: 7, ContactID: contactID(4), Status: models.TaskStatusCompleted, DueDate: &yesterday},
+	)
+	interactionRepo := &fakeInteractionRepo{lastDates: lastDates}
+	service := NewContactService(contactRepo, interactionRepo, taskRepo, nil, nil, nil, nil, nil, "BR", 0, &fakeAuditor{}, AccessPolicy{})
+
+	tests := []struct {
+		name    string
+		limit   int
+		wantIDs []uint
+	}{
+		// O lead 3 fica fora dos leads parados carregados, mas entra pelas tarefas e mantém os dois motivos
+		{name: "limite menor que o total", limit: 3, wantIDs: []uint{5, 3, 1}},
+		{name: "todos", limit: 10, wantIDs: []uint{5, 3, 1, 2, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			followUps, err := service.GetNeedingFollowUp(7, 30, tt.limit)
+			if err != nil {
+				t.Fatalf("GetNeedingFollowUp: %v", err)
+			}
+			if contactRepo.staleLeadsLimit != tt.limit {
+				t.Errorf("limite repassado ao repositório = %d, want %d", contactRepo.staleLeadsLimit, tt.limit)
+			}
+
+			ids := make([]uint, 0, len(followUps))
+			reasons := map[uint]string{}
+			for _, followUp := range followUps {
+				ids = append(ids, followUp.Contact.ID)
+				reasons[followUp.Contact.ID] = fmt.Sprint(followUp.Reasons)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Fatalf("contatos = %v, want %v", ids, tt.wantIDs)
+			}
+			if reasons[5] != "[TASK_DUE]" || reasons[3] != "[TASK_DUE STALE_LEAD]" || reasons[1] != "[STALE_LEAD]" {
+				t.Errorf("motivos inesperados: %v", reasons)
+			}
+		})
+	}
+}
+
+func TestContactServicePurgeContact(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uint
+		contactID  uint
+		wantStatus int // 0: removido
+	}{
+		{name: "contato ativo", userID: 7, contactID: 1},
+		{name: "contato na lixeira", userID: 7, contactID: 2},
+		{name: "contato de outro usuário", userID: 8, contactID: 1, wantStatus: http.StatusNotFound},
+		{name: "contato inexistente", userID: 7, contactID: 99, wantStatus: http.StatusNotFound},
+		{name: "cliente com projeto excluído", userID: 7, contactID: 3, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contactRepo := newFakeContactRepo(
+				models.Contact{ID: 1, UserID: 7, Type: models.ContactTypeLead},
+				models.Contact{ID: 3, UserID: 7, Type: models.ContactTypeClient},
+			)
+			contactRepo.deleted[2] = models.Contact{ID: 2, UserID: 7, Type: models.ContactTypeLead}
+			projectRepo := newFakeProjectRepo()
+			projectRepo.deleted[5] = models.Project{ID: 5, UserID: 7, ClientID: 3}
+			auditor := &fakeAuditor{}
+			service := NewContactService(contactRepo, nil, nil, projectRepo, nil, nil, nil, nil, "BR", 0, auditor, AccessPolicy{})
+
+			err := service.PurgeContact(tt.userID, tt.contactID)
+			if tt.wantStatus != 0 {
+				assertAppError(t, err, tt.wantStatus)
+				if len(contactRepo.purged) != 0 || len(auditor.entries) != 0 {
+					t.Fatalf("nada deveria ser removido ou auditado: %v %+v", contactRepo.purged, auditor.entries)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PurgeContact: %v", err)
+			}
+
+			if len(contactRepo.purged) != 1 || contactRepo.purged[0] != tt.contactID {
+				t.Fatalf("removidos = %v, want [%d]", contactRepo.purged, tt.contactID)
+			}
+			if actions := auditor.actions(models.AuditEntityContact); len(actions) != 1 || actions[0] != models.AuditActionPurge {
+				t.Fatalf("auditoria = %v, want [PURGE]", actions)
+			}
+		})
+	}
+}
+
+func TestContactServiceEmailScopedToUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uint
+		contactID  uint // 0: criação
+		email      string
+		wantStatus int // 0: sucesso
+	}{
+		{name: "criar com email de contato de outro usuário", userID: 8, email: "ana@example.com"},
+		{name: "criar com email já usado pelo usuário", userID: 7, email: "Ana@Example.com", wantStatus: http.StatusConflict},
+		{name: "criar com email novo", userID: 7, email: "bia@example.com"},
+		{name: "atualizar para email de contato de outro usuário", userID: 8, contactID: 2, email: "ana@example.com"},
+		{name: "atualizar para email já usado pelo usuário", userID: 7, contactID: 3, email: "ana@example.com", wantStatus: http.StatusConflict},
+		{name: "atualizar mantendo o próprio email", userID: 7, contactID: 1, email: "ANA@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contactRepo := newFakeContactRepo(
+				models.Contact{ID: 1, UserID: 7, Name: "Ana", Email: "ana@example.com", Type: models.ContactTypeLead},
+				models.Contact{ID: 2, UserID: 8, Name: "Caio", Email: "caio@example.com", Type: models.ContactTypeLead},
+				models.Contact{ID: 3, UserID: 7, Name: "Duda", Email: "duda@example.com", Type: models.ContactTypeLead},
+			)
+			service := NewContactService(contactRepo, nil, nil, nil, nil, nil, nil, nil, "BR", 0, &fakeAuditor{}, AccessPolicy{})
+
+			var (
+				contact *models.Contact
+				err     error
+			)
+			if tt.contactID == 0 {
+				contact, err = service.Create(tt.userID, &models.ContactCreateRequest{
+					Name:  "Novo contato",
+					Email: tt.email,
+					Type:  models.ContactTypeLead,
+				})
+			} else {
+				contact, err = service.Update(tt.userID, tt.contactID, &models.ContactUpdateRequest{Email: tt.email})
+			}
+
+			if tt.wantStatus != 0 {
+				assertAppError(t, err, tt.wantStatus)
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v, want nil", err)
+			}
+			if contact.UserID != tt.userID {
+				t.Errorf("UserID = %d, want %d", contact.UserID, tt.userID)
+			}
+			if contact.Email != strings.ToLower(tt.email) {
+				t.Errorf("Email = %q, want %q", contact.Email, strings.ToLower(tt.email))
+			}
+		})
+	}
+}
+
+func TestNextAction(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		date := now.Add(d)
+		return &date
+	}
+
+	tests := []struct {
+		name   string
+		tasks  []models.Task
+		wantID uint // 0: sem próxima ação
+	}{
+		{name: "sem tarefas"},
+		{
+			name: "apenas tarefas vencidas e sem vencimento",
+			tasks: []models.Task{
+				{ID: 1, Status: models.TaskStatusPending, DueDate: at(-time.Hour)},
+				{ID: 2, Status: models.TaskStatusPending},
+			},
+		},
+		{
+			name: "vencimento futuro mais próximo",
+			tasks: []models.Task{
+				{ID: 1, Status: models.TaskStatusPending, DueDate: at(-time.Hour)},
+				{ID: 2, Status: models.TaskStatusPending, DueDate: at(72 * time.Hour)},
+				{ID: 3, Status: models.TaskStatusInProgress, DueDate: at(24 * time.Hour)},
+				{ID: 4, Status: models.TaskStatusPending},
+			},
+			wantID: 3,
+		},
+		{
+			name: "tarefa concluída é ignorada",
+			tasks: []models.Task{
+				{ID: 1, Status: models.TaskStatusCompleted, DueDate: at(time.Hour)},
+				{ID: 2, Status: models.TaskStatusPending, DueDate: at(48 * time.Hour)},
+			},
+			wantID: 2,
+		},
+		{
+			name:  "vencimento exatamente agora não é futuro",
+			tasks: []models.Task{{ID: 1, Status: models.TaskStatusPending, DueDate: at(0)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := nextAction(tt.tasks, now)
+			if tt.wantID == 0 {
+				if action != nil {
+					t.Fatalf("nextAction = tarefa %d, want nil", action.ID)
+				}
+				return
+			}
+			if action == nil || action.ID != tt.wantID {
+				t.Fatalf("nextAction = %+v, want tarefa %d", action, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestContactServiceUpdateTypeChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		contactID  uint
+		newType    models.ContactType
+		wantStatus int // 0: sucesso
+	}{
+		{name: "cliente com projetos rebaixado a lead", contactID: 1, newType: models.ContactTypeLead, wantStatus: http.StatusBadRequest},
+		{name: "cliente sem projetos rebaixado a lead", contactID: 2, newType: models.ContactTypeLead},
+		{name: "cliente com projetos mantido como cliente", contactID: 1, newType: models.ContactTypeClient},
+		{name: "lead promovido a cliente", contactID: 3, newType: models.ContactTypeClient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contactRepo := newFakeContactRepo(
+				models.Contact{ID: 1, UserID: 7, Name: "Ana", Email: "ana@example.com", Type: models.ContactTypeClient},
+				models.Contact{ID: 2, UserID: 7, Name: "Bia", Email: "bia@example.com", Type: models.ContactTypeClient},
+				models.Contact{ID: 3, UserID: 7, Name: "Caio", Email: "caio@example.com", Type: models.ContactTypeLead},
+			)
+			projectRepo := newFakeProjectRepo(models.Project{ID: 10, UserID: 7, ClientID: 1})
+			service := NewContactService(contactRepo, nil, nil, projectRepo, nil, nil, nil, nil, "BR", 0, &fakeAuditor{}, AccessPolicy{})
+
+			contact, err := service.Update(7, tt.contactID, &models.ContactUpdateRequest{Type: tt.newType})
+			if tt.wantStatus != 0 {
+				assertAppError(t, err, tt.wantStatus)
+				if stored := contactRepo.contacts[tt.contactID].Type; stored != models.ContactTypeClient {
+					t.Errorf("tipo armazenado = %s, want inalterado", stored)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+			if contact.Type != tt.newType {
+				t.Errorf("Type = %s, want %s", contact.Type, tt.newType)
+			}
+		})
+	}
+}
+
+func TestContactServiceConvertLeadToClientIsIdempotent(t *testing.T) {
+	contactRepo := newFakeContactRepo(
+		models.Contact{ID: 1, UserID: 7, Name: "Ana", Email: "ana@example.com", Type: models.ContactTypeLead},
+		models.Contact{ID: 2, UserID: 7, Name: "Bia", Email: "bia@example.com", Type: models.ContactTypeClient},
+	)
+	auditor := &fakeAuditor{}
+	service := NewContactService(contactRepo, nil, nil, nil, nil, nil, nil, nil, "BR", 0, auditor, AccessPolicy{})
+
+	converted, alreadyClient, err := service.ConvertLeadToClient(7, 1)
+	if err != nil {
+		t.Fatalf("primeira conversão: %v", err)
+	}
+	if alreadyClient || converted.Type != models.ContactTypeClient || converted.ConvertedAt == nil {
+		t.Fatalf("primeira conversão = tipo %s, convertido em %v, já cliente %v; want CLIENT com data e já cliente false",
+			converted.Type, converted.ConvertedAt, alreadyClient)
+	}
+	convertedAt := *converted.ConvertedAt
+
+	// Repetir a conversão (ex.: nova tentativa de uma integração) não altera o contato
+	again, alreadyClient, err := service.ConvertLeadToClient(7, 1)
+	if err != nil {
+		t.Fatalf("segunda conversão: %v", err)
+	}
+	if !alreadyClient || again.ConvertedAt == nil || !again.ConvertedAt.Equal(convertedAt) {
+		t.Errorf("segunda conversão = convertido em %v, já cliente %v; want %v e true", again.ConvertedAt, alreadyClient, convertedAt)
+	}
+	if got := len(auditor.actions(models.AuditEntityContact)); got != 1 {
+		t.Errorf("registros de auditoria = %d, want 1", got)
+	}
+
+	// Um contato criado já como cliente não recebe data de conversão
+	client, alreadyClient, err := service.ConvertLeadToClient(7, 2)
+	if err != nil {
+		t.Fatalf("conversão de cliente: %v", err)
+	}
+	if !alreadyClient || client.ConvertedAt != nil {
+		t.Errorf("cliente = convertido em %v, já cliente %v; want nil e true", client.ConvertedAt, alreadyClient)
+	}
+
+	_, _, err = service.ConvertLeadToClient(8, 1)
+	assertAppError(t, err, http.StatusForbidden)
+}
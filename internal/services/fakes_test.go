@@ -0,0 +1,603 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Os fakes abaixo embutem a interface do repositório: apenas os métodos usados nos testes são
+// implementados e qualquer outra chamada falha com nil pointer, apontando o método faltante.
+
+// txParticipant é um fake que pode ter o estado desfeito quando a transação falha
+type txParticipant interface {
+	snapshot() (restore func())
+}
+
+// fakeTransactor executa a função diretamente, desfazendo o estado dos participantes em caso de erro
+type fakeTransactor struct {
+	participants []txParticipant
+}
+
+func (t *fakeTransactor) Transaction(fn func(tx *gorm.DB) error) error {
+	restores := make([]func(), 0, len(t.participants))
+	for _, participant := range t.participants {
+		restores = append(restores, participant.snapshot())
+	}
+	err := fn(nil)
+	if err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+	return err
+}
+
+// fakePublisher guarda as atividades publicadas
+type fakePublisher struct {
+	activities []models.UserActivity
+}
+
+func (p *fakePublisher) Publish(userID uint, activity models.UserActivity) {
+	p.activities = append(p.activities, activity)
+}
+
+// auditEntry é um registro capturado pelo fakeAuditor
+type auditEntry struct {
+	EntityType models.AuditEntityType
+	EntityID   uint
+	Action     models.AuditAction
+	Before     interface{}
+	After      interface{}
+}
+
+// fakeAuditor guarda os registros de auditoria
+type fakeAuditor struct {
+	entries []auditEntry
+}
+
+func (a *fakeAuditor) Record(userID uint, entityType models.AuditEntityType, entityID uint, action models.AuditAction, before, after interface{}) {
+	a.entries = append(a.entries, auditEntry{EntityType: entityType, EntityID: entityID, Action: action, Before: before, After: after})
+}
+
+// actions retorna as ações registradas para o tipo de entidade, na ordem
+func (a *fakeAuditor) actions(entityType models.AuditEntityType) []models.AuditAction {
+	var actions []models.AuditAction
+	for _, entry := range a.entries {
+		if entry.EntityType == entityType {
+			actions = append(actions, entry.Action)
+		}
+	}
+	return actions
+}
+
+// fakeTaskRepo guarda as tarefas em memória
+type fakeTaskRepo struct {
+	repositories.TaskRepository
+	tasks     map[uint]models.Task
+	deleted   map[uint]models.Task
+	lastID    uint
+	createErr error
+	updateErr error
+
+	// Intervalo recebido na última chamada de GetUpcomingTasks
+	upcomingFrom, upcomingTo time.Time
+	// Momento usado por GetOverdueTasks para decidir o atraso
+	now time.Time
+}
+
+func newFakeTaskRepo(tasks ...models.Task) *fakeTaskRepo {
+	r := &fakeTaskRepo{tasks: map[uint]models.Task{}, deleted: map[uint]models.Task{}}
+	for _, task := range tasks {
+		r.tasks[task.ID] = task
+		if task.ID > r.lastID {
+			r.lastID = task.ID
+		}
+	}
+	return r
+}
+
+func (r *fakeTaskRepo) snapshot() func() {
+	tasks := make(map[uint]models.Task, len(r.tasks))
+	for id, task := range r.tasks {
+		tasks[id] = task
+	}
+	lastID := r.lastID
+	return func() {
+		r.tasks = tasks
+		r.lastID = lastID
+	}
+}
+
+func (r *fakeTaskRepo) WithTx(*gorm.DB) repositories.TaskRepository {
+	return r
+}
+
+func (r *fakeTaskRepo) GetByID(id uint) (*models.Task, error) {
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &task, nil
+}
+
+func (r *fakeTaskRepo) GetDeletedByID(id uint) (*models.Task, error) {
+	task, ok := r.deleted[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &task, nil
+}
+
+func (r *fakeTaskRepo) Create(task *models.Task) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.lastID++
+	task.ID = r.lastID
+	r.tasks[task.ID] = *task
+	return nil
+}
+
+func (r *fakeTaskRepo) Update(task *models.Task) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	if stored, ok := r.tasks[task.ID]; !ok || stored.Version != task.Version {
+		return repositories.ErrVersionConflict
+	}
+	task.Version++
+	r.tasks[task.ID] = *task
+	return nil
+}
+
+func (r *fakeTaskRepo) Restore(id uint) error {
+	r.tasks[id] = r.deleted[id]
+	delete(r.deleted, id)
+	return nil
+}
+
+// fakeAuditLogRepo guarda os registros de auditoria gravados
+type fakeAuditLogRepo struct {
+	repositories.AuditLogRepository
+	logs []models.AuditLog
+}
+
+func (r *fakeAuditLogRepo) Create(auditLog *models.AuditLog) error {
+	r.logs = append(r.logs, *auditLog)
+	return nil
+}
+
+func (r *fakeTaskRepo) DeleteCompletedByUserID(userID uint, before *time.Time) ([]models.Task, error) {
+	var deleted []models.Task
+	for id, task := range r.tasks {
+		if task.UserID != userID || task.Status != models.TaskStatusCompleted {
+			continue
+		}
+		if before != nil && (task.CompletedAt == nil || !task.CompletedAt.Before(*before)) {
+			continue
+		}
+		deleted = append(deleted, task)
+		r.deleted[id] = task
+		delete(r.tasks, id)
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i].ID < deleted[j].ID })
+	return deleted, nil
+}
+
+// fakeContactRepo guarda os contatos em memória
+type fakeContactRepo struct {
+	repositories.ContactRepository
+	contacts map[uint]models.Contact
+	deleted  map[uint]models.Contact
+
+	// Última interação por contato, usada por GetStaleLeads, e o limite da última chamada
+	lastInteractions map[uint]time.Time
+	staleLeadsLimit  int
+	purged           []uint
+}
+
+func newFakeContactRepo(contacts ...models.Contact) *fakeContactRepo {
+	r := &fakeContactRepo{contacts: map[uint]models.Contact{}, deleted: map[uint]models.Contact{}}
+	for _, contact := range contacts {
+		r.contacts[contact.ID] = contact
+	}
+	return r
+}
+
+func (r *fakeContactRepo) GetByID(id uint) (*models.Contact, error) {
+	contact, ok := r.contacts[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &contact, nil
+}
+
+func (r *fakeContactRepo) GetDeletedByID(id uint) (*models.Contact, error) {
+	contact, ok := r.deleted[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &contact, nil
+}
+
+func (r *fakeContactRepo) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	for _, contact := range r.contacts {
+		if contact.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeContactRepo) Restore(id uint, restoreInteractions bool) error {
+	r.contacts[id] = r.deleted[id]
+	delete(r.deleted, id)
+	return nil
+}
+
+// fakeProjectRepo guarda os projetos em memória
+type fakeProjectRepo struct {
+	repositories.ProjectRepository
+	projects map[uint]models.Project
+	deleted  map[uint]models.Project
+}
+
+func newFakeProjectRepo(projects ...models.Project) *fakeProjectRepo {
+	r := &fakeProjectRepo{projects: map[uint]models.Project{}, deleted: map[uint]models.Project{}}
+	for _, project := range projects {
+		r.projects[project.ID] = project
+	}
+	return r
+}
+
+func (r *fakeProjectRepo) GetByID(id uint) (*models.Project, error) {
+	project, ok := r.projects[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &project, nil
+}
+
+func (r *fakeProjectRepo) GetDeletedByID(id uint) (*models.Project, error) {
+	project, ok := r.deleted[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &project, nil
+}
+
+func (r *fakeProjectRepo) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	for _, project := range r.projects {
+		if project.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeProjectRepo) Restore(id uint) error {
+	r.projects[id] = r.deleted[id]
+	delete(r.deleted, id)
+	return nil
+}
+
+func (r *fakeTaskRepo) GetByUserID(userID uint, filter *models.TaskListFilter) ([]models.Task, error) {
+	var tasks []models.Task
+	for _, task := range r.tasks {
+		if task.UserID == userID {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+// fakeInteractionRepo guarda as interações em memória
+type fakeInteractionRepo struct {
+	repositories.InteractionRepository
+	interactions []models.Interaction
+	recentSince  time.Time
+	lastDates    map[uint]time.Time
+}
+
+func (r *fakeInteractionRepo) GetRecentByUserID(userID uint, since time.Time, limit int) ([]models.Interaction, error) {
+	r.recentSince = since
+	var recent []models.Interaction
+	for _, interaction := range r.interactions {
+		if interaction.Contact.UserID == userID && !interaction.Date.Before(since) {
+			recent = append(recent, interaction)
+		}
+	}
+	return recent, nil
+}
+
+func (r *fakeTaskRepo) GetUpcomingTasks(userID uint, from, to time.Time) ([]models.Task, error) {
+	r.upcomingFrom, r.upcomingTo = from, to
+	var tasks []models.Task
+	for _, task := range r.tasks {
+		if task.UserID != userID || task.Status == models.TaskStatusCompleted || task.DueDate == nil {
+			continue
+		}
+		if !task.DueDate.Before(from) && !task.DueDate.After(to) {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].DueDate.Before(*tasks[j].DueDate) })
+	return tasks, nil
+}
+
+// fakeUserRepo guarda os usuários em memória
+type fakeUserRepo struct {
+	repositories.UserRepository
+	users map[uint]models.User
+}
+
+func newFakeUserRepo(users ...models.User) *fakeUserRepo {
+	r := &fakeUserRepo{users: map[uint]models.User{}}
+	for _, user := range users {
+		r.users[user.ID] = user
+	}
+	return r
+}
+
+func (r *fakeUserRepo) GetByID(id uint) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+func (r *fakeTaskRepo) GetOverdueTasks(userID uint) ([]models.Task, error) {
+	return r.overdue(func(task models.Task) bool { return task.UserID == userID }), nil
+}
+
+func (r *fakeTaskRepo) GetOverdueByContactID(contactID uint) ([]models.Task, error) {
+	return r.overdue(func(task models.Task) bool { return task.ContactID != nil && *task.ContactID == contactID }), nil
+}
+
+func (r *fakeTaskRepo) GetOverdueByProjectID(projectID uint) ([]models.Task, error) {
+	return r.overdue(func(task models.Task) bool { return task.ProjectID != nil && *task.ProjectID == projectID }), nil
+}
+
+// overdue devolve, ordenadas por ID, as tarefas do escopo não concluídas e vencidas antes de r.now
+func (r *fakeTaskRepo) overdue(inScope func(models.Task) bool) []models.Task {
+	var tasks []models.Task
+	for _, task := range r.tasks {
+		if inScope(task) && task.Status != models.TaskStatusCompleted && task.DueDate != nil && task.DueDate.Before(r.now) {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks
+}
+
+func (r *fakeShareRepo) Exists(resourceType models.SharedResourceType, resourceID, sharedWithID uint) (bool, error) {
+	_, ok := r.grants[[2]uint{resourceID, sharedWithID}]
+	return ok, nil
+}
+
+func (r *fakeShareRepo) Create(share *models.SharedResource) error {
+	if r.grants == nil {
+		r.grants = map[[2]uint]models.SharePermission{}
+	}
+	share.ID = uint(len(r.created) + 1)
+	r.grants[[2]uint{share.ResourceID, share.SharedWithID}] = share.Permission
+	r.created = append(r.created, *share)
+	return nil
+}
+
+func (r *fakeUserRepo) GetByEmail(email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// fakePasswordResetRepo guarda os tokens de redefinição criados
+type fakePasswordResetRepo struct {
+	repositories.PasswordResetRepository
+	resets []models.PasswordReset
+}
+
+func (r *fakePasswordResetRepo) Create(reset *models.PasswordReset) error {
+	r.resets = append(r.resets, *reset)
+	return nil
+}
+
+// sentMail é um email capturado pelo fakeMailer
+type sentMail struct {
+	To, Subject, Body string
+}
+
+// fakeMailer entrega os emails no canal sent somente depois que release é fechado
+type fakeMailer struct {
+	release chan struct{}
+	sent    chan sentMail
+}
+
+func newFakeMailer() *fakeMailer {
+	return &fakeMailer{release: make(chan struct{}), sent: make(chan sentMail, 1)}
+}
+
+func (m *fakeMailer) Send(to, subject, body string) error {
+	<-m.release
+	m.sent <- sentMail{To: to, Subject: subject, Body: body}
+	return nil
+}
+
+func (r *fakeContactRepo) GetStaleLeads(userID uint, before, until time.Time, limit int) ([]models.Contact, error) {
+	r.staleLeadsLimit = limit
+	lastActivity := func(contact models.Contact) time.Time {
+		if last, ok := r.lastInteractions[contact.ID]; ok {
+			return last
+		}
+		return contact.CreatedAt
+	}
+
+	var leads []models.Contact
+	for _, contact := range r.contacts {
+		last, hasInteraction := r.lastInteractions[contact.ID]
+		if contact.UserID == userID && contact.Type == models.ContactTypeLead && contact.CreatedAt.Before(before) &&
+			(!hasInteraction || last.Before(before)) {
+			leads = append(leads, contact)
+		}
+	}
+	sort.Slice(leads, func(i, j int) bool {
+		if a, b := lastActivity(leads[i]), lastActivity(leads[j]); !a.Equal(b) {
+			return a.Before(b)
+		}
+		return leads[i].ID < leads[j].ID
+	})
+	if limit > 0 && len(leads) > limit {
+		leads = leads[:limit]
+	}
+	return leads, nil
+}
+
+func (r *fakeContactRepo) GetByIDs(userID uint, ids []uint) ([]models.Contact, error) {
+	var contacts []models.Contact
+	for _, id := range ids {
+		if contact, ok := r.contacts[id]; ok && contact.UserID == userID {
+			contacts = append(contacts, contact)
+		}
+	}
+	return contacts, nil
+}
+
+func (r *fakeInteractionRepo) GetLastDateByContact(userID uint, until time.Time) (map[uint]time.Time, error) {
+	return r.lastDates, nil
+}
+
+func (r *fakeTaskRepo) CountOpenDueByContact(userID uint, until time.Time) (map[uint]int64, error) {
+	counts := map[uint]int64{}
+	for _, task := range r.tasks {
+		if task.UserID == userID && task.ContactID != nil && task.Status != models.TaskStatusCompleted &&
+			task.DueDate != nil && !task.DueDate.After(until) {
+			counts[*task.ContactID]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *fakeTaskRepo) GetByProjectID(projectID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	for _, task := range r.tasks {
+		if task.ProjectID != nil && *task.ProjectID == projectID {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+func (r *fakeContactRepo) Purge(id uint) error {
+	delete(r.contacts, id)
+	delete(r.deleted, id)
+	r.purged = append(r.purged, id)
+	return nil
+}
+
+func (r *fakeProjectRepo) CountByClientID(clientID uint) (int64, error) {
+	var count int64
+	for _, project := range r.projects {
+		if project.ClientID == clientID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeProjectRepo) CountAllByClientID(clientID uint) (int64, error) {
+	var count int64
+	for _, projects := range []map[uint]models.Project{r.projects, r.deleted} {
+		for _, project := range projects {
+			if project.ClientID == clientID {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeContactRepo) GetByEmailAndUserID(email string, userID uint) (*models.Contact, error) {
+	for _, contact := range r.contacts {
+		if contact.UserID == userID && strings.EqualFold(contact.Email, email) {
+			return &contact, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeContactRepo) Create(contact *models.Contact) error {
+	for id := range r.contacts {
+		if id > contact.ID {
+			contact.ID = id
+		}
+	}
+	contact.ID++
+	r.contacts[contact.ID] = *contact
+	return nil
+}
+
+func (r *fakeContactRepo) Update(contact *models.Contact) error {
+	r.contacts[contact.ID] = *contact
+	return nil
+}
+
+func (r *fakeUserRepo) EmailExists(email string) (bool, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeUserRepo) Update(user *models.User) error {
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *fakeTaskRepo) GetPendingDueBetween(from, to time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	for _, task := range r.tasks {
+		if task.Status != models.TaskStatusCompleted && task.DueDate != nil && !task.DueDate.Before(from) && !task.DueDate.After(to) {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+// fakeNotificationRepo guarda as notificações em memória
+type fakeNotificationRepo struct {
+	repositories.NotificationRepository
+	notifications []models.Notification
+}
+
+func (r *fakeNotificationRepo) ExistsForTask(taskID uint, notificationType models.NotificationType, dueDate, since time.Time) (bool, error) {
+	for _, notification := range r.notifications {
+		if notification.TaskID != nil && *notification.TaskID == taskID && notification.Type == notificationType &&
+			notification.DueDate != nil && notification.DueDate.Equal(dueDate) && !notification.CreatedAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeNotificationRepo) Create(notification *models.Notification) error {
+	notification.ID = uint(len(r.notifications) + 1)
+	notification.CreatedAt = time.Now()
+	r.notifications = append(r.notifications, *notification)
+	return nil
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"crm-backend/pkg/errors"
+	"crm-backend/pkg/logger"
+	stderrors "errors"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Os serviços registram avisos e erros pelo logger global
+	if err := logger.Init(logger.Options{}); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// assertAppError verifica se err é um *errors.AppError com o status HTTP esperado
+func assertAppError(t *testing.T, err error, code int) {
+	t.Helper()
+	var appErr *errors.AppError
+	if !stderrors.As(err, &appErr) {
+		t.Fatalf("err = %v, want AppError %d", err, code)
+	}
+	if appErr.Code != code {
+		t.Fatalf("status = %d (%s), want %d", appErr.Code, appErr.Message, code)
+	}
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxUpcomingDatesDays limita a janela de GetUpcoming a um ano
+const maxUpcomingDatesDays = 366
+
+// ImportantDateService define a interface para operações de datas importantes dos contatos
+type ImportantDateService interface {
+	Create(userID, contactID uint, req *models.ImportantDateCreateRequest) (*models.ImportantDate, error)
+	GetByContactID(userID, contactID uint) ([]models.ImportantDate, error)
+	GetUpcoming(userID uint, days int) ([]models.UpcomingImportantDate, error)
+}
+
+// importantDateService implementa ImportantDateService
+type importantDateService struct {
+	importantDateRepo repositories.ImportantDateRepository
+	contactRepo       repositories.ContactRepository
+	userRepo          repositories.UserRepository
+	access            AccessPolicy
+}
+
+// NewImportantDateService cria uma nova instância do serviço de datas importantes
+func NewImportantDateService(
+	importantDateRepo repositories.ImportantDateRepository,
+	contactRepo repositories.ContactRepository,
+	userRepo repositories.UserRepository,
+	access AccessPolicy,
+) ImportantDateService {
+	return &importantDateService{
+		importantDateRepo: importantDateRepo,
+		contactRepo:       contactRepo,
+		userRepo:          userRepo,
+		access:            access,
+	}
+}
+
+// Create cadastra uma data importante para um contato do usuário
+func (s *importantDateService) Create(userID, contactID uint, req *models.ImportantDateCreateRequest) (*models.ImportantDate, error) {
+	// Verificar se o contato existe e pertence ao usuário
+	if _, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato"); err != nil {
+		return nil, err
+	}
+
+	label := strings.TrimSpace(req.Label)
+	if label == "" {
+		return nil, errors.NewBadRequestError("Descrição da data é obrigatória")
+	}
+	if len([]rune(label)) > models.MaxImportantDateLabelLength {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("A descrição deve ter no máximo %d caracteres", models.MaxImportantDateLabelLength))
+	}
+
+	date, err := time.Parse(models.ImportantDateLayout, strings.TrimSpace(req.Date))
+	if err != nil {
+		return nil, errors.NewBadRequestError("Data inválida, use YYYY-MM-DD")
+	}
+
+	recurring := true
+	if req.Recurring != nil {
+		recurring = *req.Recurring
+	}
+
+	importantDate := &models.ImportantDate{
+		ContactID: contactID,
+		Label:     label,
+		Date:      date,
+		Recurring: recurring,
+	}
+
+	if err := s.importantDateRepo.Create(importantDate); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return importantDate, nil
+}
+
+// GetByContactID lista as datas importantes de um contato do usuário
+func (s *importantDateService) GetByContactID(userID, contactID uint) ([]models.ImportantDate, error) {
+	// Verificar se o contato existe e pertence ao usuário
+	if _, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato"); err != nil {
+		return nil, err
+	}
+
+	dates, err := s.importantDateRepo.GetByContactID(contactID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return dates, nil
+}
+
+// GetUpcoming lista as datas dos contatos do usuário que ocorrem de hoje até daqui a days dias
+// (no fuso horário do usuário), da mais próxima para a mais distante. Datas recorrentes são
+// projetadas para a próxima ocorrência, independentemente do ano cadastrado.
+func (s *importantDateService) GetUpcoming(userID uint, days int) ([]models.UpcomingImportantDate, error) {
+	if days < 1 || days > maxUpcomingDatesDays {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("O parâmetro 'days' deve estar entre 1 e %d", maxUpcomingDatesDays))
+	}
+
+	today := startOfDay(time.Now().In(userLocation(s.userRepo, userID)))
+	until := today.AddDate(0, 0, days)
+
+	candidates, err := s.importantDateRepo.GetUpcomingCandidates(userID, today, until)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	upcoming := make([]models.UpcomingImportantDate, 0, len(candidates))
+	for _, date := range candidates {
+		occurrence, ok := date.NextOccurrence(today)
+		if !ok || occurrence.After(until) {
+			continue
+		}
+
+		item := models.UpcomingImportantDate{
+			ImportantDate:  date,
+			ContactName:    date.Contact.Name,
+			NextOccurrence: occurrence.Format(models.ImportantDateLayout),
+			DaysUntil:      calendarDaysBetween(today, occurrence),
+		}
+		if years := occurrence.Year() - date.Date.Year(); date.Recurring && years >= 0 {
+			item.Years = &years
+		}
+		upcoming = append(upcoming, item)
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		if upcoming[i].DaysUntil != upcoming[j].DaysUntil {
+			return upcoming[i].DaysUntil < upcoming[j].DaysUntil
+		}
+		return upcoming[i].ID < upcoming[j].ID
+	})
+
+	return upcoming, nil
+}
+
+// calendarDaysBetween conta os dias de calendário entre duas datas, sem ser afetado por horário de verão
+func calendarDaysBetween(from, to time.Time) int {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+	start := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
@@ -1,9 +1,13 @@
 package services
 
 import (
+	"crm-backend/internal/events"
 	"crm-backend/internal/models"
 	"crm-backend/internal/repositories"
 	"crm-backend/pkg/errors"
+	"crm-backend/pkg/logger"
+	"crm-backend/pkg/validation"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -11,44 +15,110 @@
 // InteractionService define a interface para operações de interação
 type InteractionService interface {
 	Create(userID, contactID uint, req *models.InteractionCreateRequest) (*models.Interaction, error)
+	CreateByContactEmail(userID uint, req *models.InteractionCreateByEmailRequest) (*models.Interaction, error)
 	GetByID(userID, interactionID uint) (*models.Interaction, error)
+	GetWithContext(userID, interactionID uint) (*InteractionContext, error)
 	GetByContactID(userID, contactID uint, filter *models.InteractionListFilter) ([]models.Interaction, error)
+	GetPaginatedByContactID(userID, contactID uint, filter *models.InteractionListFilter) (*models.PaginatedResponse[models.Interaction], error)
 	GetByUserID(userID uint, filter *models.InteractionListFilter) ([]models.Interaction, error)
+	GetPaginatedByUserID(userID uint, filter *models.InteractionListFilter) (*models.PaginatedResponse[models.Interaction], error)
 	Update(userID, interactionID uint, req *models.InteractionUpdateRequest) (*models.Interaction, error)
 	Delete(userID, interactionID uint) error
-	GetRecentInteractions(userID uint, limit int) ([]models.Interaction, error)
+	GetRecentInteractions(userID uint, days, limit int) ([]models.Interaction, error)
 }
 
+// Limites do contexto de uma interação (GetWithContext)
+const (
+	interactionContextRecentLimit = 5  // Outras interações mais recentes do contato
+	interactionContextTaskLimit   = 10 // Tarefas em aberto do contato
+)
+
+// InteractionContext representa uma interação junto com o contexto do contato: as outras
+// interações mais recentes e as tarefas em aberto
+type InteractionContext struct {
+	Interaction        *models.Interaction  `json:"interaction"`
+	RecentInteractions []models.Interaction `json:"recent_interactions"` // Mais recentes primeiro, sem a própria interação
+	OpenTasks          []models.Task        `json:"open_tasks"`          // Não concluídas, por vencimento
+}
+
+// errInvalidOutcome indica um resultado de interação desconhecido
+var errInvalidOutcome = errors.NewBadRequestError("Resultado inválido. Valores aceitos: POSITIVE, NEUTRAL, NEGATIVE, NO_RESPONSE")
+
 // interactionService implementa InteractionService
 type interactionService struct {
 	interactionRepo repositories.InteractionRepository
 	contactRepo     repositories.ContactRepository
+	taskRepo        repositories.TaskRepository
+	userRepo        repositories.UserRepository
+	publisher       events.Publisher
+	futureTolerance time.Duration
+	recentDays      int
+	auditor         AuditRecorder
+	access          AccessPolicy
 }
 
-// NewInteractionService cria uma nova instância do serviço de interações
+// NewInteractionService cria uma nova instância do serviço de interações.
+// futureTolerance define quanto a data de uma interação não planejada pode estar à frente do momento atual
+// e recentDays a janela padrão, em dias, de GetRecentInteractions.
 func NewInteractionService(
 	interactionRepo repositories.InteractionRepository,
 	contactRepo repositories.ContactRepository,
+	taskRepo repositories.TaskRepository,
+	userRepo repositories.UserRepository,
+	publisher events.Publisher,
+	futureTolerance time.Duration,
+	recentDays int,
+	auditor AuditRecorder,
+	access AccessPolicy,
 ) InteractionService {
 	return &interactionService{
 		interactionRepo: interactionRepo,
 		contactRepo:     contactRepo,
+		taskRepo:        taskRepo,
+		userRepo:        userRepo,
+		publisher:       publisher,
+		futureTolerance: futureTolerance,
+		recentDays:      recentDays,
+		auditor:         auditor,
+		access:          access,
+	}
+}
+
+// validateDate rejeita datas além da tolerância no futuro, exceto para interações planejadas
+func (s *interactionService) validateDate(date time.Time, planned bool) error {
+	if planned {
+		return nil
+	}
+	if date.After(time.Now().Add(s.futureTolerance)) {
+		return errors.NewBadRequestError("Data da interação está muito no futuro; marque como planejada para agendar")
 	}
+	return nil
 }
 
 // Create cria uma nova interação
 func (s *interactionService) Create(userID, contactID uint, req *models.InteractionCreateRequest) (*models.Interaction, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
 	// Verificar se o contato existe e pertence ao usuário
-	contact, err := s.contactRepo.GetByID(contactID)
+	contact, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato")
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Contato")
-		}
-		return nil, errors.ErrInternalServer
+		return nil, err
 	}
 
-	if contact.UserID != userID {
-		return nil, errors.ErrForbidden
+	if err := s.validateDate(req.Date, req.Planned); err != nil {
+		return nil, err
+	}
+	outcome := req.Outcome
+	if outcome == "" {
+		outcome = models.InteractionOutcomeNeutral
+	}
+	if !outcome.IsValid() {
+		return nil, errInvalidOutcome
+	}
+	if req.FollowUpTitle != "" && req.FollowUpDate == nil {
+		return nil, errors.NewBadRequestError("Data do follow-up é obrigatória quando o título é informado")
 	}
 
 	// Criar interação
@@ -57,10 +127,19 @@ func (s *interactionService) Create(userID, contactID uint, req *models.Interact
 		Date:        req.Date,
 		Subject:     req.Subject,
 		Description: req.Description,
+		Outcome:     outcome,
 		ContactID:   contactID,
+		Planned:     req.Planned,
 	}
 
-	if err := s.interactionRepo.Create(interaction); err != nil {
+	// Com follow-up, interação e tarefa são criadas juntas (se uma falhar, nenhuma é gravada)
+	var followUp *models.Task
+	if req.FollowUpDate != nil {
+		followUp = newFollowUpTask(userID, contact, req)
+		if err := s.interactionRepo.CreateWithFollowUp(interaction, followUp); err != nil {
+			return nil, errors.ErrInternalServer
+		}
+	} else if err := s.interactionRepo.Create(interaction); err != nil {
 		return nil, errors.ErrInternalServer
 	}
 
@@ -70,48 +149,158 @@ func (s *interactionService) Create(userID, contactID uint, req *models.Interact
 		return nil, errors.ErrInternalServer
 	}
 
+	s.touchContact(createdInteraction)
+
+	publishActivity(s.publisher, userID, createActivityFromInteraction(*createdInteraction), models.ActionCreated)
+	recordAudit(s.auditor, userID, models.AuditEntityInteraction, createdInteraction.ID, models.AuditActionCreate, nil, createdInteraction)
+	if followUp != nil {
+		publishActivity(s.publisher, userID, createActivityFromTask(*followUp), models.ActionCreated)
+		recordAudit(s.auditor, userID, models.AuditEntityTask, followUp.ID, models.AuditActionCreate, nil, followUp)
+	}
+
 	return createdInteraction, nil
 }
 
-// GetByID obtém uma interação específica
-func (s *interactionService) GetByID(userID, interactionID uint) (*models.Interaction, error) {
-	interaction, err := s.interactionRepo.GetByID(interactionID)
+// CreateByContactEmail cria uma interação para o contato do usuário com o email informado
+// (sem diferenciar maiúsculas). Se houver mais de um contato com o mesmo email, usa o de menor ID.
+func (s *interactionService) CreateByContactEmail(userID uint, req *models.InteractionCreateByEmailRequest) (*models.Interaction, error) {
+	email := models.NormalizeEmail(req.ContactEmail)
+	if email == "" {
+		return nil, errors.NewBadRequestError("Email do contato é obrigatório")
+	}
+
+	contact, err := s.contactRepo.GetByEmailAndUserID(email, userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Interação")
+			return nil, errors.NewNotFoundError("Contato")
 		}
 		return nil, errors.ErrInternalServer
 	}
 
-	// Verificar se a interação pertence a um contato do usuário
-	if interaction.Contact.UserID != userID {
-		return nil, errors.ErrForbidden
+	return s.Create(userID, contact.ID, &req.InteractionCreateRequest)
+}
+
+// touchContact atualiza a data do último contato a partir de uma interação já realizada.
+// Interações planejadas são ignoradas; falhas são apenas registradas no log, sem desfazer a interação.
+func (s *interactionService) touchContact(interaction *models.Interaction) {
+	if interaction.Planned {
+		return
+	}
+
+	contactedAt := interaction.Date
+	if now := time.Now(); contactedAt.After(now) {
+		contactedAt = now
+	}
+
+	if err := s.contactRepo.TouchLastContacted(interaction.ContactID, contactedAt); err != nil {
+		logger.LogError(err, "Contact Last Contacted Update", map[string]interface{}{
+			"contact_id":     interaction.ContactID,
+			"interaction_id": interaction.ID,
+		})
+	}
+}
+
+// newFollowUpTask monta a tarefa de follow-up de uma interação, associada ao contato e com prioridade média
+func newFollowUpTask(userID uint, contact *models.Contact, req *models.InteractionCreateRequest) *models.Task {
+	title := req.FollowUpTitle
+	if title == "" {
+		title = "Follow-up com " + contact.Name
+	}
+
+	contactID := contact.ID
+	return &models.Task{
+		Title:          title,
+		Description:    req.Subject,
+		DueDate:        req.FollowUpDate,
+		Priority:       models.PriorityMedium,
+		Status:         models.TaskStatusPending,
+		UserID:         userID,
+		ContactID:      &contactID,
+		RecurrenceRule: models.RecurrenceNone,
+	}
+}
+
+// GetByID obtém uma interação específica
+func (s *interactionService) GetByID(userID, interactionID uint) (*models.Interaction, error) {
+	interaction, err := fetchOwnedInteraction(s.access, s.interactionRepo, interactionID, userID)
+	if err != nil {
+		return nil, err
 	}
 
 	return interaction, nil
 }
 
-// GetByContactID obtém interações de um contato específico
-func (s *interactionService) GetByContactID(userID, contactID uint, filter *models.InteractionListFilter) ([]models.Interaction, error) {
-	// Verificar se o contato existe e pertence ao usuário
-	contact, err := s.contactRepo.GetByID(contactID)
+// GetWithContext obtém uma interação com as outras interações recentes e as tarefas em aberto do
+// mesmo contato, limitadas a interactionContextRecentLimit e interactionContextTaskLimit itens
+func (s *interactionService) GetWithContext(userID, interactionID uint) (*InteractionContext, error) {
+	interaction, err := fetchOwnedInteraction(s.access, s.interactionRepo, interactionID, userID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Contato")
+		return nil, err
+	}
+
+	// Buscar um item a mais para compensar a própria interação
+	interactions, err := s.interactionRepo.GetByContactID(interaction.ContactID, &models.InteractionListFilter{
+		Limit: interactionContextRecentLimit + 1,
+	})
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	recent := make([]models.Interaction, 0, interactionContextRecentLimit)
+	for _, other := range interactions {
+		if other.ID != interaction.ID && len(recent) < interactionContextRecentLimit {
+			recent = append(recent, other)
 		}
+	}
+
+	tasks, err := s.taskRepo.GetByContactID(interaction.ContactID)
+	if err != nil {
 		return nil, errors.ErrInternalServer
 	}
+	open := make([]models.Task, 0, interactionContextTaskLimit)
+	for _, task := range tasks {
+		if task.Status != models.TaskStatusCompleted && len(open) < interactionContextTaskLimit {
+			open = append(open, task)
+		}
+	}
+
+	return &InteractionContext{
+		Interaction:        interaction,
+		RecentInteractions: recent,
+		OpenTasks:          open,
+	}, nil
+}
+
+// validateListFilter valida os filtros de listagem de interações (tipo, resultado e período)
+func validateListFilter(filter *models.InteractionListFilter) error {
+	if filter.Outcome != "" && !filter.Outcome.IsValid() {
+		return errInvalidOutcome
+	}
+	if err := validation.Struct(filter); err != nil {
+		return err
+	}
+	if filter.DateFrom != nil && filter.DateTo != nil && filter.DateTo.Before(*filter.DateFrom) {
+		return errors.NewBadRequestError("A data final (date_to) não pode ser anterior à data inicial (date_from)")
+	}
+	return nil
+}
 
-	if contact.UserID != userID {
-		return nil, errors.ErrForbidden
+// GetByContactID obtém interações de um contato específico
+func (s *interactionService) GetByContactID(userID, contactID uint, filter *models.InteractionListFilter) ([]models.Interaction, error) {
+	// Verificar se o contato existe e pertence ao usuário
+	if _, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato"); err != nil {
+		return nil, err
 	}
 
 	// Aplicar valores padrão ao filtro se necessário
 	if filter == nil {
 		filter = &models.InteractionListFilter{}
 	}
-	if filter.Limit == 0 {
-		filter.Limit = 50 // Limite padrão
+	filter.Limit = models.NormalizeLimit(filter.Limit)
+	if err := models.CheckOffset(filter.Offset); err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
+	}
+	if err := validateListFilter(filter); err != nil {
+		return nil, err
 	}
 
 	interactions, err := s.interactionRepo.GetByContactID(contactID, filter)
@@ -122,14 +311,38 @@ func (s *interactionService) GetByContactID(userID, contactID uint, filter *mode
 	return interactions, nil
 }
 
+// GetPaginatedByContactID obtém as interações de um contato com metadados de paginação.
+// O total considera os mesmos filtros de tipo, resultado e data da listagem.
+func (s *interactionService) GetPaginatedByContactID(userID, contactID uint, filter *models.InteractionListFilter) (*models.PaginatedResponse[models.Interaction], error) {
+	if filter == nil {
+		filter = &models.InteractionListFilter{}
+	}
+
+	items, err := s.GetByContactID(userID, contactID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.interactionRepo.CountByContactIDWithFilter(contactID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return models.NewPaginatedResponse(items, total, filter.Limit, filter.Offset), nil
+}
+
 // GetByUserID obtém todas as interações do usuário
 func (s *interactionService) GetByUserID(userID uint, filter *models.InteractionListFilter) ([]models.Interaction, error) {
 	// Aplicar valores padrão ao filtro se necessário
 	if filter == nil {
 		filter = &models.InteractionListFilter{}
 	}
-	if filter.Limit == 0 {
-		filter.Limit = 50 // Limite padrão
+	filter.Limit = models.NormalizeLimit(filter.Limit)
+	if err := models.CheckOffset(filter.Offset); err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
+	}
+	if err := validateListFilter(filter); err != nil {
+		return nil, err
 	}
 
 	interactions, err := s.interactionRepo.GetByUserID(userID, filter)
@@ -140,34 +353,120 @@ func (s *interactionService) GetByUserID(userID uint, filter *models.Interaction
 	return interactions, nil
 }
 
+// GetPaginatedByUserID obtém os interações do usuário com metadados de paginação
+func (s *interactionService) GetPaginatedByUserID(userID uint, filter *models.InteractionListFilter) (*models.PaginatedResponse[models.Interaction], error) {
+	if filter == nil {
+		filter = &models.InteractionListFilter{}
+	}
+
+	if filter.Cursor != "" {
+		return s.getCursorPageByUserID(userID, filter)
+	}
+
+	items, err := s.GetByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.interactionRepo.CountByUserIDWithFilter(userID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	page := models.NewPaginatedResponse(items, total, filter.Limit, filter.Offset)
+	if page.HasMore {
+		page.NextCursor = interactionCursor(items[len(items)-1])
+	}
+	return page, nil
+}
+
+// getCursorPageByUserID obtém a página seguinte ao cursor informado (o offset é ignorado).
+// Busca um item a mais que o limite para saber se existe próxima página.
+func (s *interactionService) getCursorPageByUserID(userID uint, filter *models.InteractionListFilter) (*models.PaginatedResponse[models.Interaction], error) {
+	after, err := models.DecodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, errors.NewBadRequestError("Cursor de paginação inválido")
+	}
+
+	filter.Limit = models.NormalizeLimit(filter.Limit)
+	if err := validateListFilter(filter); err != nil {
+		return nil, err
+	}
+	limit := filter.Limit
+
+	cursorFilter := *filter
+	cursorFilter.After = after
+	cursorFilter.Offset = 0
+	cursorFilter.Limit = limit + 1
+
+	items, err := s.interactionRepo.GetByUserID(userID, &cursorFilter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	total, err := s.interactionRepo.CountByUserIDWithFilter(userID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	page := models.NewPaginatedResponse(items, total, limit, 0)
+	page.HasMore = hasMore
+	if hasMore {
+		page.NextCursor = interactionCursor(items[len(items)-1])
+	}
+	return page, nil
+}
+
+// interactionCursor gera o cursor que aponta para a interação informada
+func interactionCursor(interaction models.Interaction) string {
+	return models.EncodeCursor(models.Cursor{Date: interaction.Date, ID: interaction.ID})
+}
+
 // Update atualiza uma interação existente
 func (s *interactionService) Update(userID, interactionID uint, req *models.InteractionUpdateRequest) (*models.Interaction, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
 	// Buscar interação existente
-	interaction, err := s.interactionRepo.GetByID(interactionID)
+	interaction, err := fetchOwnedInteraction(s.access, s.interactionRepo, interactionID, userID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Interação")
-		}
-		return nil, errors.ErrInternalServer
+		return nil, err
 	}
 
-	// Verificar se a interação pertence a um contato do usuário
-	if interaction.Contact.UserID != userID {
-		return nil, errors.ErrForbidden
-	}
+	before := *interaction
 
 	// Atualizar campos fornecidos
 	if req.Type != "" {
 		interaction.Type = req.Type
 	}
+	if req.Planned != nil {
+		interaction.Planned = *req.Planned
+	}
 	if req.Date != nil {
 		interaction.Date = *req.Date
 	}
-	if req.Subject != "" {
-		interaction.Subject = req.Subject
+	if req.Date != nil || req.Planned != nil {
+		if err := s.validateDate(interaction.Date, interaction.Planned); err != nil {
+			return nil, err
+		}
 	}
-	if req.Description != "" {
-		interaction.Description = req.Description
+	if req.Subject != nil {
+		interaction.Subject = *req.Subject
+	}
+	if req.Description != nil {
+		interaction.Description = *req.Description
+	}
+	if req.Outcome != "" {
+		if !req.Outcome.IsValid() {
+			return nil, errInvalidOutcome
+		}
+		interaction.Outcome = req.Outcome
 	}
 
 	// Salvar alterações
@@ -181,23 +480,18 @@ func (s *interactionService) Update(userID, interactionID uint, req *models.Inte
 		return nil, errors.ErrInternalServer
 	}
 
+	publishActivity(s.publisher, userID, createActivityFromInteraction(*updatedInteraction), models.ActionUpdated)
+	recordAudit(s.auditor, userID, models.AuditEntityInteraction, updatedInteraction.ID, models.AuditActionUpdate, &before, updatedInteraction)
+
 	return updatedInteraction, nil
 }
 
 // Delete exclui uma interação
 func (s *interactionService) Delete(userID, interactionID uint) error {
 	// Buscar interação existente
-	interaction, err := s.interactionRepo.GetByID(interactionID)
+	interaction, err := fetchOwnedInteraction(s.access, s.interactionRepo, interactionID, userID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.NewNotFoundError("Interação")
-		}
-		return errors.ErrInternalServer
-	}
-
-	// Verificar se a interação pertence a um contato do usuário
-	if interaction.Contact.UserID != userID {
-		return errors.ErrForbidden
+		return err
 	}
 
 	// Excluir interação
@@ -205,13 +499,22 @@ func (s *interactionService) Delete(userID, interactionID uint) error {
 		return errors.ErrInternalServer
 	}
 
+	publishActivity(s.publisher, userID, createActivityFromInteraction(*interaction), models.ActionDeleted)
+	recordAudit(s.auditor, userID, models.AuditEntityInteraction, interaction.ID, models.AuditActionDelete, interaction, nil)
+
 	return nil
 }
 
-// GetRecentInteractions obtém interações recentes dos últimos 7 dias
-func (s *interactionService) GetRecentInteractions(userID uint, limit int) ([]models.Interaction, error) {
-	// Buscar interações dos últimos 7 dias
-	interactions, err := s.interactionRepo.GetRecentByUserID(userID, 7, limit)
+// GetRecentInteractions obtém as interações dos últimos days dias (0 usa a janela padrão)
+func (s *interactionService) GetRecentInteractions(userID uint, days, limit int) ([]models.Interaction, error) {
+	days, err := recentWindowDays(days, s.recentDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buscar interações desde o início do dia, days dias atrás, no fuso horário do usuário
+	since := daysAgo(time.Now().In(userLocation(s.userRepo, userID)), days)
+	interactions, err := s.interactionRepo.GetRecentByUserID(userID, since, limit)
 	if err != nil {
 		return nil, errors.ErrInternalServer
 	}
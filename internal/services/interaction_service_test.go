@@ -0,0 +1,83 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestValidateListFilter(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := from.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		filter  models.InteractionListFilter
+		wantErr bool
+	}{
+		{name: "sem filtros", filter: models.InteractionListFilter{}},
+		{name: "filtros válidos", filter: models.InteractionListFilter{Type: "CALL", Outcome: "POSITIVE", DateFrom: &from, DateTo: &from, Search: "proposta"}},
+		{name: "tipo inválido", filter: models.InteractionListFilter{Type: "FAX"}, wantErr: true},
+		{name: "resultado inválido", filter: models.InteractionListFilter{Outcome: "MAYBE"}, wantErr: true},
+		{name: "período invertido", filter: models.InteractionListFilter{DateFrom: &from, DateTo: &before}, wantErr: true},
+		{name: "apenas data inicial", filter: models.InteractionListFilter{DateFrom: &from}},
+		{name: "offset negativo", filter: models.InteractionListFilter{Offset: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateListFilter(&tt.filter)
+			if tt.wantErr {
+				assertAppError(t, err, http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateListFilter = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestInteractionServiceValidateDate(t *testing.T) {
+	now := time.Now()
+	service := &interactionService{futureTolerance: 24 * time.Hour}
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		planned bool
+		wantErr bool
+	}{
+		{name: "data passada", date: now.AddDate(0, 0, -3)},
+		{name: "futuro próximo dentro da tolerância", date: now.Add(12 * time.Hour)},
+		{name: "futuro distante", date: now.AddDate(75, 0, 0), wantErr: true},
+		{name: "além da tolerância por pouco", date: now.Add(25 * time.Hour), wantErr: true},
+		{name: "futuro distante planejado", date: now.AddDate(0, 2, 0), planned: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.validateDate(tt.date, tt.planned)
+			if tt.wantErr {
+				assertAppError(t, err, http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateDate = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestInteractionServiceCreateRejectsFarFutureDate(t *testing.T) {
+	contactRepo := newFakeContactRepo(models.Contact{ID: 1, UserID: 7, Type: models.ContactTypeLead})
+	service := NewInteractionService(&fakeInteractionRepo{}, contactRepo, nil, nil, nil, 24*time.Hour, 0, &fakeAuditor{}, AccessPolicy{})
+
+	_, err := service.Create(7, 1, &models.InteractionCreateRequest{
+		Type:    models.InteractionTypeCall,
+		Subject: "Ligação de acompanhamento",
+		Date:    time.Now().AddDate(75, 0, 0),
+	})
+	assertAppError(t, err, http.StatusBadRequest)
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"crm-backend/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// dueSoonWindow define a antecedência com que tarefas pendentes geram notificação de vencimento
+const dueSoonWindow = 24 * time.Hour
+
+// NotificationService define a interface para operações de notificação
+type NotificationService interface {
+	GetByUserID(userID uint, filter *models.NotificationListFilter) ([]models.Notification, error)
+	MarkAsRead(userID, notificationID uint) (*models.Notification, error)
+	GenerateDueSoonNotifications() (int, error)
+	StartDueSoonScanner(ctx context.Context, interval time.Duration)
+}
+
+// notificationService implementa NotificationService
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	taskRepo         repositories.TaskRepository
+	access           AccessPolicy
+}
+
+// NewNotificationService cria uma nova instância do serviço de notificações
+func NewNotificationService(
+	notificationRepo repositories.NotificationRepository,
+	taskRepo repositories.TaskRepository,
+	access AccessPolicy,
+) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		taskRepo:         taskRepo,
+		access:           access,
+	}
+}
+
+// GetByUserID obtém as notificações do usuário
+func (s *notificationService) GetByUserID(userID uint, filter *models.NotificationListFilter) ([]models.Notification, error) {
+	if filter == nil {
+		filter = &models.NotificationListFilter{}
+	}
+	filter.Limit = models.NormalizeLimit(filter.Limit)
+	if err := models.CheckOffset(filter.Offset); err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
+	}
+
+	notifications, err := s.notificationRepo.GetByUserID(userID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return notifications, nil
+}
+
+// MarkAsRead marca uma notificação do usuário como lida
+func (s *notificationService) MarkAsRead(userID, notificationID uint) (*models.Notification, error) {
+	notification, err := s.notificationRepo.GetByID(notificationID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("Notificação")
+		}
+		return nil, errors.ErrInternalServer
+	}
+
+	// Verificar se a notificação pertence ao usuário
+	if notification.UserID != userID {
+		return nil, s.access.ownershipError("Notificação")
+	}
+
+	if !notification.Read {
+		if err := s.notificationRepo.MarkAsRead(notificationID); err != nil {
+			return nil, errors.ErrInternalServer
+		}
+		notification.Read = true
+	}
+
+	return notification, nil
+}
+
+// GenerateDueSoonNotifications cria notificações para tarefas pendentes que vencem nas próximas 24 horas.
+// A operação é idempotente: cada vencimento de uma tarefa gera no máximo uma notificação, inclusive
+// depois de lida, para que o usuário não seja notificado novamente a cada varredura. Uma tarefa
+// reagendada (novo vencimento) ou que sai de um adiamento é notificada de novo.
+func (s *notificationService) GenerateDueSoonNotifications() (int, error) {
+	now := time.Now()
+	tasks, err := s.taskRepo.GetPendingDueBetween(now, now.Add(dueSoonWindow))
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, task := range tasks {
+		// Notificações anteriores ao fim do adiamento não contam: a tarefa voltou a aparecer
+		var since time.Time
+		if task.SnoozedUntil != nil {
+			since = *task.SnoozedUntil
+		}
+		exists, err := s.notificationRepo.ExistsForTask(task.ID, models.NotificationTypeTaskDueSoon, *task.DueDate, since)
+		if err != nil {
+			return created, err
+		}
+		if exists {
+			continue
+		}
+
+		taskID := task.ID
+		notification := &models.Notification{
+			UserID:  task.UserID,
+			Type:    models.NotificationTypeTaskDueSoon,
+			Message: fmt.Sprintf("A tarefa \"%s\" vence em %s", task.Title, task.DueDate.Format("02/01/2006 15:04")),
+			TaskID:  &taskID,
+			DueDate: task.DueDate,
+		}
+		if err := s.notificationRepo.Create(notification); err != nil {
+			return created, err
+		}
+		created++
+	}
+
+	return created, nil
+}
+
+// StartDueSoonScanner executa GenerateDueSoonNotifications periodicamente até o contexto ser cancelado
+func (s *notificationService) StartDueSoonScanner(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.scanDueSoon()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// scanDueSoon executa uma varredura registrando o resultado no log
+func (s *notificationService) scanDueSoon() {
+	created, err := s.GenerateDueSoonNotifications()
+	if err != nil {
+		logger.LogError(err, "Due Soon Notification Scan", map[string]interface{}{
+			"created": created,
+		})
+		return
+	}
+	if created > 0 {
+		logger.WithFields("INFO", "Due Soon Notifications Generated", map[string]interface{}{
+			"created": created,
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestGenerateDueSoonNotificationsPerDeadline(t *testing.T) {
+	now := time.Now()
+	due := now.Add(6 * time.Hour)
+	earlierDue := now.Add(-24 * time.Hour)
+	notifiedAt := now.Add(-2 * time.Hour)
+	snoozeEndedAfter := now.Add(-time.Hour)
+	snoozeEndedBefore := now.Add(-3 * time.Hour)
+	taskID := uint(1)
+
+	tests := []struct {
+		name         string
+		snoozedUntil *time.Time
+		existing     []models.Notification
+		wantCreated  int
+	}{
+		{name: "sem notificação anterior", wantCreated: 1},
+		{
+			name:     "já notificada para o mesmo vencimento, mesmo lida",
+			existing: []models.Notification{{TaskID: &taskID, Type: models.NotificationTypeTaskDueSoon, DueDate: &due, Read: true, CreatedAt: notifiedAt}},
+		},
+		{
+			name:        "reagendada para outro vencimento",
+			existing:    []models.Notification{{TaskID: &taskID, Type: models.NotificationTypeTaskDueSoon, DueDate: &earlierDue, CreatedAt: notifiedAt}},
+			wantCreated: 1,
+		},
+		{
+			name:         "adiamento terminou depois da notificação",
+			snoozedUntil: &snoozeEndedAfter,
+			existing:     []models.Notification{{TaskID: &taskID, Type: models.NotificationTypeTaskDueSoon, DueDate: &due, Read: true, CreatedAt: notifiedAt}},
+			wantCreated:  1,
+		},
+		{
+			name:         "notificada depois do fim do adiamento",
+			snoozedUntil: &snoozeEndedBefore,
+			existing:     []models.Notification{{TaskID: &taskID, Type: models.NotificationTypeTaskDueSoon, DueDate: &due, CreatedAt: notifiedAt}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := newFakeTaskRepo(
+				models.Task{ID: taskID, UserID: 7, Title: "Enviar proposta", Status: models.TaskStatusPending, DueDate: &due, SnoozedUntil: tt.snoozedUntil},
+			)
+			notificationRepo := &fakeNotificationRepo{notifications: tt.existing}
+			service := NewNotificationService(notificationRepo, taskRepo, AccessPolicy{})
+
+			created, err := service.GenerateDueSoonNotifications()
+			if err != nil {
+				t.Fatalf("GenerateDueSoonNotifications: %v", err)
+			}
+			if created != tt.wantCreated {
+				t.Fatalf("criadas = %d, want %d", created, tt.wantCreated)
+			}
+			if created > 0 {
+				last := notificationRepo.notifications[len(notificationRepo.notifications)-1]
+				if last.DueDate == nil || !last.DueDate.Equal(due) {
+					t.Errorf("DueDate = %v, want %v", last.DueDate, due)
+				}
+			}
+
+			// Uma nova varredura não repete a notificação
+			if again, _ := service.GenerateDueSoonNotifications(); again != 0 {
+				t.Errorf("segunda varredura criou %d, want 0", again)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"crm-backend/pkg/logger"
+	"crm-backend/pkg/mailer"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// passwordResetTTL define por quanto tempo um token de redefinição de senha é válido
+const passwordResetTTL = time.Hour
+
+// PasswordResetService define a interface para redefinição de senha
+type PasswordResetService interface {
+	RequestReset(email string) error
+	ResetPassword(token, newPassword string) error
+}
+
+// passwordResetService implementa PasswordResetService
+type passwordResetService struct {
+	userRepo  repositories.UserRepository
+	resetRepo repositories.PasswordResetRepository
+	mailer    mailer.Mailer
+	resetURL  string
+}
+
+// NewPasswordResetService cria uma nova instância do serviço de redefinição de senha.
+// resetURL é o endereço da página de redefinição; o token é enviado no parâmetro "token".
+func NewPasswordResetService(
+	userRepo repositories.UserRepository,
+	resetRepo repositories.PasswordResetRepository,
+	mailer mailer.Mailer,
+	resetURL string,
+) PasswordResetService {
+	return &passwordResetService{
+		userRepo:  userRepo,
+		resetRepo: resetRepo,
+		mailer:    mailer,
+		resetURL:  resetURL,
+	}
+}
+
+// RequestReset gera um token de redefinição e o envia por email, sem aguardar o envio.
+// Não informa se o email existe: usuários inexistentes e falhas de envio são apenas registrados no log.
+func (s *passwordResetService) RequestReset(email string) error {
+	user, err := s.userRepo.GetByEmail(models.NormalizeEmail(email))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			logger.Debugf("Redefinição de senha solicitada para email inexistente")
+			return nil
+		}
+		return errors.ErrInternalServer
+	}
+
+	token, err := generateResetToken()
+	if err != nil {
+		return errors.ErrInternalServer
+	}
+
+	reset := &models.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	if err := s.resetRepo.Create(reset); err != nil {
+		return errors.ErrInternalServer
+	}
+
+	body := fmt.Sprintf("Olá, %s!\n\nRecebemos uma solicitação para redefinir sua senha. "+
+		"Acesse o link abaixo em até %d minutos:\n\n%s?token=%s\n\n"+
+		"Se você não fez esta solicitação, ignore este email.",
+		user.Name, int(passwordResetTTL.Minutes()), s.resetURL, token)
+
+	// Enviar em segundo plano: a demora do SMTP revelaria, pelo tempo de resposta, quais emails existem
+	go func(userID uint, to string) {
+		if err := s.mailer.Send(to, "Redefinição de senha", body); err != nil {
+			logger.LogError(err, "Password Reset Email", map[string]interface{}{
+				"user_id": userID,
+			})
+		}
+	}(user.ID, user.Email)
+
+	return nil
+}
+
+// ResetPassword redefine a senha do usuário a partir de um token válido (uso único)
+func (s *passwordResetService) ResetPassword(token, newPassword string) error {
+	reset, err := s.resetRepo.GetByTokenHash(hashResetToken(token))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewBadRequestError("Token de redefinição inválido ou expirado")
+		}
+		return errors.ErrInternalServer
+	}
+
+	if !reset.IsUsable() {
+		return errors.NewBadRequestError("Token de redefinição inválido ou expirado")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.ErrInternalServer
+	}
+
+	if err := s.resetRepo.Consume(reset, string(hashedPassword)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewBadRequestError("Token de redefinição inválido ou expirado")
+		}
+		return errors.ErrInternalServer
+	}
+
+	return nil
+}
+
+// generateResetToken gera um token aleatório para redefinição de senha
+func generateResetToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashResetToken calcula o hash persistido de um token (o token em si nunca é armazenado)
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
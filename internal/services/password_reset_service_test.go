@@ -0,0 +1,60 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPasswordResetServiceRequestResetDoesNotWaitForMail(t *testing.T) {
+	userRepo := newFakeUserRepo(models.User{ID: 7, Name: "Ana", Email: "ana@example.com"})
+	resetRepo := &fakePasswordResetRepo{}
+	mailer := newFakeMailer()
+	service := NewPasswordResetService(userRepo, resetRepo, mailer, "https://crm.example.com/reset")
+
+	// O mailer só conclui o envio depois de liberado: RequestReset precisa retornar antes
+	done := make(chan error, 1)
+	go func() { done <- service.RequestReset("Ana@Example.com") }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("RequestReset: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RequestReset aguardou o envio do email")
+	}
+
+	if len(resetRepo.resets) != 1 || resetRepo.resets[0].UserID != 7 {
+		t.Fatalf("tokens criados = %+v, want 1 do usuário 7", resetRepo.resets)
+	}
+
+	close(mailer.release)
+	select {
+	case mail := <-mailer.sent:
+		if mail.To != "ana@example.com" || !strings.Contains(mail.Body, "https://crm.example.com/reset?token=") {
+			t.Fatalf("email inesperado: %+v", mail)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("o email de redefinição não foi enviado")
+	}
+}
+
+func TestPasswordResetServiceRequestResetUnknownEmail(t *testing.T) {
+	resetRepo := &fakePasswordResetRepo{}
+	mailer := newFakeMailer()
+	close(mailer.release)
+	service := NewPasswordResetService(newFakeUserRepo(), resetRepo, mailer, "https://crm.example.com/reset")
+
+	if err := service.RequestReset("ninguem@example.com"); err != nil {
+		t.Fatalf("RequestReset = %v, want nil para email inexistente", err)
+	}
+	if len(resetRepo.resets) != 0 {
+		t.Fatalf("tokens criados = %d, want 0", len(resetRepo.resets))
+	}
+	select {
+	case mail := <-mailer.sent:
+		t.Fatalf("nenhum email deveria ser enviado: %+v", mail)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
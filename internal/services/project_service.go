@@ -1,9 +1,12 @@
 package services
 
 import (
+	"crm-backend/internal/events"
 	"crm-backend/internal/models"
 	"crm-backend/internal/repositories"
 	"crm-backend/pkg/errors"
+	"crm-backend/pkg/validation"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -14,21 +17,35 @@ type ProjectService interface {
 	GetByID(userID, projectID uint) (*models.Project, error)
 	GetWithTasks(userID, projectID uint) (*models.Project, error)
 	GetByUserID(userID uint, filter *models.ProjectListFilter) ([]models.Project, error)
+	GetPaginatedByUserID(userID uint, filter *models.ProjectListFilter) (*models.PaginatedResponse[models.Project], error)
+	GetChangedByUserID(userID uint, filter *models.ProjectListFilter) (*models.PaginatedResponse[models.ChangedProject], error)
 	Update(userID, projectID uint, req *models.ProjectUpdateRequest) (*models.Project, error)
 	Delete(userID, projectID uint) error
+	Restore(userID, projectID uint) (*models.Project, error)
 	GetByClientID(userID, clientID uint) ([]models.Project, error)
 	ChangeStatus(userID, projectID uint, status models.ProjectStatus) (*models.Project, error)
 	GetProjectSummary(userID, projectID uint) (*ProjectSummary, error)
+	GetBoard(userID uint) (ProjectBoard, error)
+	DuplicateProject(userID, projectID uint, req *models.ProjectDuplicateRequest) (*models.Project, error)
 }
 
+// ProjectBoard agrupa os projetos do usuário por status (todas as colunas presentes, mesmo vazias)
+type ProjectBoard map[models.ProjectStatus][]DashboardProject
+
 // ProjectSummary representa um resumo do projeto
 type ProjectSummary struct {
-	Project        *models.Project `json:"project"`
-	TotalTasks     int64           `json:"total_tasks"`
-	CompletedTasks int64           `json:"completed_tasks"`
-	PendingTasks   int64           `json:"pending_tasks"`
-	OverdueTasks   int64           `json:"overdue_tasks"`
-	TasksProgress  float64         `json:"tasks_progress"`
+	Project           *models.Project `json:"project"`
+	TotalTasks        int64           `json:"total_tasks"`
+	CompletedTasks    int64           `json:"completed_tasks"`
+	PendingTasks      int64           `json:"pending_tasks"`
+	InProgressTasks   int64           `json:"in_progress_tasks"`
+	OverdueTasks      int64           `json:"overdue_tasks"`
+	TasksProgress     float64         `json:"tasks_progress"`
+	ProgressIsManual  bool            `json:"progress_is_manual"` // TasksProgress vem do progresso informado no projeto
+	IsOverdue         bool            `json:"is_overdue"`         // Em andamento com data de término já passada
+	RemainingBudget   int64           `json:"remaining_budget"`   // Orçamento menos o valor gasto, em centavos (negativo se estourado)
+	BudgetUtilization *float64        `json:"budget_utilization"` // Percentual do orçamento gasto; nulo sem orçamento definido
+	OverBudget        bool            `json:"over_budget"`        // Valor gasto acima do orçamento definido
 }
 
 // projectService implementa ProjectService
@@ -36,34 +53,55 @@ type projectService struct {
 	projectRepo repositories.ProjectRepository
 	contactRepo repositories.ContactRepository
 	taskRepo    repositories.TaskRepository
+	shareRepo   repositories.SharedResourceRepository
+	transactor  repositories.Transactor
+	publisher   events.Publisher
+	maxProjects int
+	auditor     AuditRecorder
+	access      AccessPolicy
 }
 
-// NewProjectService cria uma nova instância do serviço de projetos
+// NewProjectService cria uma nova instância do serviço de projetos.
+// maxProjects é o limite de projetos por usuário (0: sem limite).
 func NewProjectService(
 	projectRepo repositories.ProjectRepository,
 	contactRepo repositories.ContactRepository,
 	taskRepo repositories.TaskRepository,
+	shareRepo repositories.SharedResourceRepository,
+	transactor repositories.Transactor,
+	publisher events.Publisher,
+	maxProjects int,
+	auditor AuditRecorder,
+	access AccessPolicy,
 ) ProjectService {
 	return &projectService{
 		projectRepo: projectRepo,
 		contactRepo: contactRepo,
 		taskRepo:    taskRepo,
+		shareRepo:   shareRepo,
+		transactor:  transactor,
+		publisher:   publisher,
+		maxProjects: maxProjects,
+		auditor:     auditor,
+		access:      access,
 	}
 }
 
 // Create cria um novo projeto
 func (s *projectService) Create(userID uint, req *models.ProjectCreateRequest) (*models.Project, error) {
-	// Verificar se o cliente existe e pertence ao usuário
-	client, err := s.contactRepo.GetByID(req.ClientID)
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Cliente")
-		}
-		return nil, errors.ErrInternalServer
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
-	if client.UserID != userID {
-		return nil, errors.ErrForbidden
+	// Verificar o limite de projetos do usuário
+	if err := checkQuota(s.projectRepo.CountByUserID, userID, s.maxProjects, "projetos"); err != nil {
+		return nil, err
+	}
+
+	// Verificar se o cliente existe e pertence ao usuário
+	client, err := fetchOwned(s.access, s.contactRepo.GetByID, req.ClientID, userID, "Cliente")
+	if err != nil {
+		return nil, err
 	}
 
 	// Verificar se o cliente é do tipo CLIENT
@@ -71,6 +109,13 @@ func (s *projectService) Create(userID uint, req *models.ProjectCreateRequest) (
 		return nil, errors.NewBadRequestError("O contato deve ser do tipo CLIENT para ser associado a um projeto")
 	}
 
+	if err := validateProjectDates(req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+	if err := validateProjectAmounts(req.Budget, req.SpentAmount); err != nil {
+		return nil, err
+	}
+
 	// Criar projeto
 	project := &models.Project{
 		Name:        req.Name,
@@ -78,6 +123,10 @@ func (s *projectService) Create(userID uint, req *models.ProjectCreateRequest) (
 		Status:      req.Status,
 		UserID:      userID,
 		ClientID:    req.ClientID,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Budget:      req.Budget,
+		SpentAmount: req.SpentAmount,
 	}
 
 	if err := s.projectRepo.Create(project); err != nil {
@@ -90,10 +139,13 @@ func (s *projectService) Create(userID uint, req *models.ProjectCreateRequest) (
 		return nil, errors.ErrInternalServer
 	}
 
+	publishActivity(s.publisher, userID, createActivityFromProject(*createdProject), models.ActionCreated)
+	recordAudit(s.auditor, userID, models.AuditEntityProject, createdProject.ID, models.AuditActionCreate, nil, createdProject)
+
 	return createdProject, nil
 }
 
-// GetByID obtém um projeto específico
+// GetByID obtém um projeto específico (do usuário ou compartilhado com ele)
 func (s *projectService) GetByID(userID, projectID uint) (*models.Project, error) {
 	project, err := s.projectRepo.GetByID(projectID)
 	if err != nil {
@@ -103,9 +155,9 @@ func (s *projectService) GetByID(userID, projectID uint) (*models.Project, error
 		return nil, errors.ErrInternalServer
 	}
 
-	// Verificar se o projeto pertence ao usuário
-	if project.UserID != userID {
-		return nil, errors.ErrForbidden
+	// Verificar se o projeto pertence ao usuário ou foi compartilhado com ele
+	if err := authorizeAccess(s.access, s.shareRepo, models.SharedResourceProject, project.ID, project.UserID, userID, models.SharePermissionRead, "Projeto"); err != nil {
+		return nil, err
 	}
 
 	return project, nil
@@ -113,7 +165,7 @@ func (s *projectService) GetByID(userID, projectID uint) (*models.Project, error
 
 // GetWithTasks obtém um projeto com suas tarefas
 func (s *projectService) GetWithTasks(userID, projectID uint) (*models.Project, error) {
-	// Verificar se o projeto pertence ao usuário
+	// Verificar se o projeto pertence ao usuário ou foi compartilhado com ele
 	_, err := s.GetByID(userID, projectID)
 	if err != nil {
 		return nil, err
@@ -134,8 +186,9 @@ func (s *projectService) GetByUserID(userID uint, filter *models.ProjectListFilt
 	if filter == nil {
 		filter = &models.ProjectListFilter{}
 	}
-	if filter.Limit == 0 {
-		filter.Limit = 50 // Limite padrão
+	filter.Limit = models.NormalizeLimit(filter.Limit)
+	if err := models.CheckOffset(filter.Offset); err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
 	}
 
 	projects, err := s.projectRepo.GetByUserID(userID, filter)
@@ -146,36 +199,68 @@ func (s *projectService) GetByUserID(userID uint, filter *models.ProjectListFilt
 	return projects, nil
 }
 
+// GetPaginatedByUserID obtém os projetos do usuário com metadados de paginação
+func (s *projectService) GetPaginatedByUserID(userID uint, filter *models.ProjectListFilter) (*models.PaginatedResponse[models.Project], error) {
+	if filter == nil {
+		filter = &models.ProjectListFilter{}
+	}
+
+	items, err := s.GetByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.projectRepo.CountByUserIDWithFilter(userID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return models.NewPaginatedResponse(items, total, filter.Limit, filter.Offset), nil
+}
+
+// GetChangedByUserID obtém os projetos do usuário alterados ou excluídos desde filter.ChangedSince,
+// indicando os excluídos, para sincronização incremental
+func (s *projectService) GetChangedByUserID(userID uint, filter *models.ProjectListFilter) (*models.PaginatedResponse[models.ChangedProject], error) {
+	page, err := s.GetPaginatedByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.ChangedProject, len(page.Data))
+	for i, project := range page.Data {
+		items[i] = models.ChangedProject{Project: project, ChangeState: models.NewChangeState(project.DeletedAt)}
+	}
+
+	return models.NewPaginatedResponse(items, page.Total, page.Limit, page.Offset), nil
+}
+
 // Update atualiza um projeto existente
 func (s *projectService) Update(userID, projectID uint, req *models.ProjectUpdateRequest) (*models.Project, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
 	// Buscar projeto existente
-	project, err := s.projectRepo.GetByID(projectID)
+	project, err := fetchOwned(s.access, s.projectRepo.GetByID, projectID, userID, "Projeto")
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Projeto")
-		}
-		return nil, errors.ErrInternalServer
+		return nil, err
 	}
 
-	// Verificar se o projeto pertence ao usuário
-	if project.UserID != userID {
-		return nil, errors.ErrForbidden
+	// Verificar se o projeto não foi alterado desde a leitura do cliente
+	if err := checkExpectedVersion(req.Version, project.Version); err != nil {
+		return nil, err
 	}
 
+	before := *project
+
 	// Validar novo cliente se fornecido
 	if req.ClientID != 0 {
-		client, err := s.contactRepo.GetByID(req.ClientID)
+		client, err := fetchOwned(s.access, s.contactRepo.GetByID, req.ClientID, userID, "Cliente")
 		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				return nil, errors.NewNotFoundError("Cliente")
-			}
-			return nil, errors.ErrInternalServer
-		}
-		if client.UserID != userID {
-			return nil, errors.ErrForbidden
+			return nil, err
 		}
 		if client.Type != models.ContactTypeClient {
-			return nil, errors.NewBadRequestError("O contato deve ser do tipo CLIENT")
+			return nil, errors.NewBadRequestError("O contato deve ser do tipo CLIENT para ser associado a um projeto")
 		}
 		project.ClientID = req.ClientID
 	}
@@ -184,16 +269,47 @@ func (s *projectService) Update(userID, projectID uint, req *models.ProjectUpdat
 	if req.Name != "" {
 		project.Name = req.Name
 	}
-	if req.Description != "" {
-		project.Description = req.Description
+	if req.Description != nil {
+		project.Description = *req.Description
 	}
+	statusChanged := req.Status != "" && req.Status != project.Status
 	if req.Status != "" {
 		project.Status = req.Status
 	}
+	if req.StartDate != nil {
+		project.StartDate = req.StartDate
+	}
+	if req.EndDate != nil {
+		project.EndDate = req.EndDate
+	}
+	if err := validateProjectDates(project.StartDate, project.EndDate); err != nil {
+		return nil, err
+	}
+	if req.Budget != nil {
+		project.Budget = *req.Budget
+	}
+	if req.SpentAmount != nil {
+		project.SpentAmount = *req.SpentAmount
+	}
+	if err := validateProjectAmounts(project.Budget, project.SpentAmount); err != nil {
+		return nil, err
+	}
+	if req.ProgressOverride != nil && req.ClearProgressOverride {
+		return nil, errors.NewBadRequestError("Informe progress_override ou clear_progress_override, não ambos")
+	}
+	if req.ProgressOverride != nil {
+		if *req.ProgressOverride < 0 || *req.ProgressOverride > 100 {
+			return nil, errors.NewBadRequestError("O progresso deve estar entre 0 e 100")
+		}
+		project.ProgressOverride = req.ProgressOverride
+	}
+	if req.ClearProgressOverride {
+		project.ProgressOverride = nil
+	}
 
 	// Salvar alterações
 	if err := s.projectRepo.Update(project); err != nil {
-		return nil, errors.ErrInternalServer
+		return nil, updateError(err)
 	}
 
 	// Buscar projeto atualizado com relacionamentos
@@ -202,23 +318,22 @@ func (s *projectService) Update(userID, projectID uint, req *models.ProjectUpdat
 		return nil, errors.ErrInternalServer
 	}
 
+	action := models.ActionUpdated
+	if statusChanged {
+		action = projectStatusAction(updatedProject.Status)
+	}
+	publishActivity(s.publisher, userID, createActivityFromProject(*updatedProject), action)
+	recordAudit(s.auditor, userID, models.AuditEntityProject, updatedProject.ID, models.AuditActionUpdate, &before, updatedProject)
+
 	return updatedProject, nil
 }
 
 // Delete exclui um projeto
 func (s *projectService) Delete(userID, projectID uint) error {
 	// Buscar projeto existente
-	project, err := s.projectRepo.GetByID(projectID)
+	project, err := fetchOwned(s.access, s.projectRepo.GetByID, projectID, userID, "Projeto")
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.NewNotFoundError("Projeto")
-		}
-		return errors.ErrInternalServer
-	}
-
-	// Verificar se o projeto pertence ao usuário
-	if project.UserID != userID {
-		return errors.ErrForbidden
+		return err
 	}
 
 	// Verificar se há tarefas associadas
@@ -236,22 +351,217 @@ func (s *projectService) Delete(userID, projectID uint) error {
 		return errors.ErrInternalServer
 	}
 
+	publishActivity(s.publisher, userID, createActivityFromProject(*project), models.ActionDeleted)
+	recordAudit(s.auditor, userID, models.AuditEntityProject, project.ID, models.AuditActionDelete, project, nil)
+
 	return nil
 }
 
-// GetByClientID obtém projetos de um cliente específico
-func (s *projectService) GetByClientID(userID, clientID uint) ([]models.Project, error) {
-	// Verificar se o cliente existe e pertence ao usuário
-	client, err := s.contactRepo.GetByID(clientID)
+// Restore restaura um projeto excluído do usuário
+func (s *projectService) Restore(userID, projectID uint) (*models.Project, error) {
+	// Verificar se já existe um registro ativo com este ID
+	if live, err := s.projectRepo.GetByID(projectID); err == nil {
+		if live.UserID != userID {
+			return nil, errors.NewNotFoundError("Projeto excluído")
+		}
+		return nil, errors.NewConflictError("Projeto não está excluído")
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, errors.ErrInternalServer
+	}
+
+	// Buscar registro excluído (soft delete)
+	deleted, err := s.projectRepo.GetDeletedByID(projectID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Cliente")
+			return nil, errors.NewNotFoundError("Projeto excluído")
+		}
+		return nil, errors.ErrInternalServer
+	}
+
+	// Apenas o dono pode restaurar
+	if deleted.UserID != userID {
+		return nil, errors.NewNotFoundError("Projeto excluído")
+	}
+
+	// O cliente pode ter voltado a LEAD depois da exclusão do projeto
+	if client, err := s.contactRepo.GetByID(deleted.ClientID); err == nil && client.Type != models.ContactTypeClient {
+		return nil, errors.NewBadRequestError("O contato deve ser do tipo CLIENT para ser associado a um projeto")
+	}
+
+	// Projetos excluídos não contam no limite: restaurar equivale a criar um novo
+	if err := checkQuota(s.projectRepo.CountByUserID, userID, s.maxProjects, "projetos"); err != nil {
+		return nil, err
+	}
+
+	if err := s.projectRepo.Restore(projectID); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	restored, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	recordAudit(s.auditor, userID, models.AuditEntityProject, restored.ID, models.AuditActionRestore, nil, restored)
+
+	return restored, nil
+}
+
+// DuplicateProject cria uma cópia de um projeto do usuário, com o mesmo cliente e descrição e o nome
+// acrescido de ProjectCopySuffix, em andamento. Com IncludeTasks, as tarefas do projeto também são
+// copiadas, reabertas como PENDING e com os vencimentos limpos ou deslocados em ShiftDays dias.
+// O projeto e as tarefas são criados na mesma transação.
+func (s *projectService) DuplicateProject(userID, projectID uint, req *models.ProjectDuplicateRequest) (*models.Project, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	// Apenas o dono pode duplicar o projeto
+	source, err := fetchOwned(s.access, s.projectRepo.GetByID, projectID, userID, "Projeto")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkQuota(s.projectRepo.CountByUserID, userID, s.maxProjects, "projetos"); err != nil {
+		return nil, err
+	}
+
+	// O cliente pode ter voltado a LEAD depois da criação do projeto
+	client, err := fetchOwned(s.access, s.contactRepo.GetByID, source.ClientID, userID, "Cliente")
+	if err != nil {
+		return nil, err
+	}
+	if client.Type != models.ContactTypeClient {
+		return nil, errors.NewBadRequestError("O contato deve ser do tipo CLIENT para ser associado a um projeto")
+	}
+
+	var tasks []models.Task
+	if req.IncludeTasks {
+		if tasks, err = s.taskRepo.GetByProjectID(projectID); err != nil {
+			return nil, errors.ErrInternalServer
+		}
+	}
+
+	project := &models.Project{
+		Name:        duplicateProjectName(source.Name),
+		Description: source.Description,
+		Status:      models.ProjectStatusInProgress,
+		UserID:      userID,
+		ClientID:    source.ClientID,
+	}
+
+	err = s.transactor.Transaction(func(tx *gorm.DB) error {
+		if err := s.projectRepo.WithTx(tx).Create(project); err != nil {
+			return errors.ErrInternalServer
+		}
+
+		copies := make([]models.Task, 0, len(tasks))
+		for _, task := range tasks {
+			copies = append(copies, duplicateTask(task, userID, project.ID, req.ShiftDays))
+		}
+		if err := s.taskRepo.WithTx(tx).CreateBatch(copies); err != nil {
+			return errors.ErrInternalServer
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	createdProject, err := s.projectRepo.GetByID(project.ID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	publishActivity(s.publisher, userID, createActivityFromProject(*createdProject), models.ActionCreated)
+	recordAudit(s.auditor, userID, models.AuditEntityProject, createdProject.ID, models.AuditActionCreate, nil, createdProject)
+
+	return createdProject, nil
+}
+
+// duplicateProjectName acrescenta ProjectCopySuffix ao nome, encurtando-o se necessário para
+// respeitar o tamanho máximo
+func duplicateProjectName(name string) string {
+	const maxNameLength = 255
+	runes := []rune(name)
+	if limit := maxNameLength - len([]rune(models.ProjectCopySuffix)); len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return string(runes) + models.ProjectCopySuffix
+}
+
+// duplicateTask cria a cópia de uma tarefa para o projeto projectID, reaberta como PENDING.
+// O vencimento é deslocado em shiftDays dias ou, se nil, removido. O contato só é mantido
+// quando pertence ao usuário (tarefas de colaboradores podem apontar para contatos deles).
+func duplicateTask(task models.Task, userID, projectID uint, shiftDays *int) models.Task {
+	duplicate := models.Task{
+		Title:          task.Title,
+		Description:    task.Description,
+		Priority:       task.Priority,
+		Status:         models.TaskStatusPending,
+		UserID:         userID,
+		ProjectID:      &projectID,
+		RecurrenceRule: task.RecurrenceRule,
+	}
+	if task.Contact != nil && task.Contact.UserID == userID {
+		duplicate.ContactID = task.ContactID
+	}
+	if task.DueDate != nil && shiftDays != nil {
+		dueDate := task.DueDate.AddDate(0, 0, *shiftDays)
+		duplicate.DueDate = &dueDate
+	}
+	return duplicate
+}
+
+// validateProjectDates garante que a data de término não seja anterior à data de início
+func validateProjectDates(startDate, endDate *time.Time) error {
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return errors.NewBadRequestError("A data de término não pode ser anterior à data de início")
+	}
+	return nil
+}
+
+// validateProjectAmounts garante que orçamento e valor gasto não sejam negativos
+func validateProjectAmounts(budget, spentAmount int64) error {
+	if budget < 0 {
+		return errors.NewBadRequestError("O orçamento não pode ser negativo")
+	}
+	if spentAmount < 0 {
+		return errors.NewBadRequestError("O valor gasto não pode ser negativo")
+	}
+	return nil
+}
+
+// GetBoard obtém os projetos do usuário agrupados por status para visualização em quadro
+func (s *projectService) GetBoard(userID uint) (ProjectBoard, error) {
+	projects, err := s.projectRepo.GetBoardByUserID(userID)
+	if err != nil {
 		return nil, errors.ErrInternalServer
 	}
 
-	if client.UserID != userID {
-		return nil, errors.ErrForbidden
+	board := make(ProjectBoard, len(models.ProjectStatuses))
+	for _, status := range models.ProjectStatuses {
+		board[status] = []DashboardProject{}
+	}
+
+	for _, project := range projects {
+		board[project.Status] = append(board[project.Status], DashboardProject{
+			ID:         project.ID,
+			Name:       project.Name,
+			Status:     project.Status,
+			ClientName: project.Client.Name,
+			CreatedAt:  project.CreatedAt,
+		})
+	}
+
+	return board, nil
+}
+
+// GetByClientID obtém projetos de um cliente específico
+func (s *projectService) GetByClientID(userID, clientID uint) ([]models.Project, error) {
+	// Verificar se o cliente existe e pertence ao usuário
+	if _, err := fetchOwned(s.access, s.contactRepo.GetByID, clientID, userID, "Cliente"); err != nil {
+		return nil, err
 	}
 
 	projects, err := s.projectRepo.GetByClientID(clientID)
@@ -290,29 +600,48 @@ func (s *projectService) GetProjectSummary(userID, projectID uint) (*ProjectSumm
 		TotalTasks: int64(len(tasks)),
 	}
 
-	var completedTasks, pendingTasks, overdueTasks int64
+	var completedTasks, pendingTasks, inProgressTasks, overdueTasks int64
+	now := time.Now()
 	for _, task := range tasks {
 		if task.Status == models.TaskStatusCompleted {
 			completedTasks++
+			continue
+		}
+
+		if task.Status == models.TaskStatusInProgress {
+			inProgressTasks++
 		} else {
 			pendingTasks++
-			// Verificar se está em atraso (implementação básica)
-			// Em uma implementação mais robusta, isso seria feito no repository
-			if task.DueDate != nil {
-				// Lógica para verificar se está em atraso
-				// overdueTasks++
-			}
+		}
+		// Tarefa não concluída com vencimento no passado está em atraso
+		if task.DueDate != nil && task.DueDate.Before(now) {
+			overdueTasks++
 		}
 	}
 
 	summary.CompletedTasks = completedTasks
 	summary.PendingTasks = pendingTasks
+	summary.InProgressTasks = inProgressTasks
 	summary.OverdueTasks = overdueTasks
 
-	// Calcular progresso
-	if summary.TotalTasks > 0 {
+	// Calcular progresso (o valor manual, se definido, substitui o calculado pelas tarefas)
+	if project.ProgressOverride != nil {
+		summary.TasksProgress = *project.ProgressOverride
+		summary.ProgressIsManual = true
+	} else if summary.TotalTasks > 0 {
 		summary.TasksProgress = float64(completedTasks) / float64(summary.TotalTasks) * 100
 	}
 
+	summary.IsOverdue = project.Status == models.ProjectStatusInProgress &&
+		project.EndDate != nil && project.EndDate.Before(now)
+
+	// Calcular uso do orçamento
+	summary.RemainingBudget = project.Budget - project.SpentAmount
+	if project.Budget > 0 {
+		summary.OverBudget = project.SpentAmount > project.Budget
+		usage := float64(project.SpentAmount) / float64(project.Budget) * 100
+		summary.BudgetUtilization = &usage
+	}
+
 	return summary, nil
 }
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestProjectServiceGetProjectSummaryCountsOverdueTasks(t *testing.T) {
+	projectID := uint(3)
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+	task := func(id uint, status models.TaskStatus, due *time.Time) models.Task {
+		return models.Task{ID: id, UserID: 7, ProjectID: &projectID, Status: status, DueDate: due}
+	}
+
+	taskRepo := newFakeTaskRepo(
+		task(1, models.TaskStatusPending, &past),    // atrasada
+		task(2, models.TaskStatusInProgress, &past), // atrasada
+		task(3, models.TaskStatusCompleted, &past),  // concluída não conta como atrasada
+		task(4, models.TaskStatusPending, &future),  // ainda no prazo
+		task(5, models.TaskStatusPending, nil),      // sem vencimento
+	)
+	projectRepo := newFakeProjectRepo(models.Project{ID: projectID, UserID: 7, Status: models.ProjectStatusInProgress})
+	service := NewProjectService(projectRepo, nil, taskRepo, nil, nil, nil, 0, &fakeAuditor{}, AccessPolicy{})
+
+	summary, err := service.GetProjectSummary(7, projectID)
+	if err != nil {
+		t.Fatalf("GetProjectSummary: %v", err)
+	}
+
+	if summary.TotalTasks != 5 || summary.CompletedTasks != 1 || summary.PendingTasks != 3 || summary.InProgressTasks != 1 {
+		t.Errorf("contagens = total %d, concluídas %d, pendentes %d, em andamento %d; want 5, 1, 3, 1",
+			summary.TotalTasks, summary.CompletedTasks, summary.PendingTasks, summary.InProgressTasks)
+	}
+	if summary.OverdueTasks != 2 {
+		t.Errorf("OverdueTasks = %d, want 2", summary.OverdueTasks)
+	}
+	if summary.TasksProgress != 20 {
+		t.Errorf("TasksProgress = %v, want 20", summary.TasksProgress)
+	}
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"crm-backend/pkg/errors"
+	"crm-backend/pkg/logger"
+	"fmt"
+	"net/http"
+)
+
+// checkQuota verifica se o usuário ainda pode criar um registro do recurso, dado o limite por
+// usuário (0 ou negativo: sem limite) e a função que conta os registros atuais do usuário.
+// Ao atingir o limite retorna 403 e registra um aviso.
+func checkQuota(count func(userID uint) (int64, error), userID uint, limit int, resource string) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	current, err := count(userID)
+	if err != nil {
+		return errors.ErrInternalServer
+	}
+	if current < int64(limit) {
+		return nil
+	}
+
+	logger.WithFields("WARNING", "Limite de registros por usuário atingido", map[string]interface{}{
+		"user_id":  userID,
+		"resource": resource,
+		"limit":    limit,
+		"current":  current,
+	})
+	return errors.NewAppError(http.StatusForbidden, "Limite do plano atingido",
+		fmt.Sprintf("O limite é de %d %s por usuário", limit, resource))
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	stderrors "errors"
+	"net/http"
+	"testing"
+)
+
+func TestCheckQuota(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int
+		current    int64
+		countErr   error
+		wantStatus int // 0: permitido
+		wantCount  bool
+	}{
+		{name: "abaixo do limite", limit: 5, current: 4, wantCount: true},
+		{name: "no limite", limit: 5, current: 5, wantStatus: http.StatusForbidden, wantCount: true},
+		{name: "acima do limite", limit: 5, current: 7, wantStatus: http.StatusForbidden, wantCount: true},
+		{name: "limite zero não limita", limit: 0, current: 1000},
+		{name: "limite negativo não limita", limit: -1, current: 1000},
+		{name: "falha na contagem", limit: 5, countErr: stderrors.New("db down"), wantStatus: http.StatusInternalServerError, wantCount: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counted := false
+			count := func(userID uint) (int64, error) {
+				counted = true
+				return tt.current, tt.countErr
+			}
+
+			err := checkQuota(count, 7, tt.limit, "contatos")
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("checkQuota = %v, want nil", err)
+				}
+			} else {
+				assertAppError(t, err, tt.wantStatus)
+			}
+			if counted != tt.wantCount {
+				t.Errorf("contagem executada = %v, want %v", counted, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestContactServiceRestoreChecksQuota(t *testing.T) {
+	tests := []struct {
+		name       string
+		active     int
+		limit      int
+		wantStatus int
+	}{
+		{name: "abaixo do limite", active: 1, limit: 2},
+		{name: "no limite", active: 2, limit: 2, wantStatus: http.StatusForbidden},
+		{name: "sem limite", active: 2, limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contactRepo := newFakeContactRepo()
+			for i := 0; i < tt.active; i++ {
+				contactRepo.contacts[uint(i+1)] = models.Contact{ID: uint(i + 1), UserID: 7}
+			}
+			contactRepo.deleted[10] = models.Contact{ID: 10, UserID: 7, Name: "Excluído"}
+
+			service := NewContactService(contactRepo, nil, nil, nil, nil, nil, nil, nil, "BR", tt.limit, &fakeAuditor{}, AccessPolicy{})
+			_, err := service.Restore(7, 10, false)
+
+			if tt.wantStatus != 0 {
+				assertAppError(t, err, tt.wantStatus)
+				if _, ok := contactRepo.deleted[10]; !ok {
+					t.Error("o contato não deveria ter sido restaurado")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Restore: %v", err)
+			}
+		})
+	}
+}
+
+func TestProjectServiceRestoreChecksQuota(t *testing.T) {
+	tests := []struct {
+		name       string
+		active     int
+		limit      int
+		wantStatus int
+	}{
+		{name: "abaixo do limite", active: 1, limit: 2},
+		{name: "no limite", active: 2, limit: 2, wantStatus: http.StatusForbidden},
+		{name: "sem limite", active: 2, limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contactRepo := newFakeContactRepo(models.Contact{ID: 1, UserID: 7, Type: models.ContactTypeClient})
+			projectRepo := newFakeProjectRepo()
+			for i := 0; i < tt.active; i++ {
+				projectRepo.projects[uint(i+1)] = models.Project{ID: uint(i + 1), UserID: 7, ClientID: 1}
+			}
+			projectRepo.deleted[10] = models.Project{ID: 10, UserID: 7, ClientID: 1, Name: "Excluído"}
+
+			service := NewProjectService(projectRepo, contactRepo, nil, nil, nil, nil, tt.limit, &fakeAuditor{}, AccessPolicy{})
+			_, err := service.Restore(7, 10)
+
+			if tt.wantStatus != 0 {
+				assertAppError(t, err, tt.wantStatus)
+				if _, ok := projectRepo.deleted[10]; !ok {
+					t.Error("o projeto não deveria ter sido restaurado")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Restore: %v", err)
+			}
+		})
+	}
+}
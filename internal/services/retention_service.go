@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/logger"
+	"os"
+	"time"
+)
+
+// retentionPurgeInterval define a frequência da limpeza de registros excluídos
+const retentionPurgeInterval = 24 * time.Hour
+
+// RetentionService define a interface da limpeza de registros excluídos (soft delete)
+type RetentionService interface {
+	PurgeExpired() map[string]int64
+	StartPurgeJob(ctx context.Context)
+}
+
+// retentionService implementa RetentionService
+type retentionService struct {
+	retentionRepo repositories.RetentionRepository
+	retention     time.Duration
+}
+
+// NewRetentionService cria o serviço que remove definitivamente os registros excluídos
+// há mais de retentionDays dias
+func NewRetentionService(retentionRepo repositories.RetentionRepository, retentionDays int) RetentionService {
+	return &retentionService{
+		retentionRepo: retentionRepo,
+		retention:     time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// PurgeExpired remove definitivamente os registros excluídos antes do período de retenção e
+// retorna quantas linhas foram removidas por tabela. Uma falha em uma tabela é registrada no
+// log e não impede a limpeza das demais.
+func (s *retentionService) PurgeExpired() map[string]int64 {
+	cutoff := time.Now().Add(-s.retention)
+	purged := make(map[string]int64)
+
+	attachments, paths, err := s.retentionRepo.PurgeAttachments(cutoff)
+	s.record(purged, "attachments", attachments, err)
+	if err == nil {
+		for _, path := range paths {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				logger.LogError(err, "Falha ao remover arquivo de anexo expirado", map[string]interface{}{
+					"path": path,
+				})
+			}
+		}
+	}
+
+	steps := []struct {
+		table string
+		purge func(time.Time) (int64, error)
+	}{
+		{"interactions", s.retentionRepo.PurgeInteractions},
+		{"tasks", s.retentionRepo.PurgeTasks},
+		{"projects", s.retentionRepo.PurgeProjects},
+		{"contacts", s.retentionRepo.PurgeContacts},
+	}
+	for _, step := range steps {
+		count, err := step.purge(cutoff)
+		s.record(purged, step.table, count, err)
+	}
+
+	return purged
+}
+
+// record registra no log o resultado da limpeza de uma tabela
+func (s *retentionService) record(purged map[string]int64, table string, count int64, err error) {
+	if err != nil {
+		logger.LogError(err, "Soft Delete Purge", map[string]interface{}{
+			"table": table,
+		})
+		return
+	}
+
+	purged[table] = count
+	logger.WithFields("INFO", "Soft Deleted Records Purged", map[string]interface{}{
+		"table":  table,
+		"purged": count,
+	})
+}
+
+// StartPurgeJob executa PurgeExpired diariamente até o contexto ser cancelado
+func (s *retentionService) StartPurgeJob(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(retentionPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			s.PurgeExpired()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"strings"
+)
+
+// searchResultsPerCategory limita quantos resultados são retornados por tipo de entidade
+const searchResultsPerCategory = 10
+
+// SearchService define a interface para a busca global
+type SearchService interface {
+	Search(userID uint, term string) (*SearchResults, error)
+}
+
+// SearchResults representa os resultados da busca global agrupados por tipo
+type SearchResults struct {
+	Contacts     []models.Contact     `json:"contacts"`
+	Tasks        []models.Task        `json:"tasks"`
+	Projects     []models.Project     `json:"projects"`
+	Interactions []models.Interaction `json:"interactions"`
+}
+
+// searchService implementa SearchService
+type searchService struct {
+	contactRepo     repositories.ContactRepository
+	taskRepo        repositories.TaskRepository
+	projectRepo     repositories.ProjectRepository
+	interactionRepo repositories.InteractionRepository
+}
+
+// NewSearchService cria uma nova instância do serviço de busca
+func NewSearchService(
+	contactRepo repositories.ContactRepository,
+	taskRepo repositories.TaskRepository,
+	projectRepo repositories.ProjectRepository,
+	interactionRepo repositories.InteractionRepository,
+) SearchService {
+	return &searchService{
+		contactRepo:     contactRepo,
+		taskRepo:        taskRepo,
+		projectRepo:     projectRepo,
+		interactionRepo: interactionRepo,
+	}
+}
+
+// Search busca o termo em contatos, tarefas, projetos e interações do usuário
+func (s *searchService) Search(userID uint, term string) (*SearchResults, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, errors.NewBadRequestError("Termo de busca é obrigatório")
+	}
+
+	results := &SearchResults{
+		Contacts:     []models.Contact{},
+		Tasks:        []models.Task{},
+		Projects:     []models.Project{},
+		Interactions: []models.Interaction{},
+	}
+
+	// Contatos (nome, email e empresa)
+	contacts, err := s.contactRepo.GetByUserID(userID, &models.ContactListFilter{
+		Search: term,
+		Limit:  searchResultsPerCategory,
+	})
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	if len(contacts) > 0 {
+		results.Contacts = contacts
+	}
+
+	// Tarefas (título e descrição)
+	tasks, err := s.taskRepo.Search(userID, term, searchResultsPerCategory)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	if len(tasks) > 0 {
+		results.Tasks = tasks
+	}
+
+	// Projetos (nome e descrição)
+	projects, err := s.projectRepo.Search(userID, term, searchResultsPerCategory)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	if len(projects) > 0 {
+		results.Projects = projects
+	}
+
+	// Interações (assunto e descrição)
+	interactions, err := s.interactionRepo.Search(userID, term, searchResultsPerCategory)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	if len(interactions) > 0 {
+		results.Interactions = interactions
+	}
+
+	return results, nil
+}
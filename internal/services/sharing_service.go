@@ -0,0 +1,150 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"crm-backend/pkg/validation"
+
+	"gorm.io/gorm"
+)
+
+// SharingService define a interface para compartilhamento de recursos entre usuários
+type SharingService interface {
+	ShareProject(userID, projectID uint, req *models.ShareRequest) error
+	GetProjectShares(userID, projectID uint) ([]models.SharedResource, error)
+	RevokeProjectShare(userID, projectID, shareID uint) error
+	GetProjectsSharedWithUser(userID uint) ([]models.Project, error)
+}
+
+// sharingService implementa SharingService
+type sharingService struct {
+	shareRepo   repositories.SharedResourceRepository
+	projectRepo repositories.ProjectRepository
+	userRepo    repositories.UserRepository
+	access      AccessPolicy
+}
+
+// NewSharingService cria uma nova instância do serviço de compartilhamento
+func NewSharingService(
+	shareRepo repositories.SharedResourceRepository,
+	projectRepo repositories.ProjectRepository,
+	userRepo repositories.UserRepository,
+	access AccessPolicy,
+) SharingService {
+	return &sharingService{
+		shareRepo:   shareRepo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+		access:      access,
+	}
+}
+
+// ShareProject compartilha um projeto do usuário com outro usuário, identificado pelo email.
+// Emails não cadastrados e projetos já compartilhados com o usuário não geram erro, para que a
+// resposta não revele quais emails pertencem a usuários.
+func (s *sharingService) ShareProject(userID, projectID uint, req *models.ShareRequest) error {
+	if err := validation.Struct(req); err != nil {
+		return err
+	}
+
+	if _, err := fetchOwned(s.access, s.projectRepo.GetByID, projectID, userID, "Projeto"); err != nil {
+		return err
+	}
+
+	if req.Email == "" {
+		return errors.NewBadRequestError("Email do usuário é obrigatório")
+	}
+
+	// Por enquanto apenas compartilhamento somente leitura é suportado
+	if req.Permission == "" {
+		req.Permission = models.SharePermissionRead
+	}
+	if req.Permission != models.SharePermissionRead {
+		return errors.NewBadRequestError("Permissão inválida. Valores aceitos: READ")
+	}
+
+	target, err := s.userRepo.GetByEmail(models.NormalizeEmail(req.Email))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return errors.ErrInternalServer
+	}
+
+	// O próprio email é conhecido pelo usuário, então recusá-lo não revela nada
+	if target.ID == userID {
+		return errors.NewBadRequestError("Não é possível compartilhar um projeto com você mesmo")
+	}
+
+	exists, err := s.shareRepo.Exists(models.SharedResourceProject, projectID, target.ID)
+	if err != nil {
+		return errors.ErrInternalServer
+	}
+	if exists {
+		return nil
+	}
+
+	share := &models.SharedResource{
+		ResourceType: models.SharedResourceProject,
+		ResourceID:   projectID,
+		OwnerID:      userID,
+		SharedWithID: target.ID,
+		Permission:   req.Permission,
+	}
+
+	if err := s.shareRepo.Create(share); err != nil {
+		return errors.ErrInternalServer
+	}
+
+	return nil
+}
+
+// GetProjectShares lista os compartilhamentos de um projeto do usuário
+func (s *sharingService) GetProjectShares(userID, projectID uint) ([]models.SharedResource, error) {
+	if _, err := fetchOwned(s.access, s.projectRepo.GetByID, projectID, userID, "Projeto"); err != nil {
+		return nil, err
+	}
+
+	shares, err := s.shareRepo.GetByResource(models.SharedResourceProject, projectID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return shares, nil
+}
+
+// RevokeProjectShare remove um compartilhamento de um projeto do usuário
+func (s *sharingService) RevokeProjectShare(userID, projectID, shareID uint) error {
+	if _, err := fetchOwned(s.access, s.projectRepo.GetByID, projectID, userID, "Projeto"); err != nil {
+		return err
+	}
+
+	share, err := s.shareRepo.GetByID(shareID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewNotFoundError("Compartilhamento")
+		}
+		return errors.ErrInternalServer
+	}
+
+	if share.ResourceType != models.SharedResourceProject || share.ResourceID != projectID {
+		return errors.NewNotFoundError("Compartilhamento")
+	}
+
+	if err := s.shareRepo.Delete(shareID); err != nil {
+		return errors.ErrInternalServer
+	}
+
+	return nil
+}
+
+// GetProjectsSharedWithUser lista os projetos de outros usuários compartilhados com o usuário
+func (s *sharingService) GetProjectsSharedWithUser(userID uint) ([]models.Project, error) {
+	projects, err := s.projectRepo.GetSharedWithUser(userID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return projects, nil
+}
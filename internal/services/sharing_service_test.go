@@ -0,0 +1,61 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"net/http"
+	"testing"
+)
+
+func TestSharingServiceShareProject(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      uint
+		email       string
+		wantStatus  int // 0: resposta de sucesso
+		wantCreated int
+	}{
+		{name: "email cadastrado", userID: 7, email: "ana@example.com", wantCreated: 1},
+		{name: "email cadastrado em outro formato", userID: 7, email: "Ana@Example.com", wantCreated: 1},
+		{name: "email não cadastrado", userID: 7, email: "ninguem@example.com"},
+		{name: "próprio email", userID: 7, email: "dono@example.com", wantStatus: http.StatusBadRequest},
+		{name: "projeto de outro usuário", userID: 8, email: "ana@example.com", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shareRepo := &fakeShareRepo{}
+			userRepo := newFakeUserRepo(
+				models.User{ID: 7, Email: "dono@example.com"},
+				models.User{ID: 9, Email: "ana@example.com"},
+			)
+			service := NewSharingService(shareRepo, newFakeProjectRepo(models.Project{ID: 3, UserID: 7}), userRepo, AccessPolicy{})
+
+			err := service.ShareProject(tt.userID, 3, &models.ShareRequest{Email: tt.email})
+			if tt.wantStatus != 0 {
+				assertAppError(t, err, tt.wantStatus)
+			} else if err != nil {
+				t.Fatalf("ShareProject: %v", err)
+			}
+
+			if len(shareRepo.created) != tt.wantCreated {
+				t.Fatalf("compartilhamentos criados = %d, want %d", len(shareRepo.created), tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestSharingServiceShareProjectTwice(t *testing.T) {
+	shareRepo := &fakeShareRepo{}
+	userRepo := newFakeUserRepo(models.User{ID: 9, Email: "ana@example.com"})
+	service := NewSharingService(shareRepo, newFakeProjectRepo(models.Project{ID: 3, UserID: 7}), userRepo, AccessPolicy{})
+
+	// Compartilhar de novo responde como o primeiro compartilhamento, sem duplicar
+	for i := 0; i < 2; i++ {
+		if err := service.ShareProject(7, 3, &models.ShareRequest{Email: "ana@example.com"}); err != nil {
+			t.Fatalf("ShareProject #%d: %v", i+1, err)
+		}
+	}
+	if len(shareRepo.created) != 1 {
+		t.Fatalf("compartilhamentos criados = %d, want 1", len(shareRepo.created))
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"fmt"
+	"strings"
+)
+
+// TaskCommentService define a interface para operações de comentários de tarefas
+type TaskCommentService interface {
+	Create(userID, taskID uint, req *models.TaskCommentCreateRequest) (*models.TaskCommentResponse, error)
+	GetByTaskID(userID, taskID uint) ([]models.TaskCommentResponse, error)
+}
+
+// taskCommentService implementa TaskCommentService
+type taskCommentService struct {
+	commentRepo repositories.TaskCommentRepository
+	taskRepo    repositories.TaskRepository
+	access      AccessPolicy
+}
+
+// NewTaskCommentService cria uma nova instância do serviço de comentários de tarefas
+func NewTaskCommentService(
+	commentRepo repositories.TaskCommentRepository,
+	taskRepo repositories.TaskRepository,
+	access AccessPolicy,
+) TaskCommentService {
+	return &taskCommentService{
+		commentRepo: commentRepo,
+		taskRepo:    taskRepo,
+		access:      access,
+	}
+}
+
+// Create registra um comentário em uma tarefa do usuário
+func (s *taskCommentService) Create(userID, taskID uint, req *models.TaskCommentCreateRequest) (*models.TaskCommentResponse, error) {
+	// Verificar se a tarefa existe e pertence ao usuário
+	if _, err := fetchOwned(s.access, s.taskRepo.GetByID, taskID, userID, "Tarefa"); err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.NewBadRequestError("O comentário não pode ser vazio")
+	}
+	if len([]rune(body)) > models.MaxTaskCommentLength {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("O comentário deve ter no máximo %d caracteres", models.MaxTaskCommentLength))
+	}
+
+	comment := &models.TaskComment{
+		TaskID: taskID,
+		UserID: userID,
+		Body:   body,
+	}
+
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	// Buscar comentário criado com o autor
+	created, err := s.commentRepo.GetByID(comment.ID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return &models.TaskCommentResponse{TaskComment: *created, AuthorName: created.User.Name}, nil
+}
+
+// GetByTaskID lista os comentários de uma tarefa do usuário, dos mais recentes aos mais antigos
+func (s *taskCommentService) GetByTaskID(userID, taskID uint) ([]models.TaskCommentResponse, error) {
+	// Verificar se a tarefa existe e pertence ao usuário
+	if _, err := fetchOwned(s.access, s.taskRepo.GetByID, taskID, userID, "Tarefa"); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	responses := make([]models.TaskCommentResponse, len(comments))
+	for i, comment := range comments {
+		responses[i] = models.TaskCommentResponse{TaskComment: comment, AuthorName: comment.User.Name}
+	}
+
+	return responses, nil
+}
@@ -1,9 +1,15 @@
 package services
 
 import (
+	"crm-backend/internal/events"
 	"crm-backend/internal/models"
 	"crm-backend/internal/repositories"
 	"crm-backend/pkg/errors"
+	"crm-backend/pkg/logger"
+	"crm-backend/pkg/validation"
+	"fmt"
+	"sort"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -13,14 +19,40 @@ type TaskService interface {
 	Create(userID uint, req *models.TaskCreateRequest) (*models.Task, error)
 	GetByID(userID, taskID uint) (*models.Task, error)
 	GetByUserID(userID uint, filter *models.TaskListFilter) ([]models.Task, error)
+	GetPaginatedByUserID(userID uint, filter *models.TaskListFilter) (*models.PaginatedResponse[models.Task], error)
+	GetChangedByUserID(userID uint, filter *models.TaskListFilter) (*models.PaginatedResponse[models.ChangedTask], error)
 	Update(userID, taskID uint, req *models.TaskUpdateRequest) (*models.Task, error)
 	Delete(userID, taskID uint) error
+	DeleteCompleted(userID uint, before *time.Time) (int64, error)
+	Restore(userID, taskID uint) (*models.Task, error)
 	MarkAsCompleted(userID, taskID uint) (*models.Task, error)
 	MarkAsPending(userID, taskID uint) (*models.Task, error)
+	MarkAsInProgress(userID, taskID uint) (*models.Task, error)
+	Snooze(userID, taskID uint, until time.Time) (*models.Task, error)
 	GetByContactID(userID, contactID uint) ([]models.Task, error)
+	GetAllByContactID(userID, contactID uint) ([]models.Task, error)
 	GetByProjectID(userID, projectID uint) ([]models.Task, error)
 	GetOverdueTasks(userID uint) ([]models.Task, error)
+	GetOverdueByContactID(userID, contactID uint) ([]models.Task, error)
+	GetOverdueByProjectID(userID, projectID uint) ([]models.Task, error)
 	GetUpcomingTasks(userID uint, days int) ([]models.Task, error)
+	GetStats(userID uint) (*TaskStats, error)
+	GetCalendar(userID uint, from, to string) (map[string][]models.Task, error)
+	GetMyDay(userID uint) (*MyDayTasks, error)
+}
+
+// TaskStats representa a contagem das tarefas do usuário por status e por prioridade
+type TaskStats struct {
+	ByStatus   map[models.TaskStatus]int64 `json:"by_status"`
+	ByPriority map[models.Priority]int64   `json:"by_priority"`
+}
+
+// MyDayTasks representa a visão diária das tarefas do usuário: atrasadas, com vencimento no restante
+// de hoje e com vencimento nos próximos dias
+type MyDayTasks struct {
+	Overdue  []models.Task `json:"overdue"`
+	Today    []models.Task `json:"today"`
+	Upcoming []models.Task `json:"upcoming"`
 }
 
 // taskService implementa TaskService
@@ -28,6 +60,16 @@ type taskService struct {
 	taskRepo    repositories.TaskRepository
 	contactRepo repositories.ContactRepository
 	projectRepo repositories.ProjectRepository
+	shareRepo   repositories.SharedResourceRepository
+	userRepo    repositories.UserRepository
+	transactor  repositories.Transactor
+	publisher   events.Publisher
+	auditor     AuditRecorder
+
+	// Rejeitar (true) ou apenas registrar (false) tarefas cujo projeto pertence a outro cliente
+	strictAssociations bool
+
+	access AccessPolicy
 }
 
 // NewTaskService cria uma nova instância do serviço de tarefas
@@ -35,53 +77,73 @@ func NewTaskService(
 	taskRepo repositories.TaskRepository,
 	contactRepo repositories.ContactRepository,
 	projectRepo repositories.ProjectRepository,
+	shareRepo repositories.SharedResourceRepository,
+	userRepo repositories.UserRepository,
+	transactor repositories.Transactor,
+	publisher events.Publisher,
+	strictAssociations bool,
+	auditor AuditRecorder,
+	access AccessPolicy,
 ) TaskService {
 	return &taskService{
-		taskRepo:    taskRepo,
-		contactRepo: contactRepo,
-		projectRepo: projectRepo,
+		taskRepo:           taskRepo,
+		contactRepo:        contactRepo,
+		projectRepo:        projectRepo,
+		shareRepo:          shareRepo,
+		userRepo:           userRepo,
+		transactor:         transactor,
+		publisher:          publisher,
+		auditor:            auditor,
+		strictAssociations: strictAssociations,
+		access:             access,
 	}
 }
 
 // Create cria uma nova tarefa
 func (s *taskService) Create(userID uint, req *models.TaskCreateRequest) (*models.Task, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
 	// Validar associações se fornecidas
 	if req.ContactID != nil {
-		contact, err := s.contactRepo.GetByID(*req.ContactID)
-		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				return nil, errors.NewNotFoundError("Contato")
-			}
-			return nil, errors.ErrInternalServer
-		}
-		if contact.UserID != userID {
-			return nil, errors.ErrForbidden
+		if _, err := fetchOwned(s.access, s.contactRepo.GetByID, *req.ContactID, userID, "Contato"); err != nil {
+			return nil, err
 		}
 	}
 
+	var project *models.Project
 	if req.ProjectID != nil {
-		project, err := s.projectRepo.GetByID(*req.ProjectID)
-		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				return nil, errors.NewNotFoundError("Projeto")
-			}
-			return nil, errors.ErrInternalServer
-		}
-		if project.UserID != userID {
-			return nil, errors.ErrForbidden
+		var err error
+		if project, err = fetchOwned(s.access, s.projectRepo.GetByID, *req.ProjectID, userID, "Projeto"); err != nil {
+			return nil, err
 		}
 	}
 
+	if err := s.checkProjectClient(userID, req.ContactID, req.ProjectID, project); err != nil {
+		return nil, err
+	}
+
+	// Validar regra de recorrência
+	recurrence := req.RecurrenceRule
+	if recurrence == "" {
+		recurrence = models.RecurrenceNone
+	}
+	if !recurrence.IsValid() {
+		return nil, errors.NewBadRequestError("Regra de recorrência inválida. Use: NONE, DAILY, WEEKLY ou MONTHLY")
+	}
+
 	// Criar tarefa
 	task := &models.Task{
-		Title:       req.Title,
-		Description: req.Description,
-		DueDate:     req.DueDate,
-		Priority:    req.Priority,
-		Status:      models.TaskStatusPending, // Sempre criar como PENDING (uncomplete)
-		UserID:      userID,
-		ContactID:   req.ContactID,
-		ProjectID:   req.ProjectID,
+		Title:          req.Title,
+		Description:    req.Description,
+		DueDate:        req.DueDate,
+		Priority:       req.Priority,
+		Status:         models.TaskStatusPending, // Sempre criar como PENDING; req.Status é ignorado
+		UserID:         userID,
+		ContactID:      req.ContactID,
+		ProjectID:      req.ProjectID,
+		RecurrenceRule: recurrence,
 	}
 
 	if err := s.taskRepo.Create(task); err != nil {
@@ -94,6 +156,9 @@ func (s *taskService) Create(userID uint, req *models.TaskCreateRequest) (*model
 		return nil, errors.ErrInternalServer
 	}
 
+	publishActivity(s.publisher, userID, createActivityFromTask(*createdTask), models.ActionCreated)
+	recordAudit(s.auditor, userID, models.AuditEntityTask, createdTask.ID, models.AuditActionCreate, nil, createdTask)
+
 	return createdTask, nil
 }
 
@@ -108,8 +173,16 @@ func (s *taskService) GetByID(userID, taskID uint) (*models.Task, error) {
 	}
 
 	// Verificar se a tarefa pertence ao usuário
-	if task.UserID != userID {
-		return nil, errors.ErrForbidden
+	if task.UserID == userID {
+		return task, nil
+	}
+
+	// Tarefas de projetos compartilhados podem ser visualizadas
+	if task.ProjectID == nil {
+		return nil, s.access.ownershipError("Tarefa")
+	}
+	if err := authorizeAccess(s.access, s.shareRepo, models.SharedResourceProject, *task.ProjectID, task.UserID, userID, models.SharePermissionRead, "Tarefa"); err != nil {
+		return nil, err
 	}
 
 	return task, nil
@@ -117,6 +190,18 @@ func (s *taskService) GetByID(userID, taskID uint) (*models.Task, error) {
 
 // GetByUserID obtém todas as tarefas do usuário
 func (s *taskService) GetByUserID(userID uint, filter *models.TaskListFilter) ([]models.Task, error) {
+	// Aplicar valores padrão ao filtro se necessário
+	if filter == nil {
+		filter = &models.TaskListFilter{}
+	}
+	filter.Limit = models.NormalizeLimit(filter.Limit)
+	if err := models.CheckOffset(filter.Offset); err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
+	}
+	if err := validateTaskListFilter(filter); err != nil {
+		return nil, err
+	}
+
 	tasks, err := s.taskRepo.GetByUserID(userID, filter)
 	if err != nil {
 		return nil, errors.ErrInternalServer
@@ -125,71 +210,153 @@ func (s *taskService) GetByUserID(userID uint, filter *models.TaskListFilter) ([
 	return tasks, nil
 }
 
+// validateTaskListFilter verifica cada status e prioridade informados no filtro
+func validateTaskListFilter(filter *models.TaskListFilter) error {
+	for _, status := range filter.Statuses() {
+		if !status.IsValid() {
+			return errors.NewBadRequestError("Status de tarefa inválido: " + string(status) + ". Use: PENDING, IN_PROGRESS ou COMPLETED")
+		}
+	}
+	for _, priority := range filter.Priorities() {
+		if !priority.IsValid() {
+			return errors.NewBadRequestError("Prioridade inválida: " + string(priority) + ". Use: LOW, MEDIUM ou HIGH")
+		}
+	}
+	return nil
+}
+
+// GetPaginatedByUserID obtém os tarefas do usuário com metadados de paginação
+func (s *taskService) GetPaginatedByUserID(userID uint, filter *models.TaskListFilter) (*models.PaginatedResponse[models.Task], error) {
+	if filter == nil {
+		filter = &models.TaskListFilter{}
+	}
+
+	items, err := s.GetByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.taskRepo.CountByUserIDWithFilter(userID, filter)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return models.NewPaginatedResponse(items, total, filter.Limit, filter.Offset), nil
+}
+
+// GetChangedByUserID obtém as tarefas do usuário alteradas ou excluídas desde filter.ChangedSince,
+// indicando as excluídas, para sincronização incremental
+func (s *taskService) GetChangedByUserID(userID uint, filter *models.TaskListFilter) (*models.PaginatedResponse[models.ChangedTask], error) {
+	page, err := s.GetPaginatedByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.ChangedTask, len(page.Data))
+	for i, task := range page.Data {
+		items[i] = models.ChangedTask{Task: task, ChangeState: models.NewChangeState(task.DeletedAt)}
+	}
+
+	return models.NewPaginatedResponse(items, page.Total, page.Limit, page.Offset), nil
+}
+
 // Update atualiza uma tarefa existente
 func (s *taskService) Update(userID, taskID uint, req *models.TaskUpdateRequest) (*models.Task, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
 	// Buscar tarefa existente
-	task, err := s.taskRepo.GetByID(taskID)
+	task, err := fetchOwned(s.access, s.taskRepo.GetByID, taskID, userID, "Tarefa")
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Tarefa")
-		}
-		return nil, errors.ErrInternalServer
+		return nil, err
 	}
 
-	// Verificar se a tarefa pertence ao usuário
-	if task.UserID != userID {
-		return nil, errors.ErrForbidden
+	// Verificar se a tarefa não foi alterada desde a leitura do cliente
+	if err := checkExpectedVersion(req.Version, task.Version); err != nil {
+		return nil, err
+	}
+
+	before := *task
+
+	if req.ClearDueDate && req.DueDate != nil {
+		return nil, errors.NewBadRequestError("Informe due_date ou clear_due_date, não ambos")
 	}
 
 	// Validar novas associações se fornecidas
 	if req.ContactID != nil {
-		contact, err := s.contactRepo.GetByID(*req.ContactID)
-		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				return nil, errors.NewNotFoundError("Contato")
-			}
-			return nil, errors.ErrInternalServer
-		}
-		if contact.UserID != userID {
-			return nil, errors.ErrForbidden
+		if _, err := fetchOwned(s.access, s.contactRepo.GetByID, *req.ContactID, userID, "Contato"); err != nil {
+			return nil, err
 		}
 		task.ContactID = req.ContactID
 	}
 
+	var project *models.Project
 	if req.ProjectID != nil {
-		project, err := s.projectRepo.GetByID(*req.ProjectID)
-		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				return nil, errors.NewNotFoundError("Projeto")
-			}
-			return nil, errors.ErrInternalServer
-		}
-		if project.UserID != userID {
-			return nil, errors.ErrForbidden
+		if project, err = fetchOwned(s.access, s.projectRepo.GetByID, *req.ProjectID, userID, "Projeto"); err != nil {
+			return nil, err
 		}
 		task.ProjectID = req.ProjectID
 	}
 
+	// Tarefas antigas já inconsistentes só são verificadas quando uma das associações muda
+	if req.ContactID != nil || req.ProjectID != nil {
+		if err := s.checkProjectClient(userID, task.ContactID, task.ProjectID, project); err != nil {
+			return nil, err
+		}
+	}
+
 	// Atualizar campos fornecidos
 	if req.Title != "" {
 		task.Title = req.Title
 	}
-	if req.Description != "" {
-		task.Description = req.Description
+	if req.Description != nil {
+		task.Description = *req.Description
 	}
 	if req.DueDate != nil {
 		task.DueDate = req.DueDate
 	}
+	if req.ClearDueDate {
+		task.DueDate = nil
+	}
 	if req.Priority != "" {
 		task.Priority = req.Priority
 	}
+	if req.RecurrenceRule != "" {
+		if !req.RecurrenceRule.IsValid() {
+			return nil, errors.NewBadRequestError("Regra de recorrência inválida. Use: NONE, DAILY, WEEKLY ou MONTHLY")
+		}
+		task.RecurrenceRule = req.RecurrenceRule
+	}
+	justCompleted := false
 	if req.Status != "" {
-		task.Status = req.Status
+		justCompleted = req.Status == models.TaskStatusCompleted && task.Status != models.TaskStatusCompleted
+		applyStatusChange(task, req.Status)
 	}
 
-	// Salvar alterações
-	if err := s.taskRepo.Update(task); err != nil {
-		return nil, errors.ErrInternalServer
+	// Gerar a próxima ocorrência de tarefas recorrentes ao serem concluídas
+	var next *models.Task
+	if justCompleted {
+		next = nextOccurrence(task)
+	}
+
+	// Salvar alterações e a próxima ocorrência juntas: se uma falhar, nenhuma é gravada
+	err = s.transactor.Transaction(func(tx *gorm.DB) error {
+		taskRepo := s.taskRepo.WithTx(tx)
+		if next != nil {
+			if err := taskRepo.Create(next); err != nil {
+				return errors.ErrInternalServer
+			}
+			// Manter referência da ocorrência gerada na tarefa concluída
+			task.NextOccurrenceID = &next.ID
+		}
+		if err := taskRepo.Update(task); err != nil {
+			return updateError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Buscar tarefa atualizada com relacionamentos
@@ -198,23 +365,99 @@ func (s *taskService) Update(userID, taskID uint, req *models.TaskUpdateRequest)
 		return nil, errors.ErrInternalServer
 	}
 
+	action := models.ActionUpdated
+	if justCompleted {
+		action = models.ActionCompleted
+	}
+	publishActivity(s.publisher, userID, createActivityFromTask(*updatedTask), action)
+	recordAudit(s.auditor, userID, models.AuditEntityTask, updatedTask.ID, models.AuditActionUpdate, &before, updatedTask)
+
+	if next != nil {
+		createdNext, err := s.taskRepo.GetByID(next.ID)
+		if err != nil {
+			return nil, errors.ErrInternalServer
+		}
+		publishActivity(s.publisher, userID, createActivityFromTask(*createdNext), models.ActionCreated)
+		recordAudit(s.auditor, userID, models.AuditEntityTask, createdNext.ID, models.AuditActionCreate, nil, createdNext)
+	}
+
 	return updatedTask, nil
 }
 
+// checkProjectClient verifica se o projeto da tarefa pertence ao contato associado a ela (quando
+// ambos são informados). project pode ser nil, caso em que é buscado. Com a verificação estrita
+// (STRICT_TASK_ASSOCIATIONS) a divergência é rejeitada; caso contrário, apenas registrada no log.
+func (s *taskService) checkProjectClient(userID uint, contactID, projectID *uint, project *models.Project) error {
+	if contactID == nil || projectID == nil {
+		return nil
+	}
+	if project == nil {
+		var err error
+		if project, err = s.projectRepo.GetByID(*projectID); err != nil {
+			return errors.ErrInternalServer
+		}
+	}
+	if project.ClientID == *contactID {
+		return nil
+	}
+
+	if s.strictAssociations {
+		return errors.NewBadRequestError("O projeto informado pertence a outro cliente. Associe a tarefa ao cliente do projeto ou remova uma das associações")
+	}
+	logger.WithFields("WARNING", "Tarefa associada a contato diferente do cliente do projeto", map[string]interface{}{
+		"user_id":    userID,
+		"contact_id": *contactID,
+		"project_id": *projectID,
+		"client_id":  project.ClientID,
+	})
+	return nil
+}
+
+// applyStatusChange altera o status da tarefa mantendo a data de conclusão consistente:
+// definida na transição para COMPLETED e removida ao sair de COMPLETED
+func applyStatusChange(task *models.Task, status models.TaskStatus) {
+	if status == models.TaskStatusCompleted && task.Status != models.TaskStatusCompleted {
+		now := time.Now()
+		task.CompletedAt = &now
+	} else if status != models.TaskStatusCompleted {
+		task.CompletedAt = nil
+	}
+	task.Status = status
+}
+
+// nextOccurrence monta a próxima ocorrência de uma tarefa recorrente recém-concluída, mantendo
+// título, prioridade e associações, com vencimento avançado pelo intervalo da regra. Retorna nil
+// para tarefas não recorrentes ou cuja próxima ocorrência já foi gerada.
+func nextOccurrence(task *models.Task) *models.Task {
+	if task.RecurrenceRule == "" || task.RecurrenceRule == models.RecurrenceNone || task.NextOccurrenceID != nil {
+		return nil
+	}
+
+	base := time.Now()
+	if task.DueDate != nil {
+		base = *task.DueDate
+	}
+	nextDueDate := task.RecurrenceRule.Next(base)
+
+	return &models.Task{
+		Title:          task.Title,
+		Description:    task.Description,
+		DueDate:        &nextDueDate,
+		Priority:       task.Priority,
+		Status:         models.TaskStatusPending,
+		UserID:         task.UserID,
+		ContactID:      task.ContactID,
+		ProjectID:      task.ProjectID,
+		RecurrenceRule: task.RecurrenceRule,
+	}
+}
+
 // Delete exclui uma tarefa
 func (s *taskService) Delete(userID, taskID uint) error {
 	// Buscar tarefa existente
-	task, err := s.taskRepo.GetByID(taskID)
+	task, err := fetchOwned(s.access, s.taskRepo.GetByID, taskID, userID, "Tarefa")
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.NewNotFoundError("Tarefa")
-		}
-		return errors.ErrInternalServer
-	}
-
-	// Verificar se a tarefa pertence ao usuário
-	if task.UserID != userID {
-		return errors.ErrForbidden
+		return err
 	}
 
 	// Excluir tarefa
@@ -222,9 +465,70 @@ func (s *taskService) Delete(userID, taskID uint) error {
 		return errors.ErrInternalServer
 	}
 
+	publishActivity(s.publisher, userID, createActivityFromTask(*task), models.ActionDeleted)
+	recordAudit(s.auditor, userID, models.AuditEntityTask, task.ID, models.AuditActionDelete, task, nil)
+
 	return nil
 }
 
+// DeleteCompleted remove as tarefas concluídas do usuário (opcionalmente apenas as concluídas antes de before)
+// e retorna a quantidade removida. Tarefas pendentes ou em andamento nunca são afetadas. Cada tarefa
+// removida é publicada e registrada na auditoria como uma exclusão individual.
+func (s *taskService) DeleteCompleted(userID uint, before *time.Time) (int64, error) {
+	deleted, err := s.taskRepo.DeleteCompletedByUserID(userID, before)
+	if err != nil {
+		return 0, errors.ErrInternalServer
+	}
+
+	for i := range deleted {
+		task := &deleted[i]
+		publishActivity(s.publisher, userID, createActivityFromTask(*task), models.ActionDeleted)
+		recordAudit(s.auditor, userID, models.AuditEntityTask, task.ID, models.AuditActionDelete, task, nil)
+	}
+
+	return int64(len(deleted)), nil
+}
+
+// Restore restaura uma tarefa excluída do usuário
+func (s *taskService) Restore(userID, taskID uint) (*models.Task, error) {
+	// Verificar se já existe um registro ativo com este ID
+	if live, err := s.taskRepo.GetByID(taskID); err == nil {
+		if live.UserID != userID {
+			return nil, errors.NewNotFoundError("Tarefa excluída")
+		}
+		return nil, errors.NewConflictError("Tarefa não está excluída")
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, errors.ErrInternalServer
+	}
+
+	// Buscar registro excluído (soft delete)
+	deleted, err := s.taskRepo.GetDeletedByID(taskID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("Tarefa excluída")
+		}
+		return nil, errors.ErrInternalServer
+	}
+
+	// Apenas o dono pode restaurar
+	if deleted.UserID != userID {
+		return nil, errors.NewNotFoundError("Tarefa excluída")
+	}
+
+	if err := s.taskRepo.Restore(taskID); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	restored, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	recordAudit(s.auditor, userID, models.AuditEntityTask, restored.ID, models.AuditActionRestore, nil, restored)
+
+	return restored, nil
+}
+
 // MarkAsCompleted marca uma tarefa como concluída
 func (s *taskService) MarkAsCompleted(userID, taskID uint) (*models.Task, error) {
 	req := &models.TaskUpdateRequest{
@@ -241,19 +545,52 @@ func (s *taskService) MarkAsPending(userID, taskID uint) (*models.Task, error) {
 	return s.Update(userID, taskID, req)
 }
 
-// GetByContactID obtém tarefas de um contato específico
-func (s *taskService) GetByContactID(userID, contactID uint) ([]models.Task, error) {
-	// Verificar se o contato existe e pertence ao usuário
-	contact, err := s.contactRepo.GetByID(contactID)
+// MarkAsInProgress marca uma tarefa como em andamento
+func (s *taskService) MarkAsInProgress(userID, taskID uint) (*models.Task, error) {
+	req := &models.TaskUpdateRequest{
+		Status: models.TaskStatusInProgress,
+	}
+	return s.Update(userID, taskID, req)
+}
+
+// Snooze adia uma tarefa não concluída até o momento informado: até lá ela fica fora das listas de
+// tarefas pendentes, atrasadas e próximas e não gera notificações de vencimento
+func (s *taskService) Snooze(userID, taskID uint, until time.Time) (*models.Task, error) {
+	task, err := fetchOwned(s.access, s.taskRepo.GetByID, taskID, userID, "Tarefa")
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Status == models.TaskStatusCompleted {
+		return nil, errors.NewBadRequestError("Tarefas concluídas não podem ser adiadas")
+	}
+	if !until.After(time.Now()) {
+		return nil, errors.NewBadRequestError("A data de adiamento (until) deve estar no futuro")
+	}
+
+	before := *task
+	task.SnoozedUntil = &until
+
+	if err := s.taskRepo.Update(task); err != nil {
+		return nil, updateError(err)
+	}
+
+	updatedTask, err := s.taskRepo.GetByID(task.ID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("Contato")
-		}
 		return nil, errors.ErrInternalServer
 	}
 
-	if contact.UserID != userID {
-		return nil, errors.ErrForbidden
+	publishActivity(s.publisher, userID, createActivityFromTask(*updatedTask), models.ActionUpdated)
+	recordAudit(s.auditor, userID, models.AuditEntityTask, updatedTask.ID, models.AuditActionUpdate, &before, updatedTask)
+
+	return updatedTask, nil
+}
+
+// GetByContactID obtém tarefas de um contato específico
+func (s *taskService) GetByContactID(userID, contactID uint) ([]models.Task, error) {
+	// Verificar se o contato existe e pertence ao usuário
+	if _, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato"); err != nil {
+		return nil, err
 	}
 
 	tasks, err := s.taskRepo.GetByContactID(contactID)
@@ -266,7 +603,7 @@ func (s *taskService) GetByContactID(userID, contactID uint) ([]models.Task, err
 
 // GetByProjectID obtém tarefas de um projeto específico
 func (s *taskService) GetByProjectID(userID, projectID uint) ([]models.Task, error) {
-	// Verificar se o projeto existe e pertence ao usuário
+	// Verificar se o projeto existe e pertence ao usuário ou foi compartilhado com ele
 	project, err := s.projectRepo.GetByID(projectID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -275,8 +612,8 @@ func (s *taskService) GetByProjectID(userID, projectID uint) ([]models.Task, err
 		return nil, errors.ErrInternalServer
 	}
 
-	if project.UserID != userID {
-		return nil, errors.ErrForbidden
+	if err := authorizeAccess(s.access, s.shareRepo, models.SharedResourceProject, project.ID, project.UserID, userID, models.SharePermissionRead, "Projeto"); err != nil {
+		return nil, err
 	}
 
 	tasks, err := s.taskRepo.GetByProjectID(projectID)
@@ -297,33 +634,194 @@ func (s *taskService) GetOverdueTasks(userID uint) ([]models.Task, error) {
 	return tasks, nil
 }
 
+// GetAllByContactID obtém todas as tarefas de um contato do usuário: as associadas diretamente
+// e as dos projetos em que ele é o cliente
+func (s *taskService) GetAllByContactID(userID, contactID uint) ([]models.Task, error) {
+	// Verificar se o contato existe e pertence ao usuário
+	if _, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato"); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.GetAllByContactID(contactID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return tasks, nil
+}
+
+// GetOverdueByContactID obtém as tarefas em atraso de um contato do usuário
+func (s *taskService) GetOverdueByContactID(userID, contactID uint) ([]models.Task, error) {
+	// Verificar se o contato existe e pertence ao usuário
+	if _, err := fetchOwned(s.access, s.contactRepo.GetByID, contactID, userID, "Contato"); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.GetOverdueByContactID(contactID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return tasks, nil
+}
+
+// GetOverdueByProjectID obtém as tarefas em atraso de um projeto do usuário ou compartilhado com ele
+func (s *taskService) GetOverdueByProjectID(userID, projectID uint) ([]models.Task, error) {
+	// Verificar se o projeto existe e pertence ao usuário ou foi compartilhado com ele
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("Projeto")
+		}
+		return nil, errors.ErrInternalServer
+	}
+
+	if err := authorizeAccess(s.access, s.shareRepo, models.SharedResourceProject, project.ID, project.UserID, userID, models.SharePermissionRead, "Projeto"); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.GetOverdueByProjectID(projectID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return tasks, nil
+}
+
 // GetUpcomingTasks obtém tarefas próximas do vencimento
 func (s *taskService) GetUpcomingTasks(userID uint, days int) ([]models.Task, error) {
 	if days <= 0 {
 		days = 7 // Padrão: próximos 7 dias
 	}
 
-	// Usar filtro para buscar tarefas com vencimento nos próximos dias
-	// Implementação simplificada - pode ser melhorada no repository
-	filter := &models.TaskListFilter{
-		Status: models.TaskStatusPending,
-		Limit:  100, // Limite alto para capturar todas as tarefas relevantes
+	// Do momento atual até o fim do último dia, no fuso horário do usuário
+	now := time.Now().In(userLocation(s.userRepo, userID))
+	tasks, err := s.taskRepo.GetUpcomingTasks(userID, now, endOfDay(now.AddDate(0, 0, days)))
+	if err != nil {
+		return nil, errors.ErrInternalServer
 	}
 
-	tasks, err := s.taskRepo.GetByUserID(userID, filter)
+	return tasks, nil
+}
+
+// myDayUpcomingDays é a quantidade de dias após hoje incluídos nas tarefas próximas de GetMyDay
+const myDayUpcomingDays = 7
+
+// GetMyDay monta a visão diária das tarefas do usuário, com os dias no fuso horário do usuário:
+// as atrasadas (vencimento já passado), as que vencem até o fim de hoje e as que vencem nos próximos
+// dias. Tarefas concluídas ou adiadas ficam de fora e cada grupo é ordenado por prioridade
+// (da mais alta para a mais baixa) e depois pelo vencimento.
+func (s *taskService) GetMyDay(userID uint) (*MyDayTasks, error) {
+	return s.myDayAt(userID, time.Now())
+}
+
+// myDayAt monta a visão diária de GetMyDay considerando now como o momento atual
+func (s *taskService) myDayAt(userID uint, now time.Time) (*MyDayTasks, error) {
+	now = now.In(userLocation(s.userRepo, userID))
+	tomorrow := startOfDay(now).AddDate(0, 0, 1)
+
+	overdue, err := s.taskRepo.GetOverdueTasks(userID)
 	if err != nil {
 		return nil, errors.ErrInternalServer
 	}
 
-	// Filtrar tarefas com vencimento nos próximos dias (implementação básica)
-	// Em uma implementação mais robusta, isso seria feito no repository
-	var upcomingTasks []models.Task
-	for _, task := range tasks {
-		if task.DueDate != nil {
-			// Lógica de filtro por data seria implementada aqui
-			upcomingTasks = append(upcomingTasks, task)
+	// Do momento atual até o fim do último dia da janela, separado em hoje e próximos dias
+	pending, err := s.taskRepo.GetUpcomingTasks(userID, now, endOfDay(now.AddDate(0, 0, myDayUpcomingDays)))
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	myDay := &MyDayTasks{
+		Overdue:  overdue,
+		Today:    []models.Task{},
+		Upcoming: []models.Task{},
+	}
+	if myDay.Overdue == nil {
+		myDay.Overdue = []models.Task{}
+	}
+	for _, task := range pending {
+		if task.DueDate.Before(tomorrow) {
+			myDay.Today = append(myDay.Today, task)
+		} else {
+			myDay.Upcoming = append(myDay.Upcoming, task)
+		}
+	}
+
+	sortTasksByPriority(myDay.Overdue)
+	sortTasksByPriority(myDay.Today)
+	sortTasksByPriority(myDay.Upcoming)
+
+	return myDay, nil
+}
+
+// priorityRank ordena as prioridades da mais alta para a mais baixa
+var priorityRank = map[models.Priority]int{
+	models.PriorityHigh:   0,
+	models.PriorityMedium: 1,
+	models.PriorityLow:    2,
+}
+
+// sortTasksByPriority ordena as tarefas por prioridade (da mais alta para a mais baixa), depois pelo
+// vencimento mais próximo e, em caso de empate, pelo ID
+func sortTasksByPriority(tasks []models.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if rankI, rankJ := priorityRank[tasks[i].Priority], priorityRank[tasks[j].Priority]; rankI != rankJ {
+			return rankI < rankJ
 		}
+		if !tasks[i].DueDate.Equal(*tasks[j].DueDate) {
+			return tasks[i].DueDate.Before(*tasks[j].DueDate)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// GetStats conta as tarefas do usuário por status e por prioridade, sem carregá-las
+func (s *taskService) GetStats(userID uint) (*TaskStats, error) {
+	byStatus, byPriority, err := s.taskRepo.CountGroupedByStatusAndPriority(userID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return &TaskStats{ByStatus: byStatus, ByPriority: byPriority}, nil
+}
+
+// maxCalendarDays limita o intervalo do calendário de tarefas
+const maxCalendarDays = 90
+
+// calendarDayLayout é o formato dos dias do calendário de tarefas (YYYY-MM-DD)
+const calendarDayLayout = "2006-01-02"
+
+// GetCalendar agrupa as tarefas do usuário com vencimento entre os dias from e to (YYYY-MM-DD,
+// inclusivos) pelo dia de vencimento, ambos no fuso horário do usuário. Dias sem tarefas não
+// aparecem e tarefas sem vencimento são ignoradas.
+func (s *taskService) GetCalendar(userID uint, from, to string) (map[string][]models.Task, error) {
+	loc := userLocation(s.userRepo, userID)
+
+	start, err := time.ParseInLocation(calendarDayLayout, from, loc)
+	if err != nil {
+		return nil, errors.NewBadRequestError("Parâmetro 'from' inválido, use YYYY-MM-DD")
+	}
+	end, err := time.ParseInLocation(calendarDayLayout, to, loc)
+	if err != nil {
+		return nil, errors.NewBadRequestError("Parâmetro 'to' inválido, use YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return nil, errors.NewBadRequestError("Parâmetro 'from' deve ser anterior ou igual a 'to'")
+	}
+	if calendarDaysBetween(start, end) >= maxCalendarDays {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("O intervalo do calendário deve ter no máximo %d dias", maxCalendarDays))
+	}
+
+	tasks, err := s.taskRepo.GetDueInRange(userID, start, end.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	calendar := make(map[string][]models.Task)
+	for _, task := range tasks {
+		day := task.DueDate.In(loc).Format(calendarDayLayout)
+		calendar[day] = append(calendar[day], task)
 	}
 
-	return upcomingTasks, nil
+	return calendar, nil
 }
@@ -0,0 +1,618 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newTestTaskService cria um serviço de tarefas com os fakes informados
+func newTestTaskService(taskRepo *fakeTaskRepo) (TaskService, *fakePublisher, *fakeAuditor) {
+	publisher := &fakePublisher{}
+	auditor := &fakeAuditor{}
+	transactor := &fakeTransactor{participants: []txParticipant{taskRepo}}
+	service := NewTaskService(taskRepo, nil, nil, nil, nil, transactor, publisher, false, auditor, AccessPolicy{})
+	return service, publisher, auditor
+}
+
+func TestTaskServiceCompleteRecurringTask(t *testing.T) {
+	due := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	repo := newFakeTaskRepo(models.Task{
+		ID: 1, Title: "Relatório semanal", UserID: 7, Priority: models.PriorityHigh,
+		Status: models.TaskStatusPending, DueDate: &due, RecurrenceRule: models.RecurrenceWeekly,
+	})
+	service, publisher, auditor := newTestTaskService(repo)
+
+	completed, err := service.MarkAsCompleted(7, 1)
+	if err != nil {
+		t.Fatalf("MarkAsCompleted: %v", err)
+	}
+
+	if completed.Status != models.TaskStatusCompleted || completed.CompletedAt == nil {
+		t.Fatalf("tarefa não foi concluída: %+v", completed)
+	}
+	if completed.NextOccurrenceID == nil {
+		t.Fatal("a tarefa concluída deveria referenciar a próxima ocorrência")
+	}
+
+	next, err := repo.GetByID(*completed.NextOccurrenceID)
+	if err != nil {
+		t.Fatalf("próxima ocorrência não encontrada: %v", err)
+	}
+	if want := due.AddDate(0, 0, 7); !next.DueDate.Equal(want) {
+		t.Errorf("vencimento da próxima ocorrência = %v, want %v", next.DueDate, want)
+	}
+	if next.Status != models.TaskStatusPending || next.Title != "Relatório semanal" || next.Priority != models.PriorityHigh {
+		t.Errorf("próxima ocorrência inesperada: %+v", next)
+	}
+
+	if len(publisher.activities) != 2 || publisher.activities[0].Action != models.ActionCompleted || publisher.activities[1].Action != models.ActionCreated {
+		t.Errorf("atividades publicadas = %+v, want COMPLETED e CREATED", publisher.activities)
+	}
+	actions := auditor.actions(models.AuditEntityTask)
+	if len(actions) != 2 || actions[0] != models.AuditActionUpdate || actions[1] != models.AuditActionCreate {
+		t.Errorf("auditoria = %v, want [update create]", actions)
+	}
+
+	// Concluir de novo não gera outra ocorrência
+	if _, err := service.MarkAsPending(7, 1); err != nil {
+		t.Fatalf("MarkAsPending: %v", err)
+	}
+	if _, err := service.MarkAsCompleted(7, 1); err != nil {
+		t.Fatalf("MarkAsCompleted: %v", err)
+	}
+	if len(repo.tasks) != 2 {
+		t.Errorf("tarefas = %d, want 2 (sem ocorrência duplicada)", len(repo.tasks))
+	}
+}
+
+func TestTaskServiceCompleteRecurringTaskRollsBack(t *testing.T) {
+	tests := []struct {
+		name       string
+		createErr  error
+		updateErr  error
+		wantStatus int
+	}{
+		{name: "falha ao criar a ocorrência", createErr: stderrors.New("insert failed"), wantStatus: http.StatusInternalServerError},
+		{name: "falha ao salvar a tarefa", updateErr: stderrors.New("update failed"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeTaskRepo(models.Task{
+				ID: 1, Title: "Ligar para o cliente", UserID: 7, Priority: models.PriorityLow,
+				Status: models.TaskStatusPending, RecurrenceRule: models.RecurrenceDaily,
+			})
+			repo.createErr = tt.createErr
+			repo.updateErr = tt.updateErr
+			service, publisher, auditor := newTestTaskService(repo)
+
+			_, err := service.MarkAsCompleted(7, 1)
+			assertAppError(t, err, tt.wantStatus)
+
+			stored, _ := repo.GetByID(1)
+			if stored.Status != models.TaskStatusPending || stored.NextOccurrenceID != nil {
+				t.Errorf("a tarefa não deveria ter sido alterada: %+v", stored)
+			}
+			if len(repo.tasks) != 1 {
+				t.Errorf("tarefas = %d, want 1 (sem ocorrência órfã)", len(repo.tasks))
+			}
+			if len(publisher.activities) != 0 || len(auditor.entries) != 0 {
+				t.Errorf("nada deveria ser publicado ou auditado: %+v %+v", publisher.activities, auditor.entries)
+			}
+		})
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	due := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	nextID := uint(9)
+
+	tests := []struct {
+		name    string
+		task    models.Task
+		wantNil bool
+		wantDue time.Time
+	}{
+		{name: "sem recorrência", task: models.Task{RecurrenceRule: models.RecurrenceNone, DueDate: &due}, wantNil: true},
+		{name: "regra vazia", task: models.Task{DueDate: &due}, wantNil: true},
+		{name: "ocorrência já gerada", task: models.Task{RecurrenceRule: models.RecurrenceDaily, DueDate: &due, NextOccurrenceID: &nextID}, wantNil: true},
+		{name: "diária", task: models.Task{RecurrenceRule: models.RecurrenceDaily, DueDate: &due}, wantDue: due.AddDate(0, 0, 1)},
+		{name: "semanal", task: models.Task{RecurrenceRule: models.RecurrenceWeekly, DueDate: &due}, wantDue: due.AddDate(0, 0, 7)},
+		{name: "mensal", task: models.Task{RecurrenceRule: models.RecurrenceMonthly, DueDate: &due}, wantDue: due.AddDate(0, 1, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := nextOccurrence(&tt.task)
+			if tt.wantNil {
+				if next != nil {
+					t.Fatalf("nextOccurrence = %+v, want nil", next)
+				}
+				return
+			}
+			if next == nil || next.DueDate == nil || !next.DueDate.Equal(tt.wantDue) {
+				t.Fatalf("nextOccurrence = %+v, want vencimento %v", next, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestTaskServiceRestoreIsAudited(t *testing.T) {
+	repo := newFakeTaskRepo()
+	repo.deleted[4] = models.Task{ID: 4, Title: "Excluída", UserID: 7, Priority: models.PriorityLow, Status: models.TaskStatusPending}
+	service, _, auditor := newTestTaskService(repo)
+
+	if _, err := service.Restore(8, 4); err == nil {
+		t.Fatal("outro usuário não deveria restaurar a tarefa")
+	}
+	if len(auditor.entries) != 0 {
+		t.Fatalf("auditoria = %+v, want vazia", auditor.entries)
+	}
+
+	restored, err := service.Restore(7, 4)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if restored.ID != 4 {
+		t.Fatalf("restored.ID = %d, want 4", restored.ID)
+	}
+	if actions := auditor.actions(models.AuditEntityTask); len(actions) != 1 || actions[0] != models.AuditActionRestore {
+		t.Fatalf("auditoria = %v, want [RESTORE]", actions)
+	}
+}
+
+func TestTaskServiceDeleteCompletedIsAudited(t *testing.T) {
+	completedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	repo := newFakeTaskRepo(
+		models.Task{ID: 1, UserID: 7, Status: models.TaskStatusCompleted, CompletedAt: &completedAt},
+		models.Task{ID: 2, UserID: 7, Status: models.TaskStatusPending},
+		models.Task{ID: 3, UserID: 7, Status: models.TaskStatusCompleted, CompletedAt: &completedAt},
+		models.Task{ID: 4, UserID: 8, Status: models.TaskStatusCompleted, CompletedAt: &completedAt},
+	)
+	service, publisher, auditor := newTestTaskService(repo)
+
+	deleted, err := service.DeleteCompleted(7, nil)
+	if err != nil {
+		t.Fatalf("DeleteCompleted: %v", err)
+	}
+	if deleted != 2 {
+		t.Fatalf("deleted = %d, want 2", deleted)
+	}
+
+	if len(auditor.entries) != 2 {
+		t.Fatalf("auditoria = %+v, want 2 exclusões", auditor.entries)
+	}
+	for i, wantID := range []uint{1, 3} {
+		entry := auditor.entries[i]
+		if entry.Action != models.AuditActionDelete || entry.EntityID != wantID || entry.After != nil {
+			t.Errorf("auditoria[%d] = %+v, want DELETE da tarefa %d", i, entry, wantID)
+		}
+	}
+	if len(publisher.activities) != 2 || publisher.activities[0].Action != models.ActionDeleted {
+		t.Errorf("atividades = %+v, want 2 DELETED", publisher.activities)
+	}
+	if _, err := repo.GetByID(2); err != nil {
+		t.Error("tarefas pendentes não deveriam ser removidas")
+	}
+}
+
+func TestTaskServiceCreateRejectsInvalidEnum(t *testing.T) {
+	repo := newFakeTaskRepo()
+	service, _, _ := newTestTaskService(repo)
+
+	_, err := service.Create(7, &models.TaskCreateRequest{Title: "Ligar", Priority: "URGENT"})
+	assertAppError(t, err, http.StatusBadRequest)
+	if len(repo.tasks) != 0 {
+		t.Fatal("a tarefa não deveria ter sido criada")
+	}
+}
+
+func TestTaskServiceMyDaySplitsAtUserMidnight(t *testing.T) {
+	saoPaulo := mustLoadLocation(t, "America/Sao_Paulo")
+	at := func(day, hour, min int) *time.Time {
+		due := time.Date(2024, 1, day, hour, min, 0, 0, saoPaulo)
+		return &due
+	}
+
+	// 22:00 do dia 1 em São Paulo já é dia 2 em UTC
+	now := *at(1, 22, 0)
+	repo := newFakeTaskRepo(
+		models.Task{ID: 1, UserID: 7, Title: "Atrasada", Priority: models.PriorityLow, Status: models.TaskStatusPending, DueDate: at(1, 9, 0)},
+		models.Task{ID: 2, UserID: 7, Title: "Antes da meia-noite", Priority: models.PriorityLow, Status: models.TaskStatusPending, DueDate: at(1, 23, 0)},
+		models.Task{ID: 3, UserID: 7, Title: "Último minuto", Priority: models.PriorityHigh, Status: models.TaskStatusPending, DueDate: at(1, 23, 59)},
+		models.Task{ID: 4, UserID: 7, Title: "Depois da meia-noite", Priority: models.PriorityMedium, Status: models.TaskStatusPending, DueDate: at(2, 0, 30)},
+		models.Task{ID: 5, UserID: 7, Title: "Fim da janela", Priority: models.PriorityLow, Status: models.TaskStatusPending, DueDate: at(8, 23, 0)},
+		models.Task{ID: 6, UserID: 7, Title: "Fora da janela", Priority: models.PriorityHigh, Status: models.TaskStatusPending, DueDate: at(9, 0, 30)},
+		models.Task{ID: 7, UserID: 7, Title: "Concluída", Priority: models.PriorityHigh, Status: models.TaskStatusCompleted, DueDate: at(1, 23, 30)},
+	)
+	repo.now = now
+	userRepo := newFakeUserRepo(models.User{ID: 7, Timezone: "America/Sao_Paulo"})
+	service := NewTaskService(repo, nil, nil, nil, userRepo, nil, nil, false, nil, AccessPolicy{}).(*taskService)
+
+	myDay, err := service.myDayAt(7, now.UTC())
+	if err != nil {
+		t.Fatalf("myDayAt: %v", err)
+	}
+
+	ids := func(tasks []models.Task) []uint {
+		result := []uint{}
+		for _, task := range tasks {
+			result = append(result, task.ID)
+		}
+		return result
+	}
+	tests := []struct {
+		bucket string
+		got    []models.Task
+		want   []uint
+	}{
+		{bucket: "overdue", got: myDay.Overdue, want: []uint{1}},
+		{bucket: "today", got: myDay.Today, want: []uint{3, 2}},
+		{bucket: "upcoming", got: myDay.Upcoming, want: []uint{4, 5}},
+	}
+	for _, tt := range tests {
+		if got := ids(tt.got); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s = %v, want %v", tt.bucket, got, tt.want)
+		}
+	}
+}
+
+func TestTaskServiceMyDayEmptyBuckets(t *testing.T) {
+	repo := newFakeTaskRepo()
+	repo.now = time.Now()
+	service := NewTaskService(repo, nil, nil, nil, nil, nil, nil, false, nil, AccessPolicy{})
+
+	myDay, err := service.GetMyDay(7)
+	if err != nil {
+		t.Fatalf("GetMyDay: %v", err)
+	}
+	if myDay.Overdue == nil || myDay.Today == nil || myDay.Upcoming == nil {
+		t.Fatalf("listas vazias deveriam ser [] e não null: %+v", myDay)
+	}
+}
+
+func TestTaskServiceGetUpcomingTasksWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		days     int
+		wantDays int
+	}{
+		{name: "dias informados", days: 3, wantDays: 3},
+		{name: "zero usa o padrão", days: 0, wantDays: 7},
+		{name: "negativo usa o padrão", days: -2, wantDays: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due := time.Now().AddDate(0, 0, tt.wantDays)
+			late := time.Now().AddDate(0, 0, tt.wantDays+1)
+			repo := newFakeTaskRepo(
+				models.Task{ID: 1, UserID: 7, Status: models.TaskStatusPending, DueDate: &due},
+				models.Task{ID: 2, UserID: 7, Status: models.TaskStatusPending, DueDate: &late},
+				models.Task{ID: 3, UserID: 7, Status: models.TaskStatusPending},
+			)
+			service, _, _ := newTestTaskService(repo)
+
+			tasks, err := service.GetUpcomingTasks(7, tt.days)
+			if err != nil {
+				t.Fatalf("GetUpcomingTasks: %v", err)
+			}
+			if len(tasks) != 1 || tasks[0].ID != 1 {
+				t.Fatalf("tarefas = %+v, want apenas a tarefa 1", tasks)
+			}
+			if want := endOfDay(repo.upcomingFrom.AddDate(0, 0, tt.wantDays)); !repo.upcomingTo.Equal(want) {
+				t.Fatalf("fim da janela = %v, want %v", repo.upcomingTo, want)
+			}
+		})
+	}
+}
+
+func TestApplyStatusChange(t *testing.T) {
+	completedAt := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		from          models.TaskStatus
+		completedAt   *time.Time
+		to            models.TaskStatus
+		wantCompleted bool // CompletedAt definido
+		wantKept      bool // CompletedAt original preservado
+	}{
+		{name: "pendente para concluída", from: models.TaskStatusPending, to: models.TaskStatusCompleted, wantCompleted: true},
+		{name: "em andamento para concluída", from: models.TaskStatusInProgress, to: models.TaskStatusCompleted, wantCompleted: true},
+		{name: "concluída de novo mantém a data", from: models.TaskStatusCompleted, completedAt: &completedAt, to: models.TaskStatusCompleted, wantCompleted: true, wantKept: true},
+		{name: "concluída para pendente", from: models.TaskStatusCompleted, completedAt: &completedAt, to: models.TaskStatusPending},
+		{name: "concluída para em andamento", from: models.TaskStatusCompleted, completedAt: &completedAt, to: models.TaskStatusInProgress},
+		{name: "pendente para em andamento", from: models.TaskStatusPending, to: models.TaskStatusInProgress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &models.Task{Status: tt.from, CompletedAt: tt.completedAt}
+			before := time.Now()
+			applyStatusChange(task, tt.to)
+
+			if task.Status != tt.to {
+				t.Fatalf("Status = %s, want %s", task.Status, tt.to)
+			}
+			if (task.CompletedAt != nil) != tt.wantCompleted {
+				t.Fatalf("CompletedAt = %v, want definido: %v", task.CompletedAt, tt.wantCompleted)
+			}
+			if tt.wantKept && !task.CompletedAt.Equal(completedAt) {
+				t.Fatalf("CompletedAt = %v, want %v preservado", task.CompletedAt, completedAt)
+			}
+			if tt.wantCompleted && !tt.wantKept && task.CompletedAt.Before(before) {
+				t.Fatalf("CompletedAt = %v, want o momento da conclusão", task.CompletedAt)
+			}
+		})
+	}
+}
+
+func TestTaskServiceCompletionTimestamp(t *testing.T) {
+	repo := newFakeTaskRepo(models.Task{ID: 1, Title: "Enviar proposta", UserID: 7, Priority: models.PriorityMedium, Status: models.TaskStatusPending})
+	service, _, _ := newTestTaskService(repo)
+
+	completed, err := service.MarkAsCompleted(7, 1)
+	if err != nil {
+		t.Fatalf("MarkAsCompleted: %v", err)
+	}
+	if completed.CompletedAt == nil {
+		t.Fatal("CompletedAt deveria ser definido ao concluir")
+	}
+	if stored, _ := repo.GetByID(1); stored.CompletedAt == nil {
+		t.Fatal("CompletedAt deveria ser persistido")
+	}
+
+	pending, err := service.MarkAsPending(7, 1)
+	if err != nil {
+		t.Fatalf("MarkAsPending: %v", err)
+	}
+	if pending.CompletedAt != nil {
+		t.Fatalf("CompletedAt = %v, want nil ao voltar para pendente", pending.CompletedAt)
+	}
+}
+
+func TestTaskServiceOverdueByScope(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-48 * time.Hour)
+	future := now.Add(48 * time.Hour)
+	contactA, contactB := uint(1), uint(2)
+	projectA, projectB := uint(10), uint(11)
+
+	tests := []struct {
+		name       string
+		userID     uint
+		overdue    func(TaskService, uint) ([]models.Task, error)
+		wantIDs    []uint
+		wantStatus int // 0: sucesso
+	}{
+		{
+			name:   "contato do usuário",
+			userID: 7,
+			overdue: func(s TaskService, userID uint) ([]models.Task, error) {
+				return s.GetOverdueByContactID(userID, contactA)
+			},
+			wantIDs: []uint{1},
+		},
+		{
+			name:   "contato de outro usuário",
+			userID: 8,
+			overdue: func(s TaskService, userID uint) ([]models.Task, error) {
+				return s.GetOverdueByContactID(userID, contactA)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "projeto do usuário",
+			userID: 7,
+			overdue: func(s TaskService, userID uint) ([]models.Task, error) {
+				return s.GetOverdueByProjectID(userID, projectA)
+			},
+			wantIDs: []uint{5},
+		},
+		{
+			name:   "projeto de outro usuário",
+			userID: 8,
+			overdue: func(s TaskService, userID uint) ([]models.Task, error) {
+				return s.GetOverdueByProjectID(userID, projectA)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := newFakeTaskRepo(
+				models.Task{ID: 1, UserID: 7, ContactID: &contactA, Status: models.TaskStatusPending, DueDate: &past},
+				models.Task{ID: 2, UserID: 7, ContactID: &contactB, Status: models.TaskStatusPending, DueDate: &past},
+				models.Task{ID: 3, UserID: 7, ContactID: &contactA, Status: models.TaskStatusCompleted, DueDate: &past},
+				models.Task{ID: 4, UserID: 7, ContactID: &contactA, Status: models.TaskStatusPending, DueDate: &future},
+				models.Task{ID: 5, UserID: 7, ProjectID: &projectA, Status: models.TaskStatusInProgress, DueDate: &past},
+				models.Task{ID: 6, UserID: 7, ProjectID: &projectB, Status: models.TaskStatusPending, DueDate: &past},
+			)
+			taskRepo.now = now
+			contactRepo := newFakeContactRepo(
+				models.Contact{ID: contactA, UserID: 7},
+				models.Contact{ID: contactB, UserID: 7},
+			)
+			projectRepo := newFakeProjectRepo(
+				models.Project{ID: projectA, UserID: 7},
+				models.Project{ID: projectB, UserID: 7},
+			)
+			service := NewTaskService(taskRepo, contactRepo, projectRepo, &fakeShareRepo{}, nil, nil, nil, false, &fakeAuditor{}, AccessPolicy{})
+
+			tasks, err := tt.overdue(service, tt.userID)
+			if tt.wantStatus != 0 {
+				assertAppError(t, err, tt.wantStatus)
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v, want nil", err)
+			}
+			if len(tasks) != len(tt.wantIDs) {
+				t.Fatalf("tarefas = %d, want %d", len(tasks), len(tt.wantIDs))
+			}
+			for i, task := range tasks {
+				if task.ID != tt.wantIDs[i] {
+					t.Errorf("tarefa %d = %d, want %d", i, task.ID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTaskListFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   models.TaskStatus
+		priority models.Priority
+		wantErr  bool
+	}{
+		{name: "sem filtros"},
+		{name: "um status", status: models.TaskStatusPending},
+		{name: "vários status e prioridades", status: "PENDING, IN_PROGRESS", priority: "HIGH,MEDIUM"},
+		{name: "itens vazios ignorados", status: "PENDING,,", priority: ",LOW"},
+		{name: "status inválido na lista", status: "PENDING,DONE", wantErr: true},
+		{name: "status em minúsculas", status: "pending", wantErr: true},
+		{name: "prioridade inválida", priority: "HIGH,URGENT", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTaskListFilter(&models.TaskListFilter{Status: tt.status, Priority: tt.priority})
+			if tt.wantErr {
+				assertAppError(t, err, http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateTaskListFilter = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestTaskServiceCheckProjectClient(t *testing.T) {
+	clientA, clientB, projectID := uint(1), uint(2), uint(10)
+	projectRepo := newFakeProjectRepo(models.Project{ID: projectID, UserID: 7, ClientID: clientA})
+
+	tests := []struct {
+		name      string
+		strict    bool
+		contactID *uint
+		projectID *uint
+		wantErr   bool
+	}{
+		{name: "projeto do mesmo cliente", strict: true, contactID: &clientA, projectID: &projectID},
+		{name: "projeto de outro cliente (estrito)", strict: true, contactID: &clientB, projectID: &projectID, wantErr: true},
+		{name: "projeto de outro cliente (apenas registra)", strict: false, contactID: &clientB, projectID: &projectID},
+		{name: "apenas contato", strict: true, contactID: &clientB},
+		{name: "apenas projeto", strict: true, projectID: &projectID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &taskService{projectRepo: projectRepo, strictAssociations: tt.strict}
+
+			err := service.checkProjectClient(7, tt.contactID, tt.projectID, nil)
+			if tt.wantErr {
+				assertAppError(t, err, http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkProjectClient = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestTaskServiceSnooze(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		taskID     uint
+		until      time.Time
+		wantStatus int // 0: sucesso
+	}{
+		{name: "adiar tarefa pendente", taskID: 1, until: now.Add(48 * time.Hour)},
+		{name: "data no passado", taskID: 1, until: now.Add(-time.Hour), wantStatus: http.StatusBadRequest},
+		{name: "tarefa concluída", taskID: 2, until: now.Add(48 * time.Hour), wantStatus: http.StatusBadRequest},
+		{name: "tarefa inexistente", taskID: 99, until: now.Add(48 * time.Hour), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeTaskRepo(
+				models.Task{ID: 1, Title: "Ligar para cliente", UserID: 7, Priority: models.PriorityMedium, Status: models.TaskStatusPending},
+				models.Task{ID: 2, Title: "Enviar contrato", UserID: 7, Priority: models.PriorityHigh, Status: models.TaskStatusCompleted},
+			)
+			service, _, _ := newTestTaskService(repo)
+
+			task, err := service.Snooze(7, tt.taskID, tt.until)
+			if tt.wantStatus != 0 {
+				assertAppError(t, err, tt.wantStatus)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Snooze: %v", err)
+			}
+			if task.SnoozedUntil == nil || !task.SnoozedUntil.Equal(tt.until) {
+				t.Errorf("SnoozedUntil = %v, want %v", task.SnoozedUntil, tt.until)
+			}
+			if stored, _ := repo.GetByID(tt.taskID); stored.SnoozedUntil == nil {
+				t.Error("SnoozedUntil deveria ser persistido")
+			}
+		})
+	}
+}
+
+func TestTaskServiceDeleteCompletedWindow(t *testing.T) {
+	older := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	earliest := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		before      *time.Time
+		wantDeleted []uint
+	}{
+		{name: "sem data limite", wantDeleted: []uint{1, 2}},
+		{name: "concluídas antes da data", before: &cutoff, wantDeleted: []uint{1}},
+		{name: "nenhuma antes da data", before: &earliest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeTaskRepo(
+				models.Task{ID: 1, UserID: 7, Status: models.TaskStatusCompleted, CompletedAt: &older},
+				models.Task{ID: 2, UserID: 7, Status: models.TaskStatusCompleted, CompletedAt: &newer},
+				models.Task{ID: 3, UserID: 7, Status: models.TaskStatusPending},
+				models.Task{ID: 4, UserID: 7, Status: models.TaskStatusInProgress},
+			)
+			service, _, _ := newTestTaskService(repo)
+
+			deleted, err := service.DeleteCompleted(7, tt.before)
+			if err != nil {
+				t.Fatalf("DeleteCompleted: %v", err)
+			}
+			if deleted != int64(len(tt.wantDeleted)) {
+				t.Fatalf("deleted = %d, want %d", deleted, len(tt.wantDeleted))
+			}
+			for _, id := range tt.wantDeleted {
+				if _, ok := repo.deleted[id]; !ok {
+					t.Errorf("tarefa %d deveria ser removida", id)
+				}
+			}
+			for _, id := range []uint{3, 4} {
+				if _, err := repo.GetByID(id); err != nil {
+					t.Errorf("tarefa %d não concluída não deveria ser removida", id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"fmt"
+	"time"
+)
+
+// userLocation retorna o fuso horário configurado pelo usuário (UTC se não definido, inválido ou indisponível)
+func userLocation(userRepo repositories.UserRepository, userID uint) *time.Location {
+	if userRepo == nil {
+		return time.UTC
+	}
+	user, err := userRepo.GetByID(userID)
+	if err != nil {
+		return time.UTC
+	}
+	return user.Location()
+}
+
+// startOfDay retorna a meia-noite do dia de t, no fuso horário de t
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// endOfDay retorna o último instante do dia de t, no fuso horário de t
+func endOfDay(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// maxRecentWindowDays limita as janelas de itens recentes informadas pelo cliente
+const maxRecentWindowDays = 365
+
+// recentWindowDays retorna a janela de itens recentes solicitada, ou defaultDays quando days é 0
+func recentWindowDays(days, defaultDays int) (int, error) {
+	if days == 0 {
+		return defaultDays, nil
+	}
+	if days < 1 || days > maxRecentWindowDays {
+		return 0, errors.NewBadRequestError(fmt.Sprintf("O parâmetro 'days' deve estar entre 1 e %d", maxRecentWindowDays))
+	}
+	return days, nil
+}
+
+// daysAgo retorna o início do dia de N dias antes de now, no fuso horário de now
+func daysAgo(now time.Time, days int) time.Time {
+	return startOfDay(now).AddDate(0, 0, -days)
+}
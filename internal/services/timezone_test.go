@@ -0,0 +1,123 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"testing"
+	"time"
+	_ "time/tzdata" // Fusos disponíveis mesmo sem zoneinfo no sistema
+)
+
+// mustLoadLocation carrega um fuso IANA ou falha o teste
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q): %v", name, err)
+	}
+	return loc
+}
+
+func TestDayBoundariesAcrossMidnight(t *testing.T) {
+	saoPaulo := mustLoadLocation(t, "America/Sao_Paulo")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	// 02:30 UTC do dia 2 ainda é dia 1 em São Paulo (UTC-3) e já é dia 2 em Tóquio (UTC+9)
+	instant := time.Date(2024, 1, 2, 2, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		loc       *time.Location
+		wantStart time.Time
+	}{
+		{name: "UTC", loc: time.UTC, wantStart: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "atrás de UTC", loc: saoPaulo, wantStart: time.Date(2024, 1, 1, 0, 0, 0, 0, saoPaulo)},
+		{name: "à frente de UTC", loc: tokyo, wantStart: time.Date(2024, 1, 2, 0, 0, 0, 0, tokyo)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := instant.In(tt.loc)
+
+			start := startOfDay(local)
+			if !start.Equal(tt.wantStart) {
+				t.Fatalf("startOfDay = %v, want %v", start, tt.wantStart)
+			}
+
+			end := endOfDay(local)
+			if want := tt.wantStart.AddDate(0, 0, 1).Add(-time.Nanosecond); !end.Equal(want) {
+				t.Fatalf("endOfDay = %v, want %v", end, want)
+			}
+			if local.Before(start) || local.After(end) {
+				t.Fatalf("%v fora do próprio dia [%v, %v]", local, start, end)
+			}
+		})
+	}
+}
+
+func TestDayBoundariesOnDSTChange(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	// 10/03/2024: o relógio adianta uma hora em Nova York, o dia tem 23 horas
+	noon := time.Date(2024, 3, 10, 12, 0, 0, 0, newYork)
+	if length := endOfDay(noon).Sub(startOfDay(noon)) + time.Nanosecond; length != 23*time.Hour {
+		t.Fatalf("duração do dia = %v, want 23h", length)
+	}
+
+	if days := calendarDaysBetween(time.Date(2024, 3, 9, 23, 0, 0, 0, newYork), time.Date(2024, 3, 11, 0, 30, 0, 0, newYork)); days != 2 {
+		t.Fatalf("calendarDaysBetween = %d, want 2", days)
+	}
+
+	if got, want := daysAgo(time.Date(2024, 3, 11, 8, 0, 0, 0, newYork), 2), time.Date(2024, 3, 9, 0, 0, 0, 0, newYork); !got.Equal(want) {
+		t.Fatalf("daysAgo = %v, want %v", got, want)
+	}
+}
+
+func TestUserLocation(t *testing.T) {
+	userRepo := newFakeUserRepo(
+		models.User{ID: 1, Timezone: "America/Sao_Paulo"},
+		models.User{ID: 2},
+		models.User{ID: 3, Timezone: "Mars/Olympus_Mons"},
+	)
+
+	tests := []struct {
+		name     string
+		userRepo *fakeUserRepo
+		userID   uint
+		want     string
+	}{
+		{name: "fuso configurado", userRepo: userRepo, userID: 1, want: "America/Sao_Paulo"},
+		{name: "sem fuso", userRepo: userRepo, userID: 2, want: "UTC"},
+		{name: "fuso inválido", userRepo: userRepo, userID: 3, want: "UTC"},
+		{name: "usuário inexistente", userRepo: userRepo, userID: 4, want: "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := userLocation(tt.userRepo, tt.userID).String(); got != tt.want {
+				t.Fatalf("userLocation = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if got := userLocation(nil, 1); got != time.UTC {
+		t.Fatalf("userLocation(nil) = %v, want UTC", got)
+	}
+}
+
+func TestGetUpcomingTasksEndsAtUserMidnight(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	taskRepo := newFakeTaskRepo()
+	service := NewTaskService(taskRepo, nil, nil, nil, newFakeUserRepo(models.User{ID: 7, Timezone: "Asia/Tokyo"}), nil, nil, false, nil, AccessPolicy{})
+
+	if _, err := service.GetUpcomingTasks(7, 3); err != nil {
+		t.Fatalf("GetUpcomingTasks: %v", err)
+	}
+
+	from, to := taskRepo.upcomingFrom.In(tokyo), taskRepo.upcomingTo.In(tokyo)
+	if want := endOfDay(from.AddDate(0, 0, 3)); !to.Equal(want) {
+		t.Fatalf("fim da janela = %v, want %v (fim do 3º dia em Tóquio)", to, want)
+	}
+	if hour, min, sec := to.Clock(); hour != 23 || min != 59 || sec != 59 {
+		t.Fatalf("fim da janela = %v, want 23:59:59 no horário de Tóquio", to)
+	}
+}
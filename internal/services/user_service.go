@@ -1,10 +1,14 @@
 package services
 
 import (
+	"crm-backend/internal/events"
 	"crm-backend/internal/models"
 	"crm-backend/internal/repositories"
 	"crm-backend/pkg/errors"
+	"crm-backend/pkg/validation"
+	"io"
 	"sort"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -14,12 +18,17 @@
 // UserService define a interface para operações de usuário
 type UserService interface {
 	GetProfile(userID uint) (*models.UserResponse, error)
+	GetRole(userID uint) (models.UserRole, error)
 	UpdateProfile(userID uint, req *models.UserUpdateRequest) (*models.UserResponse, error)
 	ChangePassword(userID uint, currentPassword, newPassword string) error
 	DeleteAccount(userID uint, password string) error
-	GetUserStats(userID uint) (*UserStats, error)
-	GetRecentActivities(userID uint, limit int) (*models.RecentActivityResponse, error)
+	GetUserStats(userID uint, dateRange *models.DateRange, recentDays int) (*UserStats, error)
+	GetConversionStats(userID uint, dateRange *models.DateRange) (*ConversionStats, error)
+	GetRecentActivities(userID uint, days, limit int, types []models.ActivityType) (*models.RecentActivityResponse, error)
 	GetDashboardData(userID uint) (*DashboardData, error)
+	GetDailyDigest(userID uint, date string) (*DailyDigest, error)
+	ExportAllData(userID uint, w io.Writer) error
+	SubscribeActivities(userID uint, lastEventID uint64) ([]events.Event, <-chan events.Event, func(), error)
 }
 
 // UserStats representa estatísticas do usuário
@@ -29,6 +38,7 @@ type UserStats struct {
 	TotalLeads         int64 `json:"total_leads"`
 	TotalTasks         int64 `json:"total_tasks"`
 	PendingTasks       int64 `json:"pending_tasks"`
+	InProgressTasks    int64 `json:"in_progress_tasks"`
 	CompletedTasks     int64 `json:"completed_tasks"`
 	OverdueTasks       int64 `json:"overdue_tasks"`
 	TotalProjects      int64 `json:"total_projects"`
@@ -36,6 +46,15 @@ type UserStats struct {
 	CompletedProjects  int64 `json:"completed_projects"`
 	TotalInteractions  int64 `json:"total_interactions"`
 	RecentInteractions int64 `json:"recent_interactions"`
+	RecentDays         int   `json:"recent_days"` // Janela, em dias, de recent_interactions
+}
+
+// ConversionStats representa as métricas de conversão de leads em clientes
+type ConversionStats struct {
+	TotalLeads     int64   `json:"total_leads"`
+	TotalClients   int64   `json:"total_clients"`
+	ConvertedCount int64   `json:"converted_count"`
+	ConversionRate float64 `json:"conversion_rate"` // Percentual dos clientes atuais que foram convertidos de leads
 }
 
 // DashboardProject representa um resumo de projeto para o dashboard
@@ -86,6 +105,24 @@ type DashboardData struct {
 	RecentContacts     []DashboardContact     `json:"recent_contacts"`
 }
 
+// dailyDigestListLimit limita a quantidade de itens de cada lista do resumo diário (os totais não são limitados)
+const dailyDigestListLimit = 20
+
+// DailyDigest representa o resumo do que aconteceu em um dia, no fuso horário do usuário.
+// As listas trazem no máximo dailyDigestListLimit itens, dos mais recentes aos mais antigos.
+type DailyDigest struct {
+	Date                 string                 `json:"date"` // YYYY-MM-DD
+	Timezone             string                 `json:"timezone"`
+	TasksCompletedCount  int64                  `json:"tasks_completed_count"`
+	TasksCompleted       []DashboardTask        `json:"tasks_completed"`
+	InteractionsCount    int64                  `json:"interactions_count"`
+	Interactions         []DashboardInteraction `json:"interactions"`
+	ContactsAddedCount   int64                  `json:"contacts_added_count"`
+	ContactsAdded        []DashboardContact     `json:"contacts_added"`
+	ProjectsChangedCount int64                  `json:"projects_changed_count"`
+	ProjectsChanged      []DashboardProject     `json:"projects_changed"`
+}
+
 // userService implementa UserService
 type userService struct {
 	userRepo        repositories.UserRepository
@@ -93,6 +130,11 @@ type userService struct {
 	taskRepo        repositories.TaskRepository
 	projectRepo     repositories.ProjectRepository
 	interactionRepo repositories.InteractionRepository
+	eventBus        *events.Bus
+
+	// Janelas padrão, em dias, das interações recentes (estatísticas) e das atividades recentes
+	recentInteractionsDays int
+	recentActivitiesDays   int
 }
 
 // NewUserService cria uma nova instância do serviço de usuários
@@ -102,13 +144,19 @@ func NewUserService(
 	taskRepo repositories.TaskRepository,
 	projectRepo repositories.ProjectRepository,
 	interactionRepo repositories.InteractionRepository,
+	eventBus *events.Bus,
+	recentInteractionsDays int,
+	recentActivitiesDays int,
 ) UserService {
 	return &userService{
-		userRepo:        userRepo,
-		contactRepo:     contactRepo,
-		taskRepo:        taskRepo,
-		projectRepo:     projectRepo,
-		interactionRepo: interactionRepo,
+		userRepo:               userRepo,
+		contactRepo:            contactRepo,
+		taskRepo:               taskRepo,
+		projectRepo:            projectRepo,
+		interactionRepo:        interactionRepo,
+		eventBus:               eventBus,
+		recentInteractionsDays: recentInteractionsDays,
+		recentActivitiesDays:   recentActivitiesDays,
 	}
 }
 
@@ -126,8 +174,25 @@ func (s *userService) GetProfile(userID uint) (*models.UserResponse, error) {
 	return &response, nil
 }
 
+// GetRole obtém o papel atual do usuário. Usuários inexistentes ou excluídos não são autorizados.
+func (s *userService) GetRole(userID uint) (models.UserRole, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", errors.ErrUnauthorized
+		}
+		return "", errors.ErrInternalServer
+	}
+
+	return user.Role, nil
+}
+
 // UpdateProfile atualiza o perfil do usuário
 func (s *userService) UpdateProfile(userID uint, req *models.UserUpdateRequest) (*models.UserResponse, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
 	// Buscar usuário existente
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
@@ -137,22 +202,33 @@ func (s *userService) UpdateProfile(userID uint, req *models.UserUpdateRequest)
 		return nil, errors.ErrInternalServer
 	}
 
-	// Verificar se o email está sendo alterado e se já existe
-	if req.Email != "" && req.Email != user.Email {
-		exists, err := s.userRepo.EmailExists(req.Email)
-		if err != nil {
-			return nil, errors.ErrInternalServer
-		}
-		if exists {
-			return nil, errors.NewConflictError("Email já está em uso")
+	// Verificar se o email está sendo alterado e se já existe (sem diferenciar maiúsculas)
+	if email := models.NormalizeEmail(req.Email); email != "" {
+		if email != models.NormalizeEmail(user.Email) {
+			exists, err := s.userRepo.EmailExists(email)
+			if err != nil {
+				return nil, errors.ErrInternalServer
+			}
+			if exists {
+				return nil, errors.NewConflictError("Email já está em uso")
+			}
 		}
-		user.Email = req.Email
+		user.Email = email
 	}
 
 	// Atualizar campos fornecidos
 	if req.Name != "" {
 		user.Name = req.Name
 	}
+	if req.Timezone != nil {
+		timezone := strings.TrimSpace(*req.Timezone)
+		if timezone != "" {
+			if _, err := time.LoadLocation(timezone); err != nil {
+				return nil, errors.NewBadRequestError("Fuso horário inválido: use um nome IANA, como America/Sao_Paulo")
+			}
+		}
+		user.Timezone = timezone
+	}
 
 	// Salvar alterações
 	if err := s.userRepo.Update(user); err != nil {
@@ -219,28 +295,36 @@ func (s *userService) DeleteAccount(userID uint, password string) error {
 }
 
 // GetUserStats obtém estatísticas do usuário
-func (s *userService) GetUserStats(userID uint) (*UserStats, error) {
+// Com dateRange, as contagens consideram apenas itens criados no intervalo (interações pela data da interação);
+// os contadores de estado (pendentes, ativos, concluídos) refletem o estado atual desses itens.
+func (s *userService) GetUserStats(userID uint, dateRange *models.DateRange, recentDays int) (*UserStats, error) {
+	recentDays, err := recentWindowDays(recentDays, s.recentInteractionsDays)
+	if err != nil {
+		return nil, err
+	}
+
 	stats := &UserStats{
+		RecentDays:         recentDays,
 		RecentInteractions: 0, // Inicializar explicitamente
 		OverdueTasks:       0, // Inicializar explicitamente
 	}
 
 	// Total de contatos
 	if s.contactRepo != nil {
-		totalContacts, err := s.contactRepo.CountByUserID(userID)
+		totalContacts, err := s.contactRepo.CountByUserIDInRange(userID, dateRange)
 		if err != nil {
 			return nil, errors.ErrInternalServer
 		}
 		stats.TotalContacts = totalContacts
 
 		// Contatos por tipo
-		clients, err := s.contactRepo.CountByType(userID, models.ContactTypeClient)
+		clients, err := s.contactRepo.CountByTypeInRange(userID, models.ContactTypeClient, dateRange)
 		if err != nil {
 			return nil, errors.ErrInternalServer
 		}
 		stats.TotalClients = clients
 
-		leads, err := s.contactRepo.CountByType(userID, models.ContactTypeLead)
+		leads, err := s.contactRepo.CountByTypeInRange(userID, models.ContactTypeLead, dateRange)
 		if err != nil {
 			return nil, errors.ErrInternalServer
 		}
@@ -249,21 +333,27 @@ func (s *userService) GetUserStats(userID uint) (*UserStats, error) {
 
 	// Estatísticas de tarefas
 	if s.taskRepo != nil {
-		totalTasks, err := s.taskRepo.CountByUserID(userID)
+		totalTasks, err := s.taskRepo.CountByUserIDInRange(userID, dateRange)
 		if err != nil {
 			return nil, errors.ErrInternalServer
 		}
 		stats.TotalTasks = totalTasks
 
-		pendingTasks, err := s.taskRepo.CountPendingByUserID(userID)
+		pendingTasks, err := s.taskRepo.CountPendingByUserIDInRange(userID, dateRange)
 		if err != nil {
 			return nil, errors.ErrInternalServer
 		}
 		stats.PendingTasks = pendingTasks
-		stats.CompletedTasks = totalTasks - pendingTasks
+
+		inProgressTasks, err := s.taskRepo.CountByStatusInRange(userID, models.TaskStatusInProgress, dateRange)
+		if err != nil {
+			return nil, errors.ErrInternalServer
+		}
+		stats.InProgressTasks = inProgressTasks
+		stats.CompletedTasks = totalTasks - pendingTasks - inProgressTasks
 
 		// Contar tarefas em atraso
-		overdueTasks, err := s.taskRepo.CountOverdueByUserID(userID)
+		overdueTasks, err := s.taskRepo.CountOverdueByUserIDInRange(userID, dateRange)
 		if err != nil {
 			// Se houver erro, definir como 0 mas incluir no resultado
 			stats.OverdueTasks = 0
@@ -274,19 +364,19 @@ func (s *userService) GetUserStats(userID uint) (*UserStats, error) {
 
 	// Estatísticas de projetos
 	if s.projectRepo != nil {
-		totalProjects, err := s.projectRepo.CountByUserID(userID)
+		totalProjects, err := s.projectRepo.CountByUserIDInRange(userID, dateRange)
 		if err != nil {
 			return nil, errors.ErrInternalServer
 		}
 		stats.TotalProjects = totalProjects
 
-		activeProjects, err := s.projectRepo.CountByStatus(userID, models.ProjectStatusInProgress)
+		activeProjects, err := s.projectRepo.CountByStatusInRange(userID, models.ProjectStatusInProgress, dateRange)
 		if err != nil {
 			return nil, errors.ErrInternalServer
 		}
 		stats.ActiveProjects = activeProjects
 
-		completedProjects, err := s.projectRepo.CountByStatus(userID, models.ProjectStatusCompleted)
+		completedProjects, err := s.projectRepo.CountByStatusInRange(userID, models.ProjectStatusCompleted, dateRange)
 		if err != nil {
 			return nil, errors.ErrInternalServer
 		}
@@ -295,20 +385,20 @@ func (s *userService) GetUserStats(userID uint) (*UserStats, error) {
 
 	// Total de interações (através dos contatos do usuário)
 	if s.interactionRepo != nil {
-		filter := &models.InteractionListFilter{}
-		interactions, err := s.interactionRepo.GetByUserID(userID, filter)
+		totalInteractions, err := s.interactionRepo.CountByUserIDInRange(userID, dateRange)
 		if err != nil {
 			return nil, errors.ErrInternalServer
 		}
-		stats.TotalInteractions = int64(len(interactions))
+		stats.TotalInteractions = totalInteractions
 
-		// Contar interações recentes dos últimos 7 dias
-		recentInteractions, err := s.interactionRepo.GetRecentByUserID(userID, 7, 100) // limite alto para contar todas
+		// Contar interações recentes dos últimos recentDays dias
+		since := daysAgo(time.Now().In(userLocation(s.userRepo, userID)), recentDays)
+		recentInteractions, err := s.interactionRepo.CountByUserIDInRange(userID, &models.DateRange{From: &since})
 		if err != nil {
 			// Se houver erro, definir como 0 mas incluir no resultado
 			stats.RecentInteractions = 0
 		} else {
-			stats.RecentInteractions = int64(len(recentInteractions))
+			stats.RecentInteractions = recentInteractions
 		}
 
 		// // Para debug: garantir que sempre tenha pelo menos 0
@@ -320,131 +410,129 @@ func (s *userService) GetUserStats(userID uint) (*UserStats, error) {
 	return stats, nil
 }
 
-// GetRecentActivities obtém as atividades recentes do usuário
-func (s *userService) GetRecentActivities(userID uint, limit int) (*models.RecentActivityResponse, error) {
-	if limit <= 0 {
-		limit = 20 // Limite padrão aumentado para capturar mais atividades
+// GetConversionStats obtém as métricas de conversão de leads em clientes.
+// Os totais de leads e clientes refletem o estado atual; o intervalo filtra as conversões pela data de conversão.
+func (s *userService) GetConversionStats(userID uint, dateRange *models.DateRange) (*ConversionStats, error) {
+	stats := &ConversionStats{}
+
+	leads, err := s.contactRepo.CountByType(userID, models.ContactTypeLead)
+	if err != nil {
+		return nil, errors.ErrInternalServer
 	}
+	stats.TotalLeads = leads
 
-	activities := []models.UserActivity{}
+	clients, err := s.contactRepo.CountByType(userID, models.ContactTypeClient)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	stats.TotalClients = clients
 
-	// 1. Buscar interações recentes (ordenadas por created_at/updated_at)
-	interactions, err := s.interactionRepo.GetRecentByUserID(userID, 30, limit*2) // Buscar mais para filtrar depois
+	converted, err := s.contactRepo.CountConvertedInRange(userID, dateRange)
 	if err != nil {
 		return nil, errors.ErrInternalServer
 	}
+	stats.ConvertedCount = converted
 
-	// Converter interações para atividades
-	for _, interaction := range interactions {
-		// Atividade de criação
-		createActivity := createActivityFromInteraction(interaction)
-		activities = append(activities, createActivity)
+	if stats.TotalClients > 0 {
+		stats.ConversionRate = float64(stats.ConvertedCount) / float64(stats.TotalClients) * 100
+	}
 
-		// Se foi atualizada depois da criação, adicionar atividade de atualização
-		if interaction.UpdatedAt.After(interaction.CreatedAt.Add(time.Minute)) {
-			updateActivity := createActivity
-			updateActivity.Action = models.ActionUpdated
-			updateActivity.CreatedAt = interaction.UpdatedAt
-			updateActivity.UpdatedAt = interaction.UpdatedAt
-			activities = append(activities, updateActivity)
-		}
+	return stats, nil
+}
+
+// GetRecentActivities obtém as atividades recentes do usuário. As interações consideradas são as dos
+// últimos days dias (0 usa a janela padrão). Com types, apenas as fontes desses tipos são consultadas
+// (vazio consulta todas).
+func (s *userService) GetRecentActivities(userID uint, days, limit int, types []models.ActivityType) (*models.RecentActivityResponse, error) {
+	days, err := recentWindowDays(days, s.recentActivitiesDays)
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. Buscar tarefas recentes
-	taskFilter := &models.TaskListFilter{
-		Limit: limit * 2,
+	if len(types) == 0 {
+		types = models.ActivityTypes
 	}
-	tasks, err := s.taskRepo.GetByUserID(userID, taskFilter)
-	if err != nil {
-		return nil, errors.ErrInternalServer
+	include := make(map[models.ActivityType]bool, len(types))
+	for _, activityType := range types {
+		include[activityType] = true
 	}
 
-	// Converter tarefas para atividades
-	for _, task := range tasks {
-		// Atividade de criação
-		createActivity := createActivityFromTask(task)
-		createActivity.Action = models.ActionCreated
-		activities = append(activities, createActivity)
+	if limit <= 0 {
+		limit = 20 // Limite padrão aumentado para capturar mais atividades
+	}
+
+	activities := []models.UserActivity{}
 
-		// Se foi atualizada depois da criação, adicionar atividade de atualização
-		if task.UpdatedAt.After(task.CreatedAt.Add(time.Minute)) {
-			updateActivity := createActivity
-			updateActivity.Action = models.ActionUpdated
-			updateActivity.CreatedAt = task.UpdatedAt
-			updateActivity.UpdatedAt = task.UpdatedAt
-			activities = append(activities, updateActivity)
+	// 1. Buscar interações recentes (ordenadas por created_at/updated_at)
+	if include[models.ActivityTypeInteraction] {
+		since := daysAgo(time.Now().In(userLocation(s.userRepo, userID)), days)
+		interactions, err := s.interactionRepo.GetRecentByUserID(userID, since, limit*2) // Buscar mais para filtrar depois
+		if err != nil {
+			return nil, errors.ErrInternalServer
 		}
 
-		// Se foi concluída, adicionar atividade de conclusão
-		if task.Status == models.TaskStatusCompleted {
-			completeActivity := createActivity
-			completeActivity.Action = models.ActionCompleted
-			completeActivity.CreatedAt = task.UpdatedAt
-			completeActivity.UpdatedAt = task.UpdatedAt
-			activities = append(activities, completeActivity)
+		// Converter interações para atividades
+		for _, interaction := range interactions {
+			activities = append(activities, itemActivities(createActivityFromInteraction(interaction), interaction.CreatedAt, interaction.UpdatedAt, "")...)
 		}
 	}
 
-	// 3. Buscar projetos recentes
-	projectFilter := &models.ProjectListFilter{
-		Limit: limit * 2,
-	}
-	projects, err := s.projectRepo.GetByUserID(userID, projectFilter)
-	if err != nil {
-		return nil, errors.ErrInternalServer
-	}
+	// 2. Buscar tarefas recentes
+	if include[models.ActivityTypeTask] {
+		taskFilter := &models.TaskListFilter{
+			Limit: limit * 2,
+		}
+		tasks, err := s.taskRepo.GetByUserID(userID, taskFilter)
+		if err != nil {
+			return nil, errors.ErrInternalServer
+		}
 
-	// Converter projetos para atividades
-	for _, project := range projects {
-		// Atividade de criação
-		createActivity := createActivityFromProject(project)
-		createActivity.Action = models.ActionCreated
-		activities = append(activities, createActivity)
+		// Converter tarefas para atividades (conclusão é uma ação final relevante)
+		for _, task := range tasks {
+			var final models.ActivityAction
+			if task.Status == models.TaskStatusCompleted {
+				final = models.ActionCompleted
+			}
+			activities = append(activities, itemActivities(createActivityFromTask(task), task.CreatedAt, task.UpdatedAt, final)...)
+		}
+	}
 
-		// Se foi atualizado depois da criação, adicionar atividade de atualização
-		if project.UpdatedAt.After(project.CreatedAt.Add(time.Minute)) {
-			updateActivity := createActivity
+	// 3. Buscar projetos recentes
+	if include[models.ActivityTypeProject] {
+		projectFilter := &models.ProjectListFilter{
+			Limit: limit * 2,
+		}
+		projects, err := s.projectRepo.GetByUserID(userID, projectFilter)
+		if err != nil {
+			return nil, errors.ErrInternalServer
+		}
 
-			// Determinar o tipo de atualização baseado no status
+		// Converter projetos para atividades (conclusão e cancelamento são ações finais relevantes)
+		for _, project := range projects {
+			var final models.ActivityAction
 			switch project.Status {
-			case models.ProjectStatusInProgress:
-				updateActivity.Action = models.ActionStarted
 			case models.ProjectStatusCompleted:
-				updateActivity.Action = models.ActionCompleted
+				final = models.ActionCompleted
 			case models.ProjectStatusCancelled:
-				updateActivity.Action = models.ActionCancelled
-			default:
-				updateActivity.Action = models.ActionUpdated
+				final = models.ActionCancelled
 			}
-
-			updateActivity.CreatedAt = project.UpdatedAt
-			updateActivity.UpdatedAt = project.UpdatedAt
-			activities = append(activities, updateActivity)
+			activities = append(activities, itemActivities(createActivityFromProject(project), project.CreatedAt, project.UpdatedAt, final)...)
 		}
 	}
 
 	// 4. Buscar contatos recentes
-	contactFilter := &models.ContactListFilter{
-		Limit: limit * 2,
-	}
-	contacts, err := s.contactRepo.GetByUserID(userID, contactFilter)
-	if err != nil {
-		return nil, errors.ErrInternalServer
-	}
-
-	// Converter contatos para atividades
-	for _, contact := range contacts {
-		// Atividade de criação
-		createActivity := createActivityFromContact(contact)
-		activities = append(activities, createActivity)
+	if include[models.ActivityTypeContact] {
+		contactFilter := &models.ContactListFilter{
+			Limit: limit * 2,
+		}
+		contacts, err := s.contactRepo.GetByUserID(userID, contactFilter)
+		if err != nil {
+			return nil, errors.ErrInternalServer
+		}
 
-		// Se foi atualizado depois da criação, adicionar atividade de atualização
-		if contact.UpdatedAt.After(contact.CreatedAt.Add(time.Minute)) {
-			updateActivity := createActivity
-			updateActivity.Action = models.ActionUpdated
-			updateActivity.CreatedAt = contact.UpdatedAt
-			updateActivity.UpdatedAt = contact.UpdatedAt
-			activities = append(activities, updateActivity)
+		// Converter contatos para atividades
+		for _, contact := range contacts {
+			activities = append(activities, itemActivities(createActivityFromContact(contact), contact.CreatedAt, contact.UpdatedAt, "")...)
 		}
 	}
 
@@ -464,6 +552,39 @@ func (s *userService) GetRecentActivities(userID uint, limit int) (*models.Recen
 	return response, nil
 }
 
+// activityEditThreshold define quanto depois da criação uma alteração passa a contar como atualização
+const activityEditThreshold = time.Minute
+
+// itemActivities gera as atividades de um item sem duplicá-lo: apenas a ação mais recente relevante
+// (CREATED ou UPDATED). Quando o item chegou a uma ação final (final, ex.: COMPLETED ou CANCELLED)
+// depois da criação, a criação e a ação final são emitidas; a ação final substitui UPDATED.
+func itemActivities(base models.UserActivity, createdAt, updatedAt time.Time, final models.ActivityAction) []models.UserActivity {
+	created := base
+	created.Action = models.ActionCreated
+	created.CreatedAt = createdAt
+	created.UpdatedAt = createdAt
+
+	latest := base
+	latest.CreatedAt = updatedAt
+	latest.UpdatedAt = updatedAt
+
+	edited := updatedAt.After(createdAt.Add(activityEditThreshold))
+	switch {
+	case final != "" && edited:
+		latest.Action = final
+		return []models.UserActivity{created, latest}
+	case final != "":
+		// Criado já na situação final: uma única atividade
+		latest.Action = final
+		return []models.UserActivity{latest}
+	case edited:
+		latest.Action = models.ActionUpdated
+		return []models.UserActivity{latest}
+	default:
+		return []models.UserActivity{created}
+	}
+}
+
 // Funções auxiliares para criar UserActivity de forma segura
 
 // createActivityFromInteraction cria uma UserActivity a partir de uma Interaction
@@ -601,23 +722,32 @@ func truncateString(s string, maxLength int) string {
 	return s[:maxLength-3] + "..."
 }
 
-// Helper para ordenar atividades por data (mais recentes primeiro)
+// Helper para ordenar atividades por data (mais recentes primeiro). Empates, comuns em ações em lote,
+// são desfeitos pelo tipo (ordem alfabética) e depois pelo ID do item (maior primeiro), para que a
+// ordem seja sempre a mesma entre requisições.
 func sortActivitiesByDate(activities []models.UserActivity) {
-	sort.Slice(activities, func(i, j int) bool {
-		return activities[i].CreatedAt.After(activities[j].CreatedAt)
+	sort.SliceStable(activities, func(i, j int) bool {
+		a, b := activities[i], activities[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.ItemID > b.ItemID
 	})
 }
 
 // GetDashboardData obtém dados específicos para o dashboard
 func (s *userService) GetDashboardData(userID uint) (*DashboardData, error) {
 	// 1. Obter estatísticas do usuário
-	stats, err := s.GetUserStats(userID)
+	stats, err := s.GetUserStats(userID, nil, 0)
 	if err != nil {
 		return nil, err
 	}
 
 	// 2. Obter atividades recentes (limitado a 10 para o dashboard)
-	recentActivitiesResponse, err := s.GetRecentActivities(userID, 10)
+	recentActivitiesResponse, err := s.GetRecentActivities(userID, 0, 10, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -639,14 +769,7 @@ func (s *userService) GetDashboardData(userID uint) (*DashboardData, error) {
 		recentInteractions, err := s.interactionRepo.GetByUserID(userID, recentFilter)
 		if err == nil {
 			for _, interaction := range recentInteractions {
-				dashboardInteraction := DashboardInteraction{
-					ID:          interaction.ID,
-					Type:        interaction.Type,
-					Subject:     interaction.Subject,
-					ContactName: interaction.Contact.Name,
-					Date:        interaction.Date,
-				}
-				dashboardData.RecentInteractions = append(dashboardData.RecentInteractions, dashboardInteraction)
+				dashboardData.RecentInteractions = append(dashboardData.RecentInteractions, newDashboardInteraction(interaction))
 			}
 		}
 	}
@@ -660,14 +783,7 @@ func (s *userService) GetDashboardData(userID uint) (*DashboardData, error) {
 		activeProjects, err := s.projectRepo.GetByUserID(userID, activeFilter)
 		if err == nil {
 			for _, project := range activeProjects {
-				dashboardProject := DashboardProject{
-					ID:         project.ID,
-					Name:       project.Name,
-					Status:     project.Status,
-					ClientName: project.Client.Name,
-					CreatedAt:  project.CreatedAt,
-				}
-				dashboardData.RecentProjects = append(dashboardData.RecentProjects, dashboardProject)
+				dashboardData.RecentProjects = append(dashboardData.RecentProjects, newDashboardProject(project))
 			}
 		}
 	}
@@ -681,21 +797,7 @@ func (s *userService) GetDashboardData(userID uint) (*DashboardData, error) {
 		pendingTasks, err := s.taskRepo.GetByUserID(userID, pendingFilter)
 		if err == nil {
 			for _, task := range pendingTasks {
-				dashboardTask := DashboardTask{
-					ID:       task.ID,
-					Title:    task.Title,
-					Priority: task.Priority,
-					DueDate:  task.DueDate,
-				}
-
-				if task.Contact != nil {
-					dashboardTask.ContactName = task.Contact.Name
-				}
-				if task.Project != nil {
-					dashboardTask.ProjectName = task.Project.Name
-				}
-
-				dashboardData.RecentPendingTasks = append(dashboardData.RecentPendingTasks, dashboardTask)
+				dashboardData.RecentPendingTasks = append(dashboardData.RecentPendingTasks, newDashboardTask(task))
 			}
 		}
 	}
@@ -708,19 +810,148 @@ func (s *userService) GetDashboardData(userID uint) (*DashboardData, error) {
 		contacts, err := s.contactRepo.GetByUserID(userID, recentContactFilter)
 		if err == nil {
 			for _, contact := range contacts {
-				dashboardContact := DashboardContact{
-					ID:        contact.ID,
-					Name:      contact.Name,
-					Email:     contact.Email,
-					Type:      contact.Type,
-					Company:   contact.Company,
-					CreatedAt: contact.CreatedAt,
-				}
-
-				dashboardData.RecentContacts = append(dashboardData.RecentContacts, dashboardContact)
+				dashboardData.RecentContacts = append(dashboardData.RecentContacts, newDashboardContact(contact))
 			}
 		}
 	}
 
 	return dashboardData, nil
 }
+
+// GetDailyDigest obtém o resumo de um dia (YYYY-MM-DD, hoje se vazio) no fuso horário do usuário:
+// tarefas concluídas, interações registradas, contatos adicionados e projetos alterados
+func (s *userService) GetDailyDigest(userID uint, date string) (*DailyDigest, error) {
+	loc := userLocation(s.userRepo, userID)
+
+	day := time.Now().In(loc)
+	if date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, loc)
+		if err != nil {
+			return nil, errors.NewBadRequestError("Parâmetro 'date' inválido, use YYYY-MM-DD")
+		}
+		day = parsed
+	}
+
+	from, to := startOfDay(day), endOfDay(day)
+	dateRange := &models.DateRange{From: &from, To: &to}
+
+	digest := &DailyDigest{
+		Date:            from.Format("2006-01-02"),
+		Timezone:        loc.String(),
+		TasksCompleted:  []DashboardTask{},
+		Interactions:    []DashboardInteraction{},
+		ContactsAdded:   []DashboardContact{},
+		ProjectsChanged: []DashboardProject{},
+	}
+
+	var err error
+	if digest.TasksCompletedCount, err = s.taskRepo.CountCompletedInRange(userID, dateRange); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	tasks, err := s.taskRepo.GetCompletedInRange(userID, dateRange, dailyDigestListLimit)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	for _, task := range tasks {
+		digest.TasksCompleted = append(digest.TasksCompleted, newDashboardTask(task))
+	}
+
+	if digest.InteractionsCount, err = s.interactionRepo.CountByUserIDInRange(userID, dateRange); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	interactions, err := s.interactionRepo.GetByUserIDInRange(userID, dateRange, dailyDigestListLimit)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	for _, interaction := range interactions {
+		digest.Interactions = append(digest.Interactions, newDashboardInteraction(interaction))
+	}
+
+	if digest.ContactsAddedCount, err = s.contactRepo.CountByUserIDInRange(userID, dateRange); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	contacts, err := s.contactRepo.GetByUserIDInRange(userID, dateRange, dailyDigestListLimit)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	for _, contact := range contacts {
+		digest.ContactsAdded = append(digest.ContactsAdded, newDashboardContact(contact))
+	}
+
+	if digest.ProjectsChangedCount, err = s.projectRepo.CountUpdatedInRange(userID, dateRange); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	projects, err := s.projectRepo.GetUpdatedInRange(userID, dateRange, dailyDigestListLimit)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	for _, project := range projects {
+		digest.ProjectsChanged = append(digest.ProjectsChanged, newDashboardProject(project))
+	}
+
+	return digest, nil
+}
+
+// newDashboardTask cria o resumo de uma tarefa para o dashboard
+func newDashboardTask(task models.Task) DashboardTask {
+	dashboardTask := DashboardTask{
+		ID:       task.ID,
+		Title:    task.Title,
+		Priority: task.Priority,
+		DueDate:  task.DueDate,
+	}
+
+	if task.Contact != nil {
+		dashboardTask.ContactName = task.Contact.Name
+	}
+	if task.Project != nil {
+		dashboardTask.ProjectName = task.Project.Name
+	}
+
+	return dashboardTask
+}
+
+// newDashboardInteraction cria o resumo de uma interação para o dashboard
+func newDashboardInteraction(interaction models.Interaction) DashboardInteraction {
+	return DashboardInteraction{
+		ID:          interaction.ID,
+		Type:        interaction.Type,
+		Subject:     interaction.Subject,
+		ContactName: interaction.Contact.Name,
+		Date:        interaction.Date,
+	}
+}
+
+// newDashboardProject cria o resumo de um projeto para o dashboard
+func newDashboardProject(project models.Project) DashboardProject {
+	return DashboardProject{
+		ID:         project.ID,
+		Name:       project.Name,
+		Status:     project.Status,
+		ClientName: project.Client.Name,
+		CreatedAt:  project.CreatedAt,
+	}
+}
+
+// newDashboardContact cria o resumo de um contato para o dashboard
+func newDashboardContact(contact models.Contact) DashboardContact {
+	return DashboardContact{
+		ID:        contact.ID,
+		Name:      contact.Name,
+		Email:     contact.Email,
+		Type:      contact.Type,
+		Company:   contact.Company,
+		CreatedAt: contact.CreatedAt,
+	}
+}
+
+// SubscribeActivities assina o fluxo de atividades do usuário em tempo real.
+// Retorna os eventos recentes posteriores a lastEventID, o canal de novos eventos e a função de cancelamento.
+func (s *userService) SubscribeActivities(userID uint, lastEventID uint64) ([]events.Event, <-chan events.Event, func(), error) {
+	if s.eventBus == nil {
+		return nil, nil, nil, errors.ErrInternalServer
+	}
+
+	replay, ch, unsubscribe := s.eventBus.Subscribe(userID, lastEventID)
+	return replay, ch, unsubscribe, nil
+}
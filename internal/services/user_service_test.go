@@ -0,0 +1,197 @@
+package services
+
+import (
+	"crm-backend/internal/models"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSortActivitiesByDate(t *testing.T) {
+	older := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	activities := []models.UserActivity{
+		{Type: models.ActivityTypeTask, ItemID: 1, CreatedAt: older},
+		{Type: models.ActivityTypeTask, ItemID: 5, CreatedAt: newer},
+		{Type: models.ActivityTypeContact, ItemID: 2, CreatedAt: newer},
+		{Type: models.ActivityTypeTask, ItemID: 9, CreatedAt: newer},
+		{Type: models.ActivityTypeContact, ItemID: 3, CreatedAt: newer},
+	}
+	// Mesmo instante em outro fuso: deve empatar
+	activities = append(activities, models.UserActivity{Type: models.ActivityTypeProject, ItemID: 4, CreatedAt: newer.In(time.FixedZone("BRT", -3*3600))})
+
+	want := []struct {
+		Type   models.ActivityType
+		ItemID uint
+	}{
+		{models.ActivityTypeContact, 3},
+		{models.ActivityTypeContact, 2},
+		{models.ActivityTypeProject, 4},
+		{models.ActivityTypeTask, 9},
+		{models.ActivityTypeTask, 5},
+		{models.ActivityTypeTask, 1},
+	}
+
+	// A ordem não pode depender da ordem de entrada
+	for round := 0; round < 2; round++ {
+		sortActivitiesByDate(activities)
+		for i, w := range want {
+			if activities[i].Type != w.Type || activities[i].ItemID != w.ItemID {
+				t.Fatalf("rodada %d, posição %d = %s/%d, want %s/%d", round, i, activities[i].Type, activities[i].ItemID, w.Type, w.ItemID)
+			}
+		}
+		// Inverter a entrada para a segunda rodada
+		for i, j := 0, len(activities)-1; i < j; i, j = i+1, j-1 {
+			activities[i], activities[j] = activities[j], activities[i]
+		}
+	}
+}
+
+func TestGetRecentActivitiesFiltersByType(t *testing.T) {
+	now := time.Now()
+	taskRepo := newFakeTaskRepo(models.Task{ID: 1, UserID: 7, Title: "Tarefa", CreatedAt: now, UpdatedAt: now})
+	interactionRepo := &fakeInteractionRepo{interactions: []models.Interaction{
+		{ID: 2, Subject: "Reunião", Date: now, CreatedAt: now, UpdatedAt: now, Contact: models.Contact{UserID: 7}},
+	}}
+
+	tests := []struct {
+		name      string
+		types     []models.ActivityType
+		wantTypes []models.ActivityType
+	}{
+		{name: "apenas tarefas", types: []models.ActivityType{models.ActivityTypeTask}, wantTypes: []models.ActivityType{models.ActivityTypeTask}},
+		{name: "apenas interações", types: []models.ActivityType{models.ActivityTypeInteraction}, wantTypes: []models.ActivityType{models.ActivityTypeInteraction}},
+		{
+			name:      "tarefas e interações",
+			types:     []models.ActivityType{models.ActivityTypeTask, models.ActivityTypeInteraction},
+			wantTypes: []models.ActivityType{models.ActivityTypeInteraction, models.ActivityTypeTask},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repositórios de contatos e projetos ausentes: consultá-los falharia o teste
+			service := NewUserService(nil, nil, taskRepo, nil, interactionRepo, nil, 7, 30)
+
+			response, err := service.GetRecentActivities(7, 0, 10, tt.types)
+			if err != nil {
+				t.Fatalf("GetRecentActivities: %v", err)
+			}
+
+			got := make([]models.ActivityType, len(response.Activities))
+			for i, activity := range response.Activities {
+				got[i] = activity.Type
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			if len(got) != len(tt.wantTypes) {
+				t.Fatalf("tipos = %v, want %v", got, tt.wantTypes)
+			}
+			for i := range got {
+				if got[i] != tt.wantTypes[i] {
+					t.Fatalf("tipos = %v, want %v", got, tt.wantTypes)
+				}
+			}
+		})
+	}
+}
+
+func TestUserServiceUpdateProfileEmailCaseInsensitive(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		wantEmail  string
+		wantStatus int // 0: sucesso
+	}{
+		{name: "email de outro usuário com outra capitalização", email: "A@B.com", wantStatus: http.StatusConflict},
+		{name: "próprio email com outra capitalização", email: "Carla@Example.com", wantEmail: "carla@example.com"},
+		{name: "email novo é normalizado", email: "Nova@Example.COM", wantEmail: "nova@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := newFakeUserRepo(
+				models.User{ID: 1, Name: "Ana", Email: "a@b.com"},
+				models.User{ID: 2, Name: "Carla", Email: "carla@example.com"},
+			)
+			service := NewUserService(userRepo, nil, nil, nil, nil, nil, 0, 0)
+
+			user, err := service.UpdateProfile(2, &models.UserUpdateRequest{Email: tt.email})
+			if tt.wantStatus != 0 {
+				assertAppError(t, err, tt.wantStatus)
+				if stored := userRepo.users[2].Email; stored != "carla@example.com" {
+					t.Errorf("email armazenado = %q, want inalterado", stored)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateProfile: %v", err)
+			}
+			if user.Email != tt.wantEmail || userRepo.users[2].Email != tt.wantEmail {
+				t.Errorf("email = %q (armazenado %q), want %q", user.Email, userRepo.users[2].Email, tt.wantEmail)
+			}
+		})
+	}
+}
+
+func TestItemActivities(t *testing.T) {
+	createdAt := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	base := models.UserActivity{ID: 1, Type: models.ActivityTypeTask, ItemID: 1}
+
+	tests := []struct {
+		name        string
+		updatedAt   time.Time
+		final       models.ActivityAction
+		wantActions []models.ActivityAction
+	}{
+		{name: "apenas criado", updatedAt: createdAt, wantActions: []models.ActivityAction{models.ActionCreated}},
+		{name: "alterado dentro do limiar", updatedAt: createdAt.Add(30 * time.Second), wantActions: []models.ActivityAction{models.ActionCreated}},
+		{name: "editado depois da criação", updatedAt: createdAt.Add(10 * time.Minute), wantActions: []models.ActivityAction{models.ActionUpdated}},
+		{
+			name:        "concluído depois da criação",
+			updatedAt:   createdAt.Add(time.Hour),
+			final:       models.ActionCompleted,
+			wantActions: []models.ActivityAction{models.ActionCreated, models.ActionCompleted},
+		},
+		{name: "criado já concluído", updatedAt: createdAt, final: models.ActionCompleted, wantActions: []models.ActivityAction{models.ActionCompleted}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activities := itemActivities(base, createdAt, tt.updatedAt, tt.final)
+			if len(activities) != len(tt.wantActions) {
+				t.Fatalf("atividades = %d, want %d", len(activities), len(tt.wantActions))
+			}
+			for i, activity := range activities {
+				if activity.Action != tt.wantActions[i] {
+					t.Errorf("atividade %d: ação = %s, want %s", i, activity.Action, tt.wantActions[i])
+				}
+			}
+			// A atividade mais recente usa o momento da última alteração
+			if last := activities[len(activities)-1]; tt.wantActions[len(tt.wantActions)-1] != models.ActionCreated && !last.CreatedAt.Equal(tt.updatedAt) {
+				t.Errorf("CreatedAt = %v, want %v", last.CreatedAt, tt.updatedAt)
+			}
+		})
+	}
+}
+
+func TestGetRecentActivitiesEditedTaskYieldsSingleUpdate(t *testing.T) {
+	now := time.Now()
+	taskRepo := newFakeTaskRepo(models.Task{
+		ID: 1, UserID: 7, Title: "Enviar proposta", Status: models.TaskStatusPending,
+		CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now,
+	})
+	service := NewUserService(nil, nil, taskRepo, nil, nil, nil, 7, 30)
+
+	response, err := service.GetRecentActivities(7, 0, 10, []models.ActivityType{models.ActivityTypeTask})
+	if err != nil {
+		t.Fatalf("GetRecentActivities: %v", err)
+	}
+	if response.Count != 1 || len(response.Activities) != 1 {
+		t.Fatalf("atividades = %d, want 1 (sem CREATED e UPDATED empilhados)", len(response.Activities))
+	}
+	if action := response.Activities[0].Action; action != models.ActionUpdated {
+		t.Errorf("ação = %s, want %s", action, models.ActionUpdated)
+	}
+}
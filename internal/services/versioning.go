@@ -0,0 +1,25 @@
+package services
+
+import (
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+)
+
+// errVersionConflict é retornado quando o registro foi alterado por outra requisição desde a leitura do cliente
+var errVersionConflict = errors.NewConflictError("O registro foi alterado por outra requisição; recarregue e tente novamente")
+
+// checkExpectedVersion compara a versão enviada pelo cliente (quando informada) com a versão atual do registro
+func checkExpectedVersion(expected *uint, current uint) error {
+	if expected != nil && *expected != current {
+		return errVersionConflict
+	}
+	return nil
+}
+
+// updateError converte o erro de atualização do repositório no erro da aplicação correspondente
+func updateError(err error) error {
+	if err == repositories.ErrVersionConflict {
+		return errVersionConflict
+	}
+	return errors.ErrInternalServer
+}
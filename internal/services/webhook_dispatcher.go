@@ -0,0 +1,225 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crm-backend/internal/events"
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/logger"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader contém a assinatura HMAC-SHA256 do corpo, no formato "sha256=<hex>"
+	WebhookSignatureHeader = "X-Signature"
+	// WebhookEventHeader contém o nome do evento entregue
+	WebhookEventHeader = "X-Webhook-Event"
+	// WebhookDeliveryHeader contém o ID do evento, igual em todas as tentativas de entrega
+	WebhookDeliveryHeader = "X-Webhook-Delivery"
+
+	// webhookQueueSize define quantos eventos podem aguardar entrega antes de serem descartados
+	webhookQueueSize = 256
+	// webhookWorkers define quantas entregas são feitas em paralelo
+	webhookWorkers = 4
+	// webhookRetryDelay é a espera antes da primeira nova tentativa (dobrada a cada tentativa)
+	webhookRetryDelay = time.Second
+	// webhookMaxResponseBody limita quanto da resposta do destino é lido
+	webhookMaxResponseBody = 64 << 10
+)
+
+// errWebhookAddressNotAllowed indica uma conexão recusada por apontar para um endereço interno ou local
+var errWebhookAddressNotAllowed = stderrors.New("endereço de destino do webhook não permitido")
+
+// WebhookDispatcher entrega de forma assíncrona os eventos do barramento aos webhooks cadastrados
+type WebhookDispatcher struct {
+	webhookRepo repositories.WebhookRepository
+	client      *http.Client
+	maxRetries  int
+	queue       chan events.Event
+}
+
+// NewWebhookDispatcher cria um despachante de webhooks com o tempo limite por tentativa
+// e o número de novas tentativas após uma falha
+func NewWebhookDispatcher(webhookRepo repositories.WebhookRepository, timeout time.Duration, maxRetries int) *WebhookDispatcher {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		client:      newWebhookClient(timeout),
+		maxRetries:  maxRetries,
+		queue:       make(chan events.Event, webhookQueueSize),
+	}
+}
+
+// newWebhookClient cria o cliente HTTP das entregas. O endereço é verificado novamente no momento
+// da conexão (o DNS pode ter mudado desde o cadastro), proxies do ambiente são ignorados para que
+// a verificação valha para o destino real e redirecionamentos não são seguidos: a resposta 3xx
+// conta como falha da entrega.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicWebhookIP(net.ParseIP(host)) {
+				return errWebhookAddressNotAllowed
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Enqueue agenda a entrega de um evento sem bloquear quem o publicou.
+// Deve ser registrado como ouvinte do barramento de eventos.
+func (d *WebhookDispatcher) Enqueue(event events.Event) {
+	select {
+	case d.queue <- event:
+	default:
+		logger.WithFields("WARNING", "Fila de webhooks cheia - evento descartado", map[string]interface{}{
+			"event_id": event.ID,
+			"user_id":  event.UserID,
+		})
+	}
+}
+
+// Start inicia as entregas em segundo plano até o contexto ser cancelado
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	for i := 0; i < webhookWorkers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event := <-d.queue:
+					d.dispatch(ctx, event)
+				}
+			}
+		}()
+	}
+}
+
+// dispatch entrega o evento a todos os webhooks ativos do usuário que o assinam
+func (d *WebhookDispatcher) dispatch(ctx context.Context, event events.Event) {
+	name := models.WebhookEventFromActivity(event.Activity)
+
+	webhooks, err := d.webhookRepo.GetActiveByUserID(event.UserID)
+	if err != nil {
+		logger.LogError(err, "Falha ao buscar webhooks", map[string]interface{}{
+			"event_id": event.ID,
+			"user_id":  event.UserID,
+		})
+		return
+	}
+
+	var body []byte
+	for i := range webhooks {
+		webhook := &webhooks[i]
+		if !webhook.Subscribes(name) {
+			continue
+		}
+
+		if body == nil {
+			body, err = json.Marshal(models.WebhookPayload{
+				ID:         event.ID,
+				Event:      name,
+				OccurredAt: event.Activity.CreatedAt,
+				Data:       event.Activity,
+			})
+			if err != nil {
+				logger.LogError(err, "Falha ao serializar evento de webhook", map[string]interface{}{
+					"event_id": event.ID,
+				})
+				return
+			}
+		}
+
+		d.deliver(ctx, webhook, name, event.ID, body)
+	}
+}
+
+// deliver envia o corpo ao webhook, tentando novamente com espera crescente em caso de falha
+func (d *WebhookDispatcher) deliver(ctx context.Context, webhook *models.Webhook, name models.WebhookEvent, eventID uint64, body []byte) {
+	var lastErr error
+	for attempt := 0; attempt <= d.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(webhookRetryDelay << (attempt - 1)):
+			}
+		}
+
+		if lastErr = d.send(ctx, webhook, name, eventID, body); lastErr == nil {
+			return
+		}
+	}
+
+	logger.LogError(lastErr, "Falha na entrega de webhook", map[string]interface{}{
+		"webhook_id": webhook.ID,
+		"user_id":    webhook.UserID,
+		"url":        webhook.URL,
+		"event":      name,
+		"event_id":   eventID,
+		"attempts":   d.maxRetries + 1,
+	})
+}
+
+// send faz uma tentativa de entrega; respostas fora da faixa 2xx são consideradas falha
+func (d *WebhookDispatcher) send(ctx context.Context, webhook *models.Webhook, name models.WebhookEvent, eventID uint64, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, signWebhookBody(webhook.Secret, body))
+	req.Header.Set(WebhookEventHeader, string(name))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(eventID, 10))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Ler (parte do) corpo para permitir a reutilização da conexão
+	io.Copy(io.Discard, io.LimitReader(resp.Body, webhookMaxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("destino respondeu com status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signWebhookBody calcula a assinatura HMAC-SHA256 do corpo com o segredo do webhook
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
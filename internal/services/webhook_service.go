@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"crm-backend/internal/models"
+	"crm-backend/internal/repositories"
+	"crm-backend/pkg/errors"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// webhookSecretBytes define o tamanho dos segredos gerados automaticamente
+	webhookSecretBytes = 32
+	// minWebhookSecretLength define o tamanho mínimo de um segredo informado pelo usuário
+	minWebhookSecretLength = 16
+	// maxWebhookURLLength acompanha o tamanho da coluna url
+	maxWebhookURLLength = 2048
+	// webhookLookupTimeout limita a resolução do host da URL no cadastro
+	webhookLookupTimeout = 5 * time.Second
+)
+
+// lookupWebhookHost resolve o host da URL de um webhook (substituível nos testes)
+var lookupWebhookHost = net.DefaultResolver.LookupIPAddr
+
+// WebhookService define a interface para operações de webhook
+type WebhookService interface {
+	Create(userID uint, req *models.WebhookCreateRequest) (*models.WebhookCreateResponse, error)
+	GetByUserID(userID uint) ([]models.Webhook, error)
+	GetByID(userID, webhookID uint) (*models.Webhook, error)
+	Update(userID, webhookID uint, req *models.WebhookUpdateRequest) (*models.Webhook, error)
+	Delete(userID, webhookID uint) error
+}
+
+// webhookService implementa WebhookService
+type webhookService struct {
+	webhookRepo repositories.WebhookRepository
+	access      AccessPolicy
+}
+
+// NewWebhookService cria uma nova instância do serviço de webhooks
+func NewWebhookService(webhookRepo repositories.WebhookRepository, access AccessPolicy) WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		access:      access,
+	}
+}
+
+// Create cadastra um novo webhook. O segredo é retornado apenas nesta resposta.
+func (s *webhookService) Create(userID uint, req *models.WebhookCreateRequest) (*models.WebhookCreateResponse, error) {
+	webhookURL, err := validateWebhookURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := validateWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := strings.TrimSpace(req.Secret)
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, errors.ErrInternalServer
+		}
+	} else if len(secret) < minWebhookSecretLength {
+		return nil, errors.NewBadRequestError("O segredo do webhook deve ter pelo menos 16 caracteres")
+	}
+
+	webhook := &models.Webhook{
+		UserID: userID,
+		URL:    webhookURL,
+		Events: events,
+		Secret: secret,
+		Active: true,
+	}
+
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return &models.WebhookCreateResponse{Webhook: *webhook, Secret: secret}, nil
+}
+
+// GetByUserID lista os webhooks do usuário
+func (s *webhookService) GetByUserID(userID uint) ([]models.Webhook, error) {
+	webhooks, err := s.webhookRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	return webhooks, nil
+}
+
+// GetByID obtém um webhook específico
+func (s *webhookService) GetByID(userID, webhookID uint) (*models.Webhook, error) {
+	return fetchOwned(s.access, s.webhookRepo.GetByID, webhookID, userID, "Webhook")
+}
+
+// Update atualiza um webhook existente
+func (s *webhookService) Update(userID, webhookID uint, req *models.WebhookUpdateRequest) (*models.Webhook, error) {
+	webhook, err := fetchOwned(s.access, s.webhookRepo.GetByID, webhookID, userID, "Webhook")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if webhook.URL, err = validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+	}
+	if req.Events != nil {
+		if webhook.Events, err = validateWebhookEvents(req.Events); err != nil {
+			return nil, err
+		}
+	}
+	if req.Secret != nil {
+		secret := strings.TrimSpace(*req.Secret)
+		if len(secret) < minWebhookSecretLength {
+			return nil, errors.NewBadRequestError("O segredo do webhook deve ter pelo menos 16 caracteres")
+		}
+		webhook.Secret = secret
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, errors.ErrInternalServer
+	}
+
+	return webhook, nil
+}
+
+// Delete remove um webhook
+func (s *webhookService) Delete(userID, webhookID uint) error {
+	if _, err := fetchOwned(s.access, s.webhookRepo.GetByID, webhookID, userID, "Webhook"); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(webhookID); err != nil {
+		return errors.ErrInternalServer
+	}
+	return nil
+}
+
+// validateWebhookURL exige uma URL absoluta http(s) cujo host resolva apenas para endereços públicos,
+// para que os webhooks não sejam usados para alcançar a rede interna do servidor (SSRF)
+func validateWebhookURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return "", errors.NewBadRequestError("URL do webhook inválida: informe uma URL http ou https")
+	}
+	if len(raw) > maxWebhookURLLength {
+		return "", errors.NewBadRequestError("URL do webhook muito longa")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookLookupTimeout)
+	defer cancel()
+
+	addrs, err := lookupWebhookHost(ctx, parsed.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return "", errors.NewBadRequestError("Não foi possível resolver o host da URL do webhook")
+	}
+	for _, addr := range addrs {
+		if !isPublicWebhookIP(addr.IP) {
+			return "", errors.NewBadRequestError("URL do webhook inválida: endereços internos ou locais não são permitidos")
+		}
+	}
+	return raw, nil
+}
+
+// isPublicWebhookIP verifica se o endereço pode receber webhooks: loopback, redes privadas,
+// link-local (incluindo o serviço de metadados 169.254.169.254), multicast e o endereço
+// não especificado são recusados
+func isPublicWebhookIP(ip net.IP) bool {
+	return ip != nil &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// validateWebhookEvents verifica se os eventos são suportados, removendo repetições
+func validateWebhookEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, errors.NewBadRequestError("Informe pelo menos um evento")
+	}
+
+	seen := make(map[string]bool, len(events))
+	valid := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !models.WebhookEvent(event).IsValid() {
+			return nil, errors.NewBadRequestError("Evento de webhook inválido: " + event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			valid = append(valid, event)
+		}
+	}
+	return valid, nil
+}
+
+// generateWebhookSecret gera um segredo aleatório em hexadecimal
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// stubWebhookLookup substitui a resolução de DNS do cadastro de webhooks durante o teste
+func stubWebhookLookup(t *testing.T, hosts map[string][]string) {
+	t.Helper()
+	original := lookupWebhookHost
+	lookupWebhookHost = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		ips, ok := hosts[host]
+		if !ok {
+			if ip := net.ParseIP(host); ip != nil {
+				return []net.IPAddr{{IP: ip}}, nil
+			}
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		addrs := make([]net.IPAddr, 0, len(ips))
+		for _, ip := range ips {
+			addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+		}
+		return addrs, nil
+	}
+	t.Cleanup(func() { lookupWebhookHost = original })
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	stubWebhookLookup(t, map[string][]string{
+		"hooks.example.com": {"93.184.216.34"},
+		"internal.example":  {"10.0.0.5"},
+		"mixed.example":     {"93.184.216.34", "127.0.0.1"},
+	})
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "host público", url: "https://hooks.example.com/crm", wantErr: false},
+		{name: "IP público", url: "http://93.184.216.34:8080/hook", wantErr: false},
+		{name: "esquema não suportado", url: "ftp://hooks.example.com/crm", wantErr: true},
+		{name: "sem host", url: "https:///crm", wantErr: true},
+		{name: "loopback", url: "http://127.0.0.1:8080/hook", wantErr: true},
+		{name: "loopback IPv6", url: "http://[::1]/hook", wantErr: true},
+		{name: "rede privada", url: "http://10.1.2.3/hook", wantErr: true},
+		{name: "rede privada 192.168", url: "http://192.168.0.10/hook", wantErr: true},
+		{name: "metadados da nuvem", url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "não especificado", url: "http://0.0.0.0/hook", wantErr: true},
+		{name: "host resolvido para rede privada", url: "https://internal.example/hook", wantErr: true},
+		{name: "algum endereço interno", url: "https://mixed.example/hook", wantErr: true},
+		{name: "host inexistente", url: "https://missing.example/hook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateWebhookURL(tt.url)
+			if tt.wantErr {
+				assertAppError(t, err, http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateWebhookURL(%q) = %v, want nil", tt.url, err)
+			}
+		})
+	}
+}
+
+func TestWebhookClientRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("a entrega não deveria alcançar o servidor local")
+	}))
+	defer server.Close()
+
+	_, err := newWebhookClient(time.Second).Post(server.URL, "application/json", nil)
+	if !stderrors.Is(err, errWebhookAddressNotAllowed) {
+		t.Fatalf("err = %v, want %v", err, errWebhookAddressNotAllowed)
+	}
+}
+
+func TestWebhookClientDoesNotFollowRedirects(t *testing.T) {
+	client := newWebhookClient(time.Second)
+	// Permitir o servidor local apenas neste teste, mantendo a política de redirecionamento
+	client.Transport = http.DefaultTransport
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/target" {
+			t.Error("o redirecionamento não deveria ser seguido")
+		}
+		http.Redirect(w, r, "/target", http.StatusFound)
+	}))
+	defer server.Close()
+
+	resp, err := client.Post(server.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusFound)
+	}
+}
@@ -2,11 +2,14 @@
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"runtime"
 	"strings"
 	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
@@ -14,17 +17,114 @@
 	WarningLogger *log.Logger
 	ErrorLogger   *log.Logger
 	DebugLogger   *log.Logger
+
+	// jsonFormat indica se os logs com campos devem ser emitidos em JSON (LOG_FORMAT=json)
+	jsonFormat bool
+
+	// output e errorOutput são os destinos definidos por LOG_OUTPUT
+	output      io.Writer = os.Stdout
+	errorOutput io.Writer = os.Stderr
+	// logFile é o arquivo rotativo em uso quando LOG_OUTPUT=file ou both
+	logFile *lumberjack.Logger
+)
+
+// Saídas de log suportadas (LOG_OUTPUT)
+const (
+	OutputStdout = "stdout"
+	OutputFile   = "file"
+	OutputBoth   = "both"
 )
 
-// Init inicializa os loggers
-func Init() {
+// Options define para onde os logs são escritos e como o arquivo de log é rotacionado
+type Options struct {
+	Output     string // stdout, file ou both; vazio equivale a stdout
+	File       string // Caminho do arquivo quando Output é file ou both
+	MaxSize    int    // Em MB; zero usa o padrão de 100 MB
+	MaxBackups int
+	MaxAge     int // Em dias
+	Compress   bool
+}
+
+// Init inicializa os loggers. Com Output file ou both, os logs são gravados em um arquivo
+// rotacionado ao atingir MaxSize; com both, também continuam no stdout/stderr.
+func Init(opts Options) error {
 	// Configuração com timestamp mais detalhado
 	flags := log.Ldate | log.Ltime | log.Lmicroseconds
 
-	InfoLogger = log.New(os.Stdout, "INFO: ", flags)
-	WarningLogger = log.New(os.Stdout, "WARNING: ", flags)
-	ErrorLogger = log.New(os.Stderr, "ERROR: ", flags)
-	DebugLogger = log.New(os.Stdout, "DEBUG: ", flags)
+	out, errOut, file, err := openOutputs(opts)
+	if err != nil {
+		return err
+	}
+
+	if logFile != nil {
+		logFile.Close()
+	}
+	output, errorOutput, logFile = out, errOut, file
+
+	InfoLogger = log.New(output, "INFO: ", flags)
+	WarningLogger = log.New(output, "WARNING: ", flags)
+	ErrorLogger = log.New(errorOutput, "ERROR: ", flags)
+	DebugLogger = log.New(output, "DEBUG: ", flags)
+
+	if StructuredLog != nil {
+		InitStructuredLogger()
+	}
+	return nil
+}
+
+// openOutputs monta os destinos de log conforme Options.Output
+func openOutputs(opts Options) (io.Writer, io.Writer, *lumberjack.Logger, error) {
+	switch strings.ToLower(strings.TrimSpace(opts.Output)) {
+	case "", OutputStdout:
+		return os.Stdout, os.Stderr, nil, nil
+	case OutputFile, OutputBoth:
+	default:
+		return nil, nil, nil, fmt.Errorf("saída de log inválida: %q (use stdout, file ou both)", opts.Output)
+	}
+
+	if strings.TrimSpace(opts.File) == "" {
+		return nil, nil, nil, fmt.Errorf("informe o arquivo de log para a saída %q", opts.Output)
+	}
+
+	file := &lumberjack.Logger{
+		Filename:   opts.File,
+		MaxSize:    opts.MaxSize,
+		MaxBackups: opts.MaxBackups,
+		MaxAge:     opts.MaxAge,
+		Compress:   opts.Compress,
+		LocalTime:  true,
+	}
+	// Abrir o arquivo já na inicialização para falhar cedo em caso de caminho inválido
+	if _, err := file.Write(nil); err != nil {
+		return nil, nil, nil, fmt.Errorf("não foi possível abrir o arquivo de log: %w", err)
+	}
+
+	if strings.EqualFold(strings.TrimSpace(opts.Output), OutputBoth) {
+		return io.MultiWriter(os.Stdout, file), io.MultiWriter(os.Stderr, file), file, nil
+	}
+	return file, file, file, nil
+}
+
+// Close fecha o arquivo de log, se houver
+func Close() error {
+	if logFile == nil {
+		return nil
+	}
+	return logFile.Close()
+}
+
+// SetFormat define o formato dos logs com campos estruturados ("text" ou "json").
+// No formato JSON, WithFields e LogError passam a usar o StructuredLog.
+func SetFormat(format string) {
+	jsonFormat = strings.EqualFold(format, "json")
+	if jsonFormat && StructuredLog == nil {
+		InitStructuredLogger()
+	}
+}
+
+// IsJSONFormat indica se os logs estão configurados para o formato JSON
+func IsJSONFormat() bool {
+	return jsonFormat
 }
 
 // Info registra uma mensagem de informação
@@ -73,6 +173,15 @@ func Debugf(format string, v ...interface{}) {
 
 // WithFields registra uma mensagem com campos estruturados
 func WithFields(level string, message string, fields map[string]interface{}) {
+	if jsonFormat && StructuredLog != nil {
+		level = strings.ToUpper(level)
+		if level == "DEBUG" && !isDebugMode() {
+			return
+		}
+		StructuredLog.Log(level, message, fields, nil)
+		return
+	}
+
 	var logger *log.Logger
 
 	switch strings.ToUpper(level) {
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initForTest inicializa os loggers e restaura a saída padrão ao fim do teste
+func initForTest(t *testing.T, opts Options) {
+	t.Helper()
+	if err := Init(opts); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := Init(Options{}); err != nil {
+			t.Errorf("Init: %v", err)
+		}
+	})
+}
+
+func TestInitRejectsInvalidOutput(t *testing.T) {
+	// Um arquivo comum no lugar do diretório impede a criação do arquivo de log
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "saída desconhecida", opts: Options{Output: "syslog"}},
+		{name: "arquivo sem caminho", opts: Options{Output: OutputFile}},
+		{name: "diretório inacessível", opts: Options{Output: OutputFile, File: filepath.Join(blocker, "app.log")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, err := openOutputs(tt.opts); err == nil {
+				t.Fatalf("openOutputs(%+v) = nil, want erro", tt.opts)
+			}
+		})
+	}
+}
+
+func TestInitRotatesLogFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.log")
+	initForTest(t, Options{Output: OutputFile, File: file, MaxSize: 1})
+
+	if _, err := os.Stat(file); err != nil {
+		t.Fatalf("o arquivo de log deveria ser criado no Init: %v", err)
+	}
+
+	// Linhas suficientes para passar de 1 MB
+	line := strings.Repeat("x", 1024)
+	for i := 0; i < 1100; i++ {
+		Info(line)
+	}
+	if err := Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("backups = %v, want 1 arquivo rotacionado", backups)
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > 1024*1024 {
+		t.Fatalf("arquivo atual com %d bytes, want no máximo 1 MB", info.Size())
+	}
+}
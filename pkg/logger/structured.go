@@ -3,7 +3,6 @@
 import (
 	"encoding/json"
 	"log"
-	"os"
 	"time"
 )
 
@@ -25,7 +24,7 @@ type LogEntry struct {
 // NewStructuredLogger creates a new structured logger
 func NewStructuredLogger() *StructuredLogger {
 	return &StructuredLogger{
-		logger: log.New(os.Stdout, "", 0),
+		logger: log.New(output, "", 0),
 	}
 }
 
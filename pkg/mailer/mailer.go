@@ -0,0 +1,76 @@
+package mailer
+
+import (
+	"crm-backend/pkg/logger"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Mailer define a interface para envio de emails
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// SMTPConfig contém as configurações do servidor SMTP
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer envia emails através de um servidor SMTP
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer cria um novo Mailer que utiliza SMTP
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{config: config}
+}
+
+// Send envia um email de texto simples
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + m.config.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(addr, auth, m.config.From, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("falha ao enviar email para %s: %w", to, err)
+	}
+	return nil
+}
+
+// LogMailer apenas registra os emails no log (útil em desenvolvimento)
+type LogMailer struct{}
+
+// NewLogMailer cria um novo Mailer que apenas registra os emails no log
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send registra o email no log em vez de enviá-lo
+func (m *LogMailer) Send(to, subject, body string) error {
+	logger.WithFields("INFO", "Email Not Sent (LogMailer)", map[string]interface{}{
+		"to":      to,
+		"subject": subject,
+		"body":    body,
+	})
+	return nil
+}
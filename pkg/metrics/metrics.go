@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry reúne as métricas da aplicação, além das métricas do runtime Go e do processo,
+// e as expõe no formato do Prometheus
+type Registry struct {
+	registry  *prometheus.Registry
+	requests  *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	durations *prometheus.HistogramVec
+	dbQueries *prometheus.CounterVec
+}
+
+// NewRegistry cria um registro de métricas com as séries da aplicação e os coletores de Go e do processo
+func NewRegistry() *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total de requisições HTTP por método, rota e status.",
+		}, []string{"method", "route", "status"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_request_errors_total",
+			Help: "Total de requisições HTTP com status >= 400 por método, rota e status.",
+		}, []string{"method", "route", "status"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latência das requisições HTTP em segundos por método e rota.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		dbQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_queries_total",
+			Help: "Total de consultas ao banco de dados por operação.",
+		}, []string{"operation"}),
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.requests,
+		r.errors,
+		r.durations,
+		r.dbQueries,
+	)
+	return r
+}
+
+// ObserveRequest registra uma requisição HTTP concluída. route deve ser o modelo da rota
+// (ex.: /api/contacts/:id), nunca o caminho bruto, para limitar o número de séries.
+// Respostas com status >= 400 também são contadas como erro.
+func (r *Registry) ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	r.requests.WithLabelValues(method, route, code).Inc()
+	if status >= 400 {
+		r.errors.WithLabelValues(method, route, code).Inc()
+	}
+	r.durations.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// IncDBQuery registra uma consulta ao banco de dados do tipo informado (create, query, update, delete, row, raw)
+func (r *Registry) IncDBQuery(operation string) {
+	r.dbQueries.WithLabelValues(operation).Inc()
+}
+
+// Handler retorna um http.Handler que expõe as métricas no formato do Prometheus
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
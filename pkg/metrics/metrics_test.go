@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistryHandler(t *testing.T) {
+	registry := NewRegistry()
+	registry.ObserveRequest(http.MethodGet, "/api/contacts/:id", http.StatusOK, 20*time.Millisecond)
+	registry.ObserveRequest(http.MethodGet, "/api/contacts/:id", http.StatusNotFound, 3*time.Millisecond)
+	registry.IncDBQuery("query")
+	registry.IncDBQuery("query")
+
+	w := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	body, _ := io.ReadAll(w.Body)
+
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/api/contacts/:id",status="200"} 1`,
+		`http_requests_total{method="GET",route="/api/contacts/:id",status="404"} 1`,
+		`http_request_errors_total{method="GET",route="/api/contacts/:id",status="404"} 1`,
+		`http_request_duration_seconds_bucket{method="GET",route="/api/contacts/:id",le="0.005"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="/api/contacts/:id"} 2`,
+		`db_queries_total{operation="query"} 2`,
+		"go_goroutines ",
+		"process_start_time_seconds ",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("métricas sem %q", want)
+		}
+	}
+	if strings.Contains(string(body), `http_request_errors_total{method="GET",route="/api/contacts/:id",status="200"}`) {
+		t.Error("respostas 2xx não deveriam ser contadas como erro")
+	}
+}
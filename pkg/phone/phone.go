@@ -0,0 +1,111 @@
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidNumber indica um telefone que não pode ser um número válido
+var ErrInvalidNumber = errors.New("número de telefone inválido")
+
+// Limites de dígitos aceitos (E.164 permite no máximo 15, incluindo o código do país)
+const (
+	minNationalDigits      = 6 // Sem região conhecida
+	minInternationalDigits = 8
+	maxInternationalDigits = 15
+)
+
+// region descreve o código do país e o tamanho do número nacional (sem o prefixo de longa distância)
+type region struct {
+	callingCode string
+	minDigits   int
+	maxDigits   int
+}
+
+// regions lista as regiões conhecidas pelo código ISO 3166-1 alfa-2
+var regions = map[string]region{
+	"BR": {callingCode: "55", minDigits: 10, maxDigits: 11},
+	"US": {callingCode: "1", minDigits: 10, maxDigits: 10},
+	"CA": {callingCode: "1", minDigits: 10, maxDigits: 10},
+	"PT": {callingCode: "351", minDigits: 9, maxDigits: 9},
+	"ES": {callingCode: "34", minDigits: 9, maxDigits: 9},
+	"AR": {callingCode: "54", minDigits: 10, maxDigits: 10},
+	"MX": {callingCode: "52", minDigits: 10, maxDigits: 10},
+	"GB": {callingCode: "44", minDigits: 10, maxDigits: 10},
+}
+
+// IsKnownRegion informa se a região (código ISO de duas letras) é suportada por Normalize
+func IsKnownRegion(code string) bool {
+	_, ok := regions[strings.ToUpper(code)]
+	return ok
+}
+
+// Normalize padroniza um telefone no formato E.164 (+<código do país><número>) quando o país pode ser
+// inferido: pelo prefixo internacional (+ ou 00) ou pela região padrão informada.
+// Espaços, hífens, pontos e parênteses são descartados. Sem região conhecida, números nacionais
+// são retornados apenas com os dígitos. Retorna ErrInvalidNumber se houver outros caracteres
+// ou se a quantidade de dígitos não for plausível.
+func Normalize(raw, defaultRegion string) (string, error) {
+	cleaned := strings.TrimSpace(raw)
+	if cleaned == "" {
+		return "", nil
+	}
+
+	international := false
+	if strings.HasPrefix(cleaned, "+") {
+		international = true
+		cleaned = cleaned[1:]
+	}
+
+	var digits strings.Builder
+	for _, r := range cleaned {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+			continue
+		default:
+			return "", ErrInvalidNumber
+		}
+	}
+	number := digits.String()
+
+	if !international && strings.HasPrefix(number, "00") {
+		international = true
+		number = number[2:]
+	}
+	if international {
+		return formatInternational(number)
+	}
+
+	reg, ok := regions[strings.ToUpper(defaultRegion)]
+	if !ok {
+		if len(number) < minNationalDigits || len(number) > maxInternationalDigits {
+			return "", ErrInvalidNumber
+		}
+		return number, nil
+	}
+
+	// Número já com o código do país, sem o "+"
+	if strings.HasPrefix(number, reg.callingCode) {
+		national := len(number) - len(reg.callingCode)
+		if national >= reg.minDigits && national <= reg.maxDigits {
+			return "+" + number, nil
+		}
+	}
+
+	// Prefixo de longa distância nacional (ex.: 0 no Brasil)
+	national := strings.TrimLeft(number, "0")
+	if len(national) < reg.minDigits || len(national) > reg.maxDigits {
+		return "", ErrInvalidNumber
+	}
+	return formatInternational(reg.callingCode + national)
+}
+
+// formatInternational valida a quantidade de dígitos de um número com código do país e o prefixa com "+"
+func formatInternational(number string) (string, error) {
+	if len(number) < minInternationalDigits || len(number) > maxInternationalDigits || number[0] == '0' {
+		return "", ErrInvalidNumber
+	}
+	return "+" + number, nil
+}
@@ -0,0 +1,83 @@
+// Package validation aplica as regras declaradas nas tags `validate` dos modelos de requisição,
+// para que os services as façam valer independentemente do handler que os chamou.
+package validation
+
+import (
+	"crm-backend/pkg/errors"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate é a instância compartilhada do validador (segura para uso concorrente e com cache das structs)
+var validate = newValidator()
+
+// newValidator cria o validador, identificando os campos pelo nome usado na API (tag json ou form)
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	return v
+}
+
+// Struct valida uma struct (ou ponteiro para struct) pelas tags validate. Retorna nil quando os dados
+// são válidos ou um erro 400 com a mensagem de cada campo inválido (ex.: "type: deve ser um dos
+// valores: CLIENT, LEAD").
+func Struct(s interface{}) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if !stderrors.As(err, &fieldErrors) {
+		// Valor que não é uma struct: erro de programação, não do cliente
+		return errors.ErrInternalServer
+	}
+
+	messages := make([]string, len(fieldErrors))
+	for i, fieldError := range fieldErrors {
+		messages[i] = fieldError.Field() + ": " + message(fieldError)
+	}
+	return errors.NewBadRequestError(strings.Join(messages, "; "))
+}
+
+// message traduz a regra violada por um campo
+func message(fieldError validator.FieldError) string {
+	param := fieldError.Param()
+
+	switch fieldError.Tag() {
+	case "required":
+		return "é obrigatório"
+	case "email":
+		return "deve ser um email válido"
+	case "oneof":
+		return "deve ser um dos valores: " + strings.Join(strings.Fields(param), ", ")
+	case "min":
+		return sizeMessage(fieldError.Kind(), "no mínimo", "maior ou igual a", param)
+	case "max":
+		return sizeMessage(fieldError.Kind(), "no máximo", "menor ou igual a", param)
+	}
+	return fmt.Sprintf("é inválido (regra %s)", fieldError.Tag())
+}
+
+// sizeMessage descreve os limites min/max conforme o tipo do campo: caracteres em textos, itens em
+// listas e valor em números
+func sizeMessage(kind reflect.Kind, length, value, param string) string {
+	switch kind {
+	case reflect.String:
+		return fmt.Sprintf("deve ter %s %s caracteres", length, param)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("deve ter %s %s itens", length, param)
+	}
+	return fmt.Sprintf("deve ser %s %s", value, param)
+}
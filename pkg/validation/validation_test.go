@@ -0,0 +1,80 @@
+package validation
+
+import (
+	"crm-backend/pkg/errors"
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type testRequest struct {
+	Name     string   `json:"name" validate:"required,min=2,max=10"`
+	Email    string   `json:"email,omitempty" validate:"omitempty,email"`
+	Type     string   `json:"type" validate:"required,oneof=CLIENT LEAD"`
+	Priority string   `form:"priority" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
+	Tags     []string `json:"tags,omitempty" validate:"omitempty,max=2"`
+	Amount   int      `json:"amount" validate:"min=0"`
+}
+
+func TestStruct(t *testing.T) {
+	valid := testRequest{Name: "Ana", Type: "CLIENT"}
+
+	tests := []struct {
+		name    string
+		mutate  func(r *testRequest)
+		wantMsg string // Detalhes esperados (vazio: válido)
+	}{
+		{name: "válido", mutate: func(r *testRequest) {}},
+		{name: "enum inválido", mutate: func(r *testRequest) { r.Type = "PARTNER" }, wantMsg: "type: deve ser um dos valores: CLIENT, LEAD"},
+		{name: "enum inválido em campo de query", mutate: func(r *testRequest) { r.Priority = "URGENT" }, wantMsg: "priority: deve ser um dos valores: LOW, MEDIUM, HIGH"},
+		{name: "obrigatório ausente", mutate: func(r *testRequest) { r.Name = "" }, wantMsg: "name: é obrigatório"},
+		{name: "texto curto", mutate: func(r *testRequest) { r.Name = "A" }, wantMsg: "name: deve ter no mínimo 2 caracteres"},
+		{name: "texto longo", mutate: func(r *testRequest) { r.Name = "Nome muito longo" }, wantMsg: "name: deve ter no máximo 10 caracteres"},
+		{name: "email inválido", mutate: func(r *testRequest) { r.Email = "ana" }, wantMsg: "email: deve ser um email válido"},
+		{name: "lista longa", mutate: func(r *testRequest) { r.Tags = []string{"a", "b", "c"} }, wantMsg: "tags: deve ter no máximo 2 itens"},
+		{name: "número abaixo do mínimo", mutate: func(r *testRequest) { r.Amount = -1 }, wantMsg: "amount: deve ser maior ou igual a 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.mutate(&req)
+
+			err := Struct(&req)
+			if tt.wantMsg == "" {
+				if err != nil {
+					t.Fatalf("Struct = %v, want nil", err)
+				}
+				return
+			}
+
+			var appErr *errors.AppError
+			if !stderrors.As(err, &appErr) || appErr.Code != http.StatusBadRequest {
+				t.Fatalf("Struct = %v, want 400", err)
+			}
+			if appErr.Details != tt.wantMsg {
+				t.Fatalf("detalhes = %q, want %q", appErr.Details, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestStructJoinsFieldErrors(t *testing.T) {
+	err := Struct(&testRequest{Name: "", Type: "X"})
+
+	var appErr *errors.AppError
+	if !stderrors.As(err, &appErr) {
+		t.Fatalf("Struct = %v, want AppError", err)
+	}
+	if !strings.Contains(appErr.Details, "name: é obrigatório") || !strings.Contains(appErr.Details, "; type: ") {
+		t.Fatalf("detalhes = %q, want os dois campos separados por ';'", appErr.Details)
+	}
+}
+
+func TestStructRejectsNonStruct(t *testing.T) {
+	var appErr *errors.AppError
+	if err := Struct("texto"); !stderrors.As(err, &appErr) || appErr.Code != http.StatusInternalServerError {
+		t.Fatalf("Struct(string) = %v, want 500", err)
+	}
+}